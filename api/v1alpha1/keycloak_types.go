@@ -15,6 +15,36 @@ type KeycloakSpec struct {
 	// Contains configuration for external Keycloak instances. Unmanaged needs to be set to true to use this.
 	// +optional
 	External KeycloakExternal `json:"external"`
+	// The realm the operator authenticates against when calling the admin API. Defaults to master.
+	// Allows using credentials which only have realm-management roles in a single realm.
+	// +optional
+	AdminRealm string `json:"adminRealm,omitempty"`
+	// The token endpoint the operator requests its access tokens from.
+	// If not set, the token endpoint of the admin realm is used.
+	// +optional
+	TokenURL string `json:"tokenURL,omitempty"`
+	// Controls how the operator calls the admin API of this Keycloak, i.e. timeouts, retries and rate limiting.
+	// +optional
+	AdminAPI KeycloakAdminAPI `json:"adminAPI,omitempty"`
+}
+
+type KeycloakAdminAPI struct {
+	// Timeout of a single request against Keycloak. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// How often a request is retried if Keycloak is temporarily unavailable. Only idempotent requests are
+	// retried, unless Keycloak answers with 429 Too Many Requests. Defaults to 3, 0 disables retries.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// Maximum number of requests per second the operator sends to this Keycloak. Unlimited if not set.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int32 `json:"requestsPerSecond,omitempty"`
+	// Maximum number of requests sent in a burst before requestsPerSecond applies. Defaults to requestsPerSecond.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Burst int32 `json:"burst,omitempty"`
 }
 
 type KeycloakExternal struct {
@@ -24,12 +54,17 @@ type KeycloakExternal struct {
 	// The URL to use for the keycloak admin API. Needs to be set if external is true.
 	// +optional
 	URL string `json:"url,omitempty"`
+	// The context path Keycloak is served from, e.g. "/auth" for Keycloak 16 and older or "/" for Keycloak 17+ (Quarkus).
+	// If not set, the context path is detected automatically.
+	// +optional
+	ContextPath string `json:"contextPath,omitempty"`
 }
 
 // KeycloakStatus defines the observed state of Keycloak.
 // +k8s:openapi-gen=true
 type KeycloakStatus struct {
-	// Current phase of the operator.
+	// Current phase of the operator. Deprecated: use the Ready condition instead, the phase stays reconciling after
+	// a successful reconcile.
 	Phase StatusPhase `json:"phase"`
 	// Human-readable message indicating details about current operator phase or error.
 	Message string `json:"message"`
@@ -37,8 +72,25 @@ type KeycloakStatus struct {
 	Ready bool `json:"ready"`
 	// A map of all the secondary resources types and names created for this CR. e.g "Deployment": [ "DeploymentName1", "DeploymentName2" ].
 	SecondaryResources map[string][]string `json:"secondaryResources,omitempty"`
-	// Version of Keycloak or RHSSO running on the cluster.
+	// Conditions of the resource, e.g. Ready.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Generation of the resource the status was last computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Time of the last successful reconcile.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Version of Keycloak or RHSSO running on the cluster, as reported by the server info of its admin API.
 	Version string `json:"version"`
+	// Features enabled on the Keycloak server, as reported by the server info of its admin API.
+	// +optional
+	Features []string `json:"features,omitempty"`
+	// Types of the protocol mappers available on the Keycloak server by protocol, e.g. "openid-connect".
+	// +optional
+	ProtocolMapperTypes map[string][]string `json:"protocolMapperTypes,omitempty"`
 	// External URL for accessing Keycloak instance from outside the cluster. Is identical to external.URL if it's specified, otherwise is computed (e.g. from Ingress).
 	ExternalURL string `json:"externalURL,omitempty"`
 	// The secret where the admin credentials are to be found.
@@ -54,11 +106,45 @@ var (
 	PhaseInitialising StatusPhase = "initialising"
 )
 
+// Types of the conditions in the status of Keycloaks, KeycloakRealms and KeycloakClients.
+const (
+	// ConditionReady is true if the last reconcile of the resource succeeded.
+	ConditionReady = "Ready"
+	// ConditionSynced is true if the resource has been applied to all matching Keycloaks.
+	ConditionSynced = "Synced"
+	// ConditionKeycloakReachable is true if the controller could authenticate against the admin API of Keycloak.
+	ConditionKeycloakReachable = "KeycloakReachable"
+	// ConditionSecretReady is true if the client secret of a KeycloakClient has been written.
+	ConditionSecretReady = "SecretReady"
+	// ConditionDrifted is true if the client in Keycloak differed from a KeycloakClient applied before, e.g. after a
+	// change in the admin console.
+	ConditionDrifted = "Drifted"
+)
+
+// Reasons of the conditions in the status of Keycloaks, KeycloakRealms and KeycloakClients.
+const (
+	ReasonReconciled          = "Reconciled"
+	ReasonReconcileFailed     = "ReconcileFailed"
+	ReasonInitialising        = "Initialising"
+	ReasonKeycloakReachable   = "KeycloakReachable"
+	ReasonKeycloakUnreachable = "KeycloakUnreachable"
+	ReasonSecretWritten       = "SecretWritten"
+	ReasonNoDrift             = "NoDrift"
+	ReasonDriftCorrected      = "DriftCorrected"
+	ReasonDriftDetected       = "DriftDetected"
+	ReasonDryRun              = "DryRun"
+)
+
 // Keycloak is the Schema for the keycloaks API.
 // +genclient
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Keycloak struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -82,3 +168,64 @@ func init() {
 func (i *Keycloak) UpdateStatusSecondaryResources(kind string, resourceName string) {
 	i.Status.SecondaryResources = UpdateStatusSecondaryResources(i.Status.SecondaryResources, kind, resourceName)
 }
+
+// KeycloakServerInfo is the part of the server info of the admin API used by the operator
+type KeycloakServerInfo struct {
+	SystemInfo  KeycloakSystemInfo  `json:"systemInfo"`
+	ProfileInfo KeycloakProfileInfo `json:"profileInfo"`
+	// Features of the server, only reported by Keycloak 24 and newer
+	// +optional
+	Features []KeycloakFeature `json:"features,omitempty"`
+	// Protocol mapper types by protocol
+	// +optional
+	ProtocolMapperTypes map[string][]KeycloakProtocolMapperType `json:"protocolMapperTypes,omitempty"`
+}
+
+type KeycloakSystemInfo struct {
+	// Version of the server, e.g. "25.0.6".
+	Version string `json:"version"`
+}
+
+type KeycloakProfileInfo struct {
+	// Features which are disabled.
+	// +optional
+	DisabledFeatures []string `json:"disabledFeatures,omitempty"`
+	// Preview features, whether enabled or not.
+	// +optional
+	PreviewFeatures []string `json:"previewFeatures,omitempty"`
+	// Experimental features, whether enabled or not.
+	// +optional
+	ExperimentalFeatures []string `json:"experimentalFeatures,omitempty"`
+}
+
+type KeycloakFeature struct {
+	// Name of the feature, e.g. "TOKEN_EXCHANGE".
+	Name string `json:"name"`
+	// Whether the feature is enabled.
+	Enabled bool `json:"enabled"`
+}
+
+type KeycloakProtocolMapperType struct {
+	// ID of the protocol mapper type, e.g. "oidc-usermodel-attribute-mapper".
+	ID string `json:"id"`
+	// Display name of the protocol mapper type.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Category of the protocol mapper type, e.g. "Token mapper".
+	// +optional
+	Category string `json:"category,omitempty"`
+}
+
+// KeycloakOpenIDConfiguration is the part of the OpenID Connect discovery document of a realm used by the operator
+type KeycloakOpenIDConfiguration struct {
+	// Issuer of the tokens of the realm.
+	Issuer string `json:"issuer"`
+	// +optional
+	AuthorizationEndpoint string `json:"authorization_endpoint,omitempty"`
+	// +optional
+	TokenEndpoint string `json:"token_endpoint,omitempty"`
+	// +optional
+	UserinfoEndpoint string `json:"userinfo_endpoint,omitempty"`
+	// +optional
+	JwksURI string `json:"jwks_uri,omitempty"`
+}
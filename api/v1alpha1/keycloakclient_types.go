@@ -28,6 +28,204 @@ type KeycloakClientSpec struct {
 	// Service account client roles for this client.
 	// +optional
 	ServiceAccountClientRoles map[string][]string `json:"serviceAccountClientRoles,omitempty"`
+	// Authentication of the confidential client at the token endpoint, clientSecret (default), privateKeyJWT or
+	// x509. With privateKeyJWT the controller generates a key pair, stores the private key in the client secret and
+	// registers the public key as JWKS of the client. With x509 the client authenticates with the certificate of
+	// the TLS Secret referenced by x509.
+	// +kubebuilder:validation:Enum=clientSecret;privateKeyJWT;x509
+	// +optional
+	ClientAuthentication string `json:"clientAuthentication,omitempty"`
+	// Key pair of a client with privateKeyJWT authentication.
+	// +optional
+	PrivateKeyJWT *KeycloakClientPrivateKeyJWT `json:"privateKeyJWT,omitempty"`
+	// Client certificate of a client with x509 authentication.
+	// +optional
+	X509 *KeycloakClientX509 `json:"x509,omitempty"`
+	// Settings of a client with protocol saml, applied as SAML attributes of the client.
+	// +optional
+	SAML *KeycloakClientSAML `json:"saml,omitempty"`
+	// Reference to a Secret holding the secret of the client. The secret is pushed to Keycloak and takes precedence
+	// over client.secret, but is never written into the CR. Cannot be combined with secretRotation.
+	// +optional
+	SecretRef *KeycloakClientSecretRef `json:"secretRef,omitempty"`
+	// Scheduled rotation of the client secret. The secret is never rotated if not set.
+	// +optional
+	SecretRotation *KeycloakClientSecretRotation `json:"secretRotation,omitempty"`
+	// Template of the secret generated for the client. Without template, the secret contains CLIENT_ID and
+	// CLIENT_SECRET.
+	// +optional
+	SecretTemplate *KeycloakClientSecretTemplate `json:"secretTemplate,omitempty"`
+	// Additional Secrets the credentials of the client are written to, e.g. in the namespace of a gateway. Targets in
+	// other namespaces must be allowed by the KeycloakRealm or the controller. Targets removed from the list are
+	// deleted.
+	// +optional
+	SecretTargets []KeycloakClientSecretTarget `json:"secretTargets,omitempty"`
+	// Documents of Keycloak installation providers for the client, e.g. keycloak.json, published in Secrets or
+	// ConfigMaps and refreshed on every reconcile.
+	// +optional
+	Installations []KeycloakClientInstallation `json:"installations,omitempty"`
+	// Handling of changes made to the client in Keycloak outside of the CR, e.g. in the admin console. With correct
+	// (default) they are overwritten, with reportOnly they are only reported in the Drifted condition and an Event.
+	// +kubebuilder:validation:Enum=correct;reportOnly
+	// +optional
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+}
+
+// KeycloakClientInstallation defines a document of an installation provider of Keycloak for the client and the key
+// of the Secret or ConfigMap it is published in
+type KeycloakClientInstallation struct {
+	// ID of the installation provider, e.g. "keycloak-oidc-keycloak-json", "keycloak-oidc-jboss-subsystem",
+	// "keycloak-saml", "saml-idp-descriptor", "saml-sp-descriptor" or "mod-auth-mellon".
+	// +kubebuilder:validation:Required
+	ProviderID string `json:"providerId"`
+	// Key of the document in the Secret or ConfigMap, e.g. "keycloak.json".
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+	// Kind of the object the document is published in. Defaults to ConfigMap for public clients and Secret otherwise,
+	// as the documents of confidential clients contain the client secret.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Name of the Secret or ConfigMap. Defaults to keycloak-client-installation-<name of the KeycloakClient>.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// KeycloakClientPrivateKeyJWT defines the key pair the controller generates for a client with privateKeyJWT
+// authentication.
+type KeycloakClientPrivateKeyJWT struct {
+	// Signature algorithm of the key pair, RS256 (default) or ES256.
+	// +kubebuilder:validation:Enum=RS256;ES256
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+	// Interval between two rotations of the key pair, e.g. "2160h". The key pair is never rotated if not set.
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+	// Period the public key of the previous key pair stays registered after a rotation, e.g. "1h", so that client
+	// assertions signed before the workloads reloaded the client secret are still accepted.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// KeycloakClientX509 references the certificate a client with x509 authentication authenticates with, e.g. issued by
+// cert-manager.
+type KeycloakClientX509 struct {
+	// Name of the kubernetes.io/tls Secret in the namespace of the KeycloakClient holding the client certificate in
+	// tls.crt. The subject DN of the certificate is registered with the client and updated when it is renewed.
+	// +kubebuilder:validation:Required
+	SecretName string `json:"secretName"`
+	// Bind the access tokens of the client to its certificate (RFC 8705). Defaults to true.
+	// +optional
+	CertificateBoundAccessTokens *bool `json:"certificateBoundAccessTokens,omitempty"`
+}
+
+// KeycloakClientSAML defines the settings of a SAML client.
+type KeycloakClientSAML struct {
+	// Sign the SAML documents of Keycloak. Defaults to true.
+	// +optional
+	SignDocuments *bool `json:"signDocuments,omitempty"`
+	// Sign the assertions of Keycloak.
+	// +optional
+	SignAssertions bool `json:"signAssertions,omitempty"`
+	// Signature algorithm of Keycloak, e.g. RSA_SHA256 (default).
+	// +kubebuilder:validation:Enum=RSA_SHA1;RSA_SHA256;RSA_SHA256_MGF1;RSA_SHA512;RSA_SHA512_MGF1;DSA_SHA1
+	// +optional
+	SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+	// Require the client to sign its requests with the key of the signing certificate.
+	// +optional
+	ClientSignatureRequired bool `json:"clientSignatureRequired,omitempty"`
+	// Name of the kubernetes.io/tls Secret in the namespace of the KeycloakClient with the certificate the
+	// signatures of the client are verified with, in tls.crt. Required if clientSignatureRequired is set.
+	// +optional
+	SigningCertificateSecretName string `json:"signingCertificateSecretName,omitempty"`
+	// Encrypt the assertions with the encryption certificate of the client.
+	// +optional
+	EncryptAssertions bool `json:"encryptAssertions,omitempty"`
+	// Name of the kubernetes.io/tls Secret in the namespace of the KeycloakClient with the certificate the
+	// assertions are encrypted for, in tls.crt. Required if encryptAssertions is set.
+	// +optional
+	EncryptionCertificateSecretName string `json:"encryptionCertificateSecretName,omitempty"`
+	// Name ID format of the subject, username (default), email, transient or persistent.
+	// +kubebuilder:validation:Enum=username;email;transient;persistent
+	// +optional
+	NameIDFormat string `json:"nameIDFormat,omitempty"`
+	// Ignore the name ID format requested by the client.
+	// +optional
+	ForceNameIDFormat bool `json:"forceNameIDFormat,omitempty"`
+	// Assertion consumer service URL of the POST binding.
+	// +optional
+	AssertionConsumerURLPost string `json:"assertionConsumerURLPost,omitempty"`
+	// Assertion consumer service URL of the redirect binding.
+	// +optional
+	AssertionConsumerURLRedirect string `json:"assertionConsumerURLRedirect,omitempty"`
+	// Single logout service URL of the POST binding.
+	// +optional
+	LogoutURLPost string `json:"logoutURLPost,omitempty"`
+	// Single logout service URL of the redirect binding.
+	// +optional
+	LogoutURLRedirect string `json:"logoutURLRedirect,omitempty"`
+	// Name of a ConfigMap in the namespace of the KeycloakClient the IdP metadata descriptor of the realm is
+	// published in, as idp-metadata.xml. Not published if not set.
+	// +optional
+	IdPDescriptorConfigMapName string `json:"idpDescriptorConfigMapName,omitempty"`
+}
+
+// KeycloakClientSecretTarget is an additional Secret the credentials of a client are written to.
+type KeycloakClientSecretTarget struct {
+	// Namespace of the Secret. Defaults to the namespace of the KeycloakClient.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the Secret.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Template of the Secret. Defaults to the secretTemplate of the KeycloakClient.
+	// +optional
+	Template *KeycloakClientSecretTemplate `json:"template,omitempty"`
+}
+
+// KeycloakClientSecretRef references the key of a Secret holding the secret of a confidential client.
+type KeycloakClientSecretRef struct {
+	// Name of the Secret.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Key of the client secret in the Secret. Defaults to CLIENT_SECRET.
+	// +optional
+	Key string `json:"key,omitempty"`
+	// Namespace of the Secret. Defaults to the namespace of the KeycloakClient. A Secret in another namespace must
+	// allow the namespace of the KeycloakClient in its keycloak.org/allowed-client-namespaces annotation, a comma
+	// separated list of namespaces or "*".
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// KeycloakClientSecretTemplate defines the keys, labels and annotations of the secret generated for a client.
+// Values are Go templates rendered with .ClientID, .ClientSecret, .PreviousClientSecret, .Realm, .KeycloakURL,
+// .IssuerURL, .TokenURL, .AuthURL, .UserinfoURL and .JWKSURL, e.g. "{{ .IssuerURL }}". Labels and annotations
+// cannot refer to the credentials, i.e. .ClientSecret, .PreviousClientSecret and .PrivateKey.
+type KeycloakClientSecretTemplate struct {
+	// Keys of the secret with the templates of their values. Defaults to CLIENT_ID and CLIENT_SECRET.
+	// +optional
+	Data map[string]string `json:"data,omitempty"`
+	// Labels added to the secret, with the templates of their values.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations added to the secret, with the templates of their values.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// KeycloakClientSecretRotation defines how often the secret of a confidential client is regenerated.
+// While rotation is enabled, the secret in Keycloak takes precedence over the secret of the client in the CR.
+type KeycloakClientSecretRotation struct {
+	// Interval between two rotations of the client secret, e.g. "720h".
+	// +kubebuilder:validation:Required
+	Interval metav1.Duration `json:"interval"`
+	// Period the previous secret is kept valid after a rotation. During the grace period the previous secret is
+	// published as CLIENT_SECRET_PREVIOUS in the client secret. Requires the client secret rotation of Keycloak,
+	// i.e. the client-secret-rotation feature and a client policy with the secret-rotation executor, otherwise the
+	// previous secret becomes invalid with the rotation.
+	// +optional
+	GracePeriod metav1.Duration `json:"gracePeriod,omitempty"`
 }
 
 // https://www.keycloak.org/docs-api/11.0/rest-api/index.html#_mappingsrepresentation
@@ -57,7 +255,8 @@ type ClientMappingsRepresentation struct {
 }
 
 type KeycloakAPIClient struct {
-	// Client ID. If not specified, automatically generated.
+	// Internal ID of the client in Keycloak. Deprecated: the controller keeps the ID in status.id and no longer
+	// writes it here. An ID written by earlier versions of the controller is only used until status.id is set.
 	// +optional
 	ID string `json:"id,omitempty"`
 	// Client ID.
@@ -353,7 +552,8 @@ type KeycloakScope struct {
 // KeycloakClientStatus defines the observed state of KeycloakClient
 // +k8s:openapi-gen=true
 type KeycloakClientStatus struct {
-	// Current phase of the operator.
+	// Current phase of the operator. Deprecated: use the Ready condition instead, the phase stays reconciling after
+	// a successful reconcile.
 	Phase StatusPhase `json:"phase"`
 	// Human-readable message indicating details about current operator phase or error.
 	Message string `json:"message"`
@@ -361,6 +561,81 @@ type KeycloakClientStatus struct {
 	Ready bool `json:"ready"`
 	// A map of all the secondary resources types and names created for this CR. e.g "Deployment": [ "DeploymentName1", "DeploymentName2" ]
 	SecondaryResources map[string][]string `json:"secondaryResources,omitempty"`
+	// Conditions of the resource, e.g. Ready.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Generation of the resource the status was last computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Time of the last successful reconcile.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Internal ID of the client in Keycloak. If not set or not found, the client is looked up by its clientId.
+	// Refers to the first target if the client is reconciled with several realms or Keycloaks.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Realm of Keycloak the client with the ID belongs to.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+	// Internal IDs of the roles of the client in Keycloak by name.
+	// +optional
+	RoleIDs map[string]string `json:"roleIDs,omitempty"`
+	// State of the client in each realm and Keycloak matched by the realm selector.
+	// +optional
+	Targets []KeycloakClientTarget `json:"targets,omitempty"`
+	// Time of the last rotation of the client secret.
+	// +optional
+	LastSecretRotation *metav1.Time `json:"lastSecretRotation,omitempty"`
+	// Time the secret rotation was enabled, the first rotation is due one interval later.
+	// +optional
+	SecretRotationStart *metav1.Time `json:"secretRotationStart,omitempty"`
+	// Time the key pair of a client with privateKeyJWT authentication was generated.
+	// +optional
+	LastKeyPairRotation *metav1.Time `json:"lastKeyPairRotation,omitempty"`
+	// Expiry of the client certificate of a client with x509 authentication.
+	// +optional
+	CertificateNotAfter *metav1.Time `json:"certificateNotAfter,omitempty"`
+}
+
+// KeycloakClientTarget is the state of the client in one of the realms and Keycloaks it is reconciled with.
+type KeycloakClientTarget struct {
+	// Namespace and name of the Keycloak CR.
+	Keycloak string `json:"keycloak"`
+	// Namespace and name of the KeycloakRealm CR.
+	KeycloakRealm string `json:"keycloakRealm"`
+	// Name of the realm in Keycloak.
+	Realm string `json:"realm"`
+	// Internal ID of the client in this realm of Keycloak.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Name of the Secret with the credentials of the client for this target.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// True if the last reconcile of this target succeeded.
+	Synced bool `json:"synced"`
+	// Error of the last reconcile of this target.
+	// +optional
+	Error string `json:"error,omitempty"`
+	// Time of the last successful reconcile of this target.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Time of the last rotation of the client secret in this target.
+	// +optional
+	LastSecretRotation *metav1.Time `json:"lastSecretRotation,omitempty"`
+	// Time the secret rotation was enabled for this target, the first rotation is due one interval later.
+	// +optional
+	SecretRotationStart *metav1.Time `json:"secretRotationStart,omitempty"`
+	// Time the key pair of this target was generated.
+	// +optional
+	LastKeyPairRotation *metav1.Time `json:"lastKeyPairRotation,omitempty"`
+	// Fields of the client in Keycloak which differed from the CR in the last reconcile of this target.
+	// +optional
+	Drift []string `json:"drift,omitempty"`
+	// Actions the controller would run for this target, planned in a dry run instead of running them.
+	// +optional
+	Plan []string `json:"plan,omitempty"`
 }
 
 // KeycloakClient is the Schema for the keycloakclients API.
@@ -368,6 +643,10 @@ type KeycloakClientStatus struct {
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason"
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type KeycloakClient struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -17,6 +17,10 @@ type KeycloakRealmSpec struct {
 	// Keycloak Realm REST object.
 	// +kubebuilder:validation:Required
 	Realm *KeycloakAPIRealm `json:"realm"`
+	// Namespaces the KeycloakClients of the realm may write the secretTargets of their credentials to, besides
+	// their own namespace. "*" allows all namespaces.
+	// +optional
+	AllowedSecretTargetNamespaces []string `json:"allowedSecretTargetNamespaces,omitempty"`
 }
 
 type KeycloakAPIRealm struct {
@@ -175,7 +179,8 @@ type TokenResponse struct {
 // KeycloakRealmStatus defines the observed state of KeycloakRealm
 // +k8s:openapi-gen=true
 type KeycloakRealmStatus struct {
-	// Current phase of the operator.
+	// Current phase of the operator. Deprecated: use the Ready condition instead, the phase stays reconciling after
+	// a successful reconcile.
 	Phase StatusPhase `json:"phase"`
 	// Human-readable message indicating details about current operator phase or error.
 	Message string `json:"message"`
@@ -183,6 +188,17 @@ type KeycloakRealmStatus struct {
 	Ready bool `json:"ready"`
 	// A map of all the secondary resources types and names created for this CR. e.g "Deployment": [ "DeploymentName1", "DeploymentName2" ]
 	SecondaryResources map[string][]string `json:"secondaryResources,omitempty"`
+	// Conditions of the resource, e.g. Ready.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Generation of the resource the status was last computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Time of the last successful reconcile.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 	// TODO
 	LoginURL string `json:"loginURL"`
 }
@@ -192,6 +208,10 @@ type KeycloakRealmStatus struct {
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason"
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type KeycloakRealm struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -68,7 +68,7 @@ func (in *Keycloak) DeepCopyInto(out *Keycloak) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -267,6 +267,31 @@ func (in *KeycloakAPIUser) DeepCopy() *KeycloakAPIUser {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakAdminAPI) DeepCopyInto(out *KeycloakAdminAPI) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAdminAPI.
+func (in *KeycloakAdminAPI) DeepCopy() *KeycloakAdminAPI {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakAdminAPI)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakClient) DeepCopyInto(out *KeycloakClient) {
 	*out = *in
@@ -294,6 +319,21 @@ func (in *KeycloakClient) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientInstallation) DeepCopyInto(out *KeycloakClientInstallation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientInstallation.
+func (in *KeycloakClientInstallation) DeepCopy() *KeycloakClientInstallation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientInstallation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakClientList) DeepCopyInto(out *KeycloakClientList) {
 	*out = *in
@@ -326,6 +366,51 @@ func (in *KeycloakClientList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientPrivateKeyJWT) DeepCopyInto(out *KeycloakClientPrivateKeyJWT) {
+	*out = *in
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientPrivateKeyJWT.
+func (in *KeycloakClientPrivateKeyJWT) DeepCopy() *KeycloakClientPrivateKeyJWT {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientPrivateKeyJWT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientSAML) DeepCopyInto(out *KeycloakClientSAML) {
+	*out = *in
+	if in.SignDocuments != nil {
+		in, out := &in.SignDocuments, &out.SignDocuments
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientSAML.
+func (in *KeycloakClientSAML) DeepCopy() *KeycloakClientSAML {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientSAML)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakClientScope) DeepCopyInto(out *KeycloakClientScope) {
 	*out = *in
@@ -355,6 +440,94 @@ func (in *KeycloakClientScope) DeepCopy() *KeycloakClientScope {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientSecretRef) DeepCopyInto(out *KeycloakClientSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientSecretRef.
+func (in *KeycloakClientSecretRef) DeepCopy() *KeycloakClientSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientSecretRotation) DeepCopyInto(out *KeycloakClientSecretRotation) {
+	*out = *in
+	out.Interval = in.Interval
+	out.GracePeriod = in.GracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientSecretRotation.
+func (in *KeycloakClientSecretRotation) DeepCopy() *KeycloakClientSecretRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientSecretRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientSecretTarget) DeepCopyInto(out *KeycloakClientSecretTarget) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(KeycloakClientSecretTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientSecretTarget.
+func (in *KeycloakClientSecretTarget) DeepCopy() *KeycloakClientSecretTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientSecretTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientSecretTemplate) DeepCopyInto(out *KeycloakClientSecretTemplate) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientSecretTemplate.
+func (in *KeycloakClientSecretTemplate) DeepCopy() *KeycloakClientSecretTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientSecretTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakClientSpec) DeepCopyInto(out *KeycloakClientSpec) {
 	*out = *in
@@ -401,6 +574,48 @@ func (in *KeycloakClientSpec) DeepCopyInto(out *KeycloakClientSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PrivateKeyJWT != nil {
+		in, out := &in.PrivateKeyJWT, &out.PrivateKeyJWT
+		*out = new(KeycloakClientPrivateKeyJWT)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.X509 != nil {
+		in, out := &in.X509, &out.X509
+		*out = new(KeycloakClientX509)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SAML != nil {
+		in, out := &in.SAML, &out.SAML
+		*out = new(KeycloakClientSAML)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(KeycloakClientSecretRef)
+		**out = **in
+	}
+	if in.SecretRotation != nil {
+		in, out := &in.SecretRotation, &out.SecretRotation
+		*out = new(KeycloakClientSecretRotation)
+		**out = **in
+	}
+	if in.SecretTemplate != nil {
+		in, out := &in.SecretTemplate, &out.SecretTemplate
+		*out = new(KeycloakClientSecretTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretTargets != nil {
+		in, out := &in.SecretTargets, &out.SecretTargets
+		*out = make([]KeycloakClientSecretTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Installations != nil {
+		in, out := &in.Installations, &out.Installations
+		*out = make([]KeycloakClientInstallation, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientSpec.
@@ -432,6 +647,47 @@ func (in *KeycloakClientStatus) DeepCopyInto(out *KeycloakClientStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RoleIDs != nil {
+		in, out := &in.RoleIDs, &out.RoleIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]KeycloakClientTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSecretRotation != nil {
+		in, out := &in.LastSecretRotation, &out.LastSecretRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.SecretRotationStart != nil {
+		in, out := &in.SecretRotationStart, &out.SecretRotationStart
+		*out = (*in).DeepCopy()
+	}
+	if in.LastKeyPairRotation != nil {
+		in, out := &in.LastKeyPairRotation, &out.LastKeyPairRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.CertificateNotAfter != nil {
+		in, out := &in.CertificateNotAfter, &out.CertificateNotAfter
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientStatus.
@@ -444,6 +700,67 @@ func (in *KeycloakClientStatus) DeepCopy() *KeycloakClientStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientTarget) DeepCopyInto(out *KeycloakClientTarget) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSecretRotation != nil {
+		in, out := &in.LastSecretRotation, &out.LastSecretRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.SecretRotationStart != nil {
+		in, out := &in.SecretRotationStart, &out.SecretRotationStart
+		*out = (*in).DeepCopy()
+	}
+	if in.LastKeyPairRotation != nil {
+		in, out := &in.LastKeyPairRotation, &out.LastKeyPairRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientTarget.
+func (in *KeycloakClientTarget) DeepCopy() *KeycloakClientTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientX509) DeepCopyInto(out *KeycloakClientX509) {
+	*out = *in
+	if in.CertificateBoundAccessTokens != nil {
+		in, out := &in.CertificateBoundAccessTokens, &out.CertificateBoundAccessTokens
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientX509.
+func (in *KeycloakClientX509) DeepCopy() *KeycloakClientX509 {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientX509)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakCredential) DeepCopyInto(out *KeycloakCredential) {
 	*out = *in
@@ -474,6 +791,21 @@ func (in *KeycloakExternal) DeepCopy() *KeycloakExternal {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakFeature) DeepCopyInto(out *KeycloakFeature) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakFeature.
+func (in *KeycloakFeature) DeepCopy() *KeycloakFeature {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakFeature)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakList) DeepCopyInto(out *KeycloakList) {
 	*out = *in
@@ -506,6 +838,21 @@ func (in *KeycloakList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakOpenIDConfiguration) DeepCopyInto(out *KeycloakOpenIDConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakOpenIDConfiguration.
+func (in *KeycloakOpenIDConfiguration) DeepCopy() *KeycloakOpenIDConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakOpenIDConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakPolicy) DeepCopyInto(out *KeycloakPolicy) {
 	*out = *in
@@ -557,6 +904,36 @@ func (in *KeycloakPolicy) DeepCopy() *KeycloakPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakProfileInfo) DeepCopyInto(out *KeycloakProfileInfo) {
+	*out = *in
+	if in.DisabledFeatures != nil {
+		in, out := &in.DisabledFeatures, &out.DisabledFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreviewFeatures != nil {
+		in, out := &in.PreviewFeatures, &out.PreviewFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExperimentalFeatures != nil {
+		in, out := &in.ExperimentalFeatures, &out.ExperimentalFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakProfileInfo.
+func (in *KeycloakProfileInfo) DeepCopy() *KeycloakProfileInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakProfileInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakProtocolMapper) DeepCopyInto(out *KeycloakProtocolMapper) {
 	*out = *in
@@ -579,6 +956,21 @@ func (in *KeycloakProtocolMapper) DeepCopy() *KeycloakProtocolMapper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakProtocolMapperType) DeepCopyInto(out *KeycloakProtocolMapperType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakProtocolMapperType.
+func (in *KeycloakProtocolMapperType) DeepCopy() *KeycloakProtocolMapperType {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakProtocolMapperType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRealm) DeepCopyInto(out *KeycloakRealm) {
 	*out = *in
@@ -651,6 +1043,11 @@ func (in *KeycloakRealmSpec) DeepCopyInto(out *KeycloakRealmSpec) {
 		*out = new(KeycloakAPIRealm)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AllowedSecretTargetNamespaces != nil {
+		in, out := &in.AllowedSecretTargetNamespaces, &out.AllowedSecretTargetNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmSpec.
@@ -682,6 +1079,17 @@ func (in *KeycloakRealmStatus) DeepCopyInto(out *KeycloakRealmStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmStatus.
@@ -793,10 +1201,49 @@ func (in *KeycloakScope) DeepCopy() *KeycloakScope {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakServerInfo) DeepCopyInto(out *KeycloakServerInfo) {
+	*out = *in
+	out.SystemInfo = in.SystemInfo
+	in.ProfileInfo.DeepCopyInto(&out.ProfileInfo)
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]KeycloakFeature, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtocolMapperTypes != nil {
+		in, out := &in.ProtocolMapperTypes, &out.ProtocolMapperTypes
+		*out = make(map[string][]KeycloakProtocolMapperType, len(*in))
+		for key, val := range *in {
+			var outVal []KeycloakProtocolMapperType
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]KeycloakProtocolMapperType, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakServerInfo.
+func (in *KeycloakServerInfo) DeepCopy() *KeycloakServerInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakServerInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
 	*out = *in
 	out.External = in.External
+	in.AdminAPI.DeepCopyInto(&out.AdminAPI)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakSpec.
@@ -828,6 +1275,38 @@ func (in *KeycloakStatus) DeepCopyInto(out *KeycloakStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtocolMapperTypes != nil {
+		in, out := &in.ProtocolMapperTypes, &out.ProtocolMapperTypes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakStatus.
@@ -840,6 +1319,21 @@ func (in *KeycloakStatus) DeepCopy() *KeycloakStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakSystemInfo) DeepCopyInto(out *KeycloakSystemInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakSystemInfo.
+func (in *KeycloakSystemInfo) DeepCopy() *KeycloakSystemInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakSystemInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakUserRole) DeepCopyInto(out *KeycloakUserRole) {
 	*out = *in
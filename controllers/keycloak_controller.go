@@ -18,12 +18,14 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/movewp3/keycloakclient-controller/pkg/common"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -31,7 +33,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/movewp3/keycloakclient-controller/version"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,11 +45,11 @@ import (
 type KeycloakReconciler struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
-	Client   client.Client
-	Scheme   *runtime.Scheme
-	context  context.Context
-	cancel   context.CancelFunc
-	recorder record.EventRecorder
+	Client client.Client
+	Scheme *runtime.Scheme
+	// Creates the clients for the admin API of Keycloak, defaults to a LocalConfigKeycloakFactory
+	KeycloakFactory common.KeycloakClientFactory
+	recorder        record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=keycloak.org,resources=keycloaks,verbs=get;list;watch;create;update;patch;delete
@@ -85,11 +86,6 @@ func (r *KeycloakReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	//client := mgr.GetClient()
-	r.context = ctx
-	r.cancel = cancel
 	r.recorder = mgr.GetEventRecorderFor(KeycloakControllerName)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -104,23 +100,38 @@ const (
 	KeycloakRequeueDelay      = 150 * time.Second
 	KeycloakRequeueDelayError = 60 * time.Second
 	KeycloakControllerName    = "keycloak-controller"
+	// DefaultReconcileTimeout is the maximum duration of a reconcile of a realm or client if none is configured
+	DefaultReconcileTimeout = 2 * time.Minute
+	// StatusUpdateTimeout is the maximum duration of writing the status and finalizers after a reconcile
+	StatusUpdateTimeout = 10 * time.Second
 )
 
 // newReconciler returns a new reconcile.Reconciler
 func NewKeycloakReconciler(mgr manager.Manager) reconcile.Reconciler {
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
 	client := mgr.GetClient()
 
 	return &KeycloakReconciler{
 		Client:   client,
 		Scheme:   mgr.GetScheme(),
-		context:  ctx,
-		cancel:   cancel,
 		recorder: mgr.GetEventRecorderFor(KeycloakControllerName),
 	}
 }
 
+// reconcileContext derives the context of a single reconcile, which is cancelled after the given timeout or
+// when the manager shuts down. A timeout of zero means no limit.
+func reconcileContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// statusContext derives the context the outcome of a reconcile is written with. It is not bound by the timeout of the
+// reconcile, so that a reconcile which ran out of time still records its failure in the status.
+func statusContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), StatusUpdateTimeout)
+}
+
 // blank assignment to verify that KeycloakReconciler implements reconcile.Reconciler
 var _ reconcile.Reconciler = &KeycloakReconciler{}
 
@@ -133,12 +144,14 @@ func (r *KeycloakReconciler) Reconcile(ctx context.Context, request reconcile.Re
 	// Fetch the Keycloak instance
 	instance := &keycloakv1alpha1.Keycloak{}
 
-	err := r.Client.Get(r.context, request.NamespacedName, instance)
+	err := r.Client.Get(ctx, request.NamespacedName, instance)
 	if err != nil {
 		if kubeerrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// The pooled api client of the instance is not needed anymore.
 			// Return and don't requeue
+			common.EvictAuthenticatedClient(request.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -147,17 +160,17 @@ func (r *KeycloakReconciler) Reconcile(ctx context.Context, request reconcile.Re
 	currentState := common.NewClusterState()
 
 	if instance.Spec.Unmanaged {
-		return r.ManageSuccess(instance, currentState)
+		return r.ManageSuccess(ctx, instance, currentState)
 	}
 
 	if instance.Spec.External.Enabled {
-		return r.ManageError(instance, errors.Errorf("if external.enabled is true, unmanaged also needs to be true"))
+		return r.ManageError(ctx, instance, errors.Errorf("if external.enabled is true, unmanaged also needs to be true"))
 	}
 
 	// Read current state
-	err = currentState.Read(r.context, instance, r.Client)
+	err = currentState.Read(ctx, instance, r.Client)
 	if err != nil {
-		return r.ManageError(instance, err)
+		return r.ManageError(ctx, instance, err)
 	}
 
 	// Get Action to reconcile current state into desired state
@@ -165,25 +178,26 @@ func (r *KeycloakReconciler) Reconcile(ctx context.Context, request reconcile.Re
 	desiredState := r.ReconcileIt(currentState, instance)
 
 	// Run the actions to reach the desired state
-	actionRunner := common.NewClusterActionRunner(r.context, r.Client, r.Scheme, instance)
+	actionRunner := common.NewClusterActionRunner(ctx, r.Client, r.Scheme, instance)
 	err = actionRunner.RunAll(desiredState)
 	if err != nil {
-		return r.ManageError(instance, err)
+		return r.ManageError(ctx, instance, err)
 	}
 
-	return r.ManageSuccess(instance, currentState)
+	return r.ManageSuccess(ctx, instance, currentState)
 }
 
-func (r *KeycloakReconciler) ManageError(instance *keycloakv1alpha1.Keycloak, issue error) (reconcile.Result, error) {
+func (r *KeycloakReconciler) ManageError(ctx context.Context, instance *keycloakv1alpha1.Keycloak, issue error) (reconcile.Result, error) {
 	r.recorder.Event(instance, "Warning", "ProcessingError", issue.Error())
 
 	instance.Status.Message = issue.Error()
 	instance.Status.Ready = false
 	instance.Status.Phase = keycloakv1alpha1.PhaseFailing
+	instance.Status.ObservedGeneration = instance.Generation
+	setCondition(&instance.Status.Conditions, instance.Generation, keycloakv1alpha1.ConditionReady, metav1.ConditionFalse,
+		keycloakv1alpha1.ReasonReconcileFailed, issue.Error())
 
-	r.setVersion(instance)
-
-	err := r.Client.Status().Update(r.context, instance)
+	err := r.Client.Status().Update(ctx, instance)
 	if err != nil {
 		logKc.Error(err, "unable to update status")
 	}
@@ -194,21 +208,11 @@ func (r *KeycloakReconciler) ManageError(instance *keycloakv1alpha1.Keycloak, is
 	}, nil
 }
 
-func (r *KeycloakReconciler) ManageSuccess(instance *keycloakv1alpha1.Keycloak, currentState *common.ClusterState) (reconcile.Result, error) {
+func (r *KeycloakReconciler) ManageSuccess(ctx context.Context, instance *keycloakv1alpha1.Keycloak, currentState *common.ClusterState) (reconcile.Result, error) {
 	// Check if the resources are ready
 	resourcesReady, err := currentState.IsResourcesReady(instance)
 	if err != nil {
-		return r.ManageError(instance, err)
-	}
-
-	instance.Status.Ready = resourcesReady
-	instance.Status.Message = ""
-
-	// If resources are ready and we have not errored before now, we are in a reconciling phase
-	if resourcesReady {
-		instance.Status.Phase = keycloakv1alpha1.PhaseReconciling
-	} else {
-		instance.Status.Phase = keycloakv1alpha1.PhaseInitialising
+		return r.ManageError(ctx, instance, err)
 	}
 
 	if instance.Spec.External.URL != "" { //nolint
@@ -220,9 +224,33 @@ func (r *KeycloakReconciler) ManageSuccess(instance *keycloakv1alpha1.Keycloak,
 		instance.Status.CredentialSecret = currentState.KeycloakAdminSecret.Name
 	}
 
-	r.setVersion(instance)
+	// Keycloak is only ready if the controller can reach its admin API
+	unreachable := r.readServerInfo(ctx, instance)
+
+	instance.Status.Ready = resourcesReady && unreachable == nil
+	instance.Status.Message = ""
+	instance.Status.ObservedGeneration = instance.Generation
+	requeueDelay := KeycloakRequeueDelay
+	switch {
+	case !resourcesReady:
+		instance.Status.Phase = keycloakv1alpha1.PhaseInitialising
+		setCondition(&instance.Status.Conditions, instance.Generation, keycloakv1alpha1.ConditionReady, metav1.ConditionFalse,
+			keycloakv1alpha1.ReasonInitialising, "")
+	case unreachable != nil:
+		instance.Status.Phase = keycloakv1alpha1.PhaseFailing
+		instance.Status.Message = unreachable.Error()
+		setCondition(&instance.Status.Conditions, instance.Generation, keycloakv1alpha1.ConditionReady, metav1.ConditionFalse,
+			keycloakv1alpha1.ReasonKeycloakUnreachable, unreachable.Error())
+		requeueDelay = KeycloakRequeueDelayError
+	default:
+		// the phase stays reconciling for compatibility, the Ready condition tells that the reconcile is done
+		instance.Status.Phase = keycloakv1alpha1.PhaseReconciling
+		instance.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
+		setCondition(&instance.Status.Conditions, instance.Generation, keycloakv1alpha1.ConditionReady, metav1.ConditionTrue,
+			keycloakv1alpha1.ReasonReconciled, "")
+	}
 
-	err = r.Client.Status().Update(r.context, instance)
+	err = r.Client.Status().Update(ctx, instance)
 	if err != nil {
 		logKc.Error(err, "unable to update status")
 		return reconcile.Result{
@@ -232,9 +260,40 @@ func (r *KeycloakReconciler) ManageSuccess(instance *keycloakv1alpha1.Keycloak,
 	}
 
 	logKc.Info("desired cluster state met")
-	return reconcile.Result{RequeueAfter: KeycloakRequeueDelay}, nil
+	return reconcile.Result{RequeueAfter: requeueDelay}, nil
+}
+
+// readServerInfo stores the version, the enabled features and the protocol mapper types of the Keycloak server in
+// the status. If the server info cannot be read, the previous values are kept, a warning event is recorded, Keycloak
+// is marked as unreachable and the error is returned. The server info is read whenever the factory can authenticate
+// against Keycloak, a Keycloak without URL is reported as unreachable.
+func (r *KeycloakReconciler) readServerInfo(ctx context.Context, instance *keycloakv1alpha1.Keycloak) error {
+	serverInfo, err := r.getServerInfo(ctx, *instance)
+	if err != nil {
+		logKc.Error(err, "unable to read server info")
+		r.recorder.Event(instance, "Warning", "ServerInfoError", err.Error())
+		setCondition(&instance.Status.Conditions, instance.Generation, keycloakv1alpha1.ConditionKeycloakReachable, metav1.ConditionFalse,
+			keycloakv1alpha1.ReasonKeycloakUnreachable, err.Error())
+		return err
+	}
+	setKeycloakReachableCondition(&instance.Status.Conditions, instance.Generation)
+
+	common.ApplyServerInfo(&instance.Status, serverInfo)
+	logKc.Info(fmt.Sprintf("keycloak %v/%v runs version %v", instance.Namespace, instance.Name, instance.Status.Version))
+	return nil
 }
 
-func (r *KeycloakReconciler) setVersion(instance *keycloakv1alpha1.Keycloak) {
-	instance.Status.Version = version.Version
+func (r *KeycloakReconciler) getServerInfo(ctx context.Context, instance keycloakv1alpha1.Keycloak) (*keycloakv1alpha1.KeycloakServerInfo, error) {
+	authenticated, err := r.keycloakFactory().AuthenticatedClient(ctx, instance, false)
+	if err != nil {
+		return nil, err
+	}
+	return authenticated.GetServerInfo(ctx)
+}
+
+func (r *KeycloakReconciler) keycloakFactory() common.KeycloakClientFactory {
+	if r.KeycloakFactory == nil {
+		return &common.LocalConfigKeycloakFactory{}
+	}
+	return r.KeycloakFactory
 }
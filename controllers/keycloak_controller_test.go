@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/movewp3/keycloakclient-controller/pkg/common"
+	"github.com/movewp3/keycloakclient-controller/pkg/model"
+	"github.com/movewp3/keycloakclient-controller/test/fakekeycloak"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTestKeycloakReconciler returns a reconciler for Keycloaks working against a fake Kubernetes API, which
+// contains an external Keycloak "keycloak" for the fake Keycloak server
+func newTestKeycloakReconciler(t *testing.T, server *fakekeycloak.Server) (*KeycloakReconciler, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&v1alpha1.Keycloak{
+				ObjectMeta: v13.ObjectMeta{Name: "keycloak", Namespace: "test"},
+				Spec: v1alpha1.KeycloakSpec{
+					Unmanaged: true,
+					External:  v1alpha1.KeycloakExternal{Enabled: true, URL: server.URL},
+				},
+				Status: v1alpha1.KeycloakStatus{Version: "25.0.6"},
+			},
+			&v1.Secret{
+				ObjectMeta: v13.ObjectMeta{Name: "credential-keycloak", Namespace: "test"},
+				Data: map[string][]byte{
+					model.AdminUsernameProperty: []byte(fakekeycloak.AdminUser),
+					model.AdminPasswordProperty: []byte(fakekeycloak.AdminPassword),
+				},
+			}).
+		WithStatusSubresource(&v1alpha1.Keycloak{}).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	return &KeycloakReconciler{
+		Client:          k8sClient,
+		Scheme:          scheme,
+		KeycloakFactory: &common.LocalConfigKeycloakFactory{Client: k8sClient},
+		recorder:        recorder,
+	}, recorder
+}
+
+func TestKeycloakController_ServerInfo(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	r, _ := newTestKeycloakReconciler(t, server)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "keycloak"}}
+
+	// when
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	instance := &v1alpha1.Keycloak{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, fakekeycloak.DefaultVersion, instance.Status.Version)
+	assert.Equal(t, []string{"TOKEN_EXCHANGE"}, instance.Status.Features)
+	assert.Contains(t, instance.Status.ProtocolMapperTypes["openid-connect"], "oidc-audience-mapper")
+	assert.True(t, instance.Status.Ready)
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionReady))
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionKeycloakReachable))
+	assert.NotNil(t, instance.Status.LastSyncTime)
+}
+
+func TestKeycloakController_ServerInfoUnavailable(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	server.Fail(http.MethodGet, "/admin/serverinfo", http.StatusForbidden)
+	r, recorder := newTestKeycloakReconciler(t, server)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "keycloak"}}
+
+	// when
+	result, err := r.Reconcile(context.TODO(), request)
+
+	// then Keycloak is not ready, as its admin API cannot be used
+	assert.NoError(t, err)
+	assert.Equal(t, KeycloakRequeueDelayError, result.RequeueAfter)
+	instance := &v1alpha1.Keycloak{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, "25.0.6", instance.Status.Version)
+	assert.False(t, instance.Status.Ready)
+	assert.Nil(t, instance.Status.LastSyncTime)
+	ready := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionReady)
+	assert.Equal(t, v13.ConditionFalse, ready.Status)
+	assert.Equal(t, v1alpha1.ReasonKeycloakUnreachable, ready.Reason)
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "ServerInfoError")
+	reachable := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionKeycloakReachable)
+	assert.Equal(t, v13.ConditionFalse, reachable.Status)
+	assert.Equal(t, v1alpha1.ReasonKeycloakUnreachable, reachable.Reason)
+
+	// when Keycloak is back
+	server.Fail(http.MethodGet, "/admin/serverinfo", 0)
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.True(t, instance.Status.Ready)
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionReady))
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionKeycloakReachable))
+}
+
+// urlKeycloakFactory authenticates against the Keycloak at the URL, whatever the URL of the Keycloak CR
+type urlKeycloakFactory struct {
+	common.LocalConfigKeycloakFactory
+	URL string
+}
+
+func (f *urlKeycloakFactory) AuthenticatedClient(ctx context.Context, kc v1alpha1.Keycloak, insecureSsl bool) (common.KeycloakInterface, error) {
+	kc.Status.ExternalURL = f.URL
+	return f.LocalConfigKeycloakFactory.AuthenticatedClient(ctx, kc, insecureSsl)
+}
+
+func TestKeycloakController_ServerInfoWithoutURL(t *testing.T) {
+	// given a Keycloak without URL
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	r, recorder := newTestKeycloakReconciler(t, server)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "keycloak"}}
+	instance := &v1alpha1.Keycloak{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.External.URL = ""
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+
+	// when
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then the version cannot be detected, which is reported
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, "25.0.6", instance.Status.Version)
+	assert.False(t, instance.Status.Ready)
+	assert.True(t, meta.IsStatusConditionFalse(instance.Status.Conditions, v1alpha1.ConditionKeycloakReachable))
+	assert.Contains(t, <-recorder.Events, "ServerInfoError")
+
+	// when the factory can authenticate against Keycloak nevertheless
+	r.KeycloakFactory = &urlKeycloakFactory{LocalConfigKeycloakFactory: common.LocalConfigKeycloakFactory{Client: r.Client}, URL: server.URL}
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the version is detected
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, fakekeycloak.DefaultVersion, instance.Status.Version)
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionKeycloakReachable))
+}
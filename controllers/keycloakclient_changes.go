@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"bytes"
+	"maps"
+
+	kc "github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/movewp3/keycloakclient-controller/pkg/common"
+	"github.com/movewp3/keycloakclient-controller/pkg/model"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Changes drops the actions of the desired state which would not change anything. ReconcileIt always updates an
+// existing client, its roles and its Secret, which is harmless when the actions are run, but would list no-op actions in the
+// plan of a dry run.
+func (i *DedicatedKeycloakClientReconciler) Changes(state *common.ClientState, cr *kc.KeycloakClient, desired common.DesiredClusterState) common.DesiredClusterState {
+	changes := common.DesiredClusterState{}
+	for _, action := range desired {
+		switch action := action.(type) {
+		case common.UpdateClientAction:
+			if state.Client != nil && len(model.ClientChanges(cr.Spec.Client, state.Client)) == 0 {
+				continue
+			}
+		case common.UpdateClientRoleAction:
+			if action.Role.Name == action.OldRole.Name && action.Role.Description == action.OldRole.Description {
+				continue
+			}
+		case common.GenericUpdateAction:
+			if secret, ok := action.Ref.(*v1.Secret); ok && secretUnchanged(secret, state.ClientSecret) {
+				continue
+			}
+		}
+		changes = append(changes, action)
+	}
+	return changes
+}
+
+// secretUnchanged compares the data, labels and annotations of a rendered Secret with the existing one
+func secretUnchanged(desired, existing *v1.Secret) bool {
+	return existing != nil && desired.Namespace == existing.Namespace && desired.Name == existing.Name &&
+		len(desired.StringData) == 0 && maps.EqualFunc(desired.Data, existing.Data, bytes.Equal) &&
+		maps.Equal(desired.Labels, existing.Labels) && maps.Equal(desired.Annotations, existing.Annotations)
+}
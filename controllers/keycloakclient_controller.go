@@ -19,12 +19,18 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/movewp3/keycloakclient-controller/pkg/util"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/movewp3/keycloakclient-controller/pkg/common"
+	"github.com/movewp3/keycloakclient-controller/pkg/model"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"k8s.io/client-go/tools/record"
@@ -32,6 +38,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
@@ -41,10 +48,16 @@ import (
 
 // KeycloakClientReconciler reconciles a KeycloakClient object
 type KeycloakClientReconciler struct {
-	Client   client.Client
-	Scheme   *runtime.Scheme
-	context  context.Context
-	cancel   context.CancelFunc
+	Client client.Client
+	Scheme *runtime.Scheme
+	// Maximum duration of a single reconcile, including all requests against Keycloak
+	ReconcileTimeout time.Duration
+	// Creates the clients for the admin API of Keycloak, defaults to a LocalConfigKeycloakFactory
+	KeycloakFactory common.KeycloakClientFactory
+	// Namespaces all KeycloakClients may write secret targets to, in addition to those allowed by their realms
+	SecretTargetNamespaces []string
+	// Plans the changes of all KeycloakClients without applying them, like the annotation keycloak.org/dry-run
+	DryRun   bool
 	recorder record.EventRecorder
 }
 
@@ -53,12 +66,15 @@ var logKcc = logf.Log.WithName("controller_keycloakclient")
 const (
 	ClientFinalizer         = "client.cleanup"
 	ClientRequeueDelayError = 60 * time.Second
-	ClientControllerName    = "keycloakclient-controller"
+	// ClientRequeueDelayPermanentError is used for errors retrying does not resolve, e.g. a rejected representation
+	ClientRequeueDelayPermanentError = 5 * time.Minute
+	ClientControllerName             = "keycloakclient-controller"
 )
 
 //+kubebuilder:rbac:groups=keycloak.org,resources=keycloakclients,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=keycloak.org,resources=keycloakclients/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=keycloak.org,resources=keycloakclients/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets;configmaps,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -72,12 +88,21 @@ const (
 func (r *KeycloakClientReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	_ = logf.FromContext(ctx)
 
+	// bound the reconcile, so that calls against an unresponsive Keycloak are cancelled
+	ctx, cancel := reconcileContext(ctx, r.ReconcileTimeout)
+	defer cancel()
+	ctx, span := common.StartSpan(ctx, "KeycloakClient.Reconcile",
+		common.AttributeKind.String("KeycloakClient"),
+		common.AttributeNamespace.String(request.Namespace),
+		common.AttributeName.String(request.Name))
+	defer span.End()
+
 	reqLogger := logKcc.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling KeycloakClient")
 
 	// Fetch the KeycloakClient instance
 	instance := &kc.KeycloakClient{}
-	err := r.Client.Get(context.TODO(), request.NamespacedName, instance)
+	err := r.Client.Get(ctx, request.NamespacedName, instance)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -91,89 +116,372 @@ func (r *KeycloakClientReconciler) Reconcile(ctx context.Context, request ctrl.R
 
 	r.adjustCrDefaults(instance)
 
+	if err := model.ValidateClientSecretTemplate(instance.Spec.SecretTemplate); err != nil {
+		return r.ManageError(ctx, instance, err)
+	}
+
+	if err := model.ValidateClientAuthentication(instance); err != nil {
+		return r.ManageError(ctx, instance, err)
+	}
+
+	if err := model.ValidateSAML(instance); err != nil {
+		return r.ManageError(ctx, instance, err)
+	}
+
 	// The client may be applicable to multiple keycloak instances,
 	// process all of them
-	realms, err := common.GetMatchingRealms(r.context, r.Client, instance.Spec.RealmSelector)
+	realms, err := common.GetMatchingRealms(ctx, r.Client, instance.Spec.RealmSelector)
 	if err != nil {
-		return r.ManageError(instance, err)
+		return r.ManageError(ctx, instance, err)
 	}
 	logKcc.Info(fmt.Sprintf("found %v matching realm(s) for client %v/%v", len(realms.Items), instance.Namespace, instance.Name))
+	var targets []clientTarget
 	for _, realm := range realms.Items {
-		keycloaks, err := common.GetMatchingKeycloaks(r.context, r.Client, realm.Spec.InstanceSelector)
+		if instance.DeletionTimestamp == nil {
+			allowedNamespaces := append(slices.Clone(r.SecretTargetNamespaces), realm.Spec.AllowedSecretTargetNamespaces...)
+			if err := model.ValidateClientSecretTargets(instance, allowedNamespaces); err != nil {
+				return r.ManageError(ctx, instance, err)
+			}
+		}
+
+		keycloaks, err := common.GetMatchingKeycloaks(ctx, r.Client, realm.Spec.InstanceSelector)
 		if err != nil {
-			return r.ManageError(instance, err)
+			return r.ManageError(ctx, instance, err)
 		}
 		logKcc.Info(fmt.Sprintf("found %v matching keycloak(s) for realm %v/%v", len(keycloaks.Items), realm.Namespace, realm.Name))
 
 		for _, keycloak := range keycloaks.Items {
-			// Get an authenticated keycloak api client for the instance
-			keycloakFactory := common.LocalConfigKeycloakFactory{}
-			authenticated, err := keycloakFactory.AuthenticatedClient(keycloak, false)
-			if err != nil {
-				return r.ManageError(instance, err)
-			}
+			targets = append(targets, clientTarget{realm: realm, keycloak: keycloak})
+		}
+	}
 
-			// Compute the current state of the realm
-			logKcc.Info(fmt.Sprintf("got authenticated client for keycloak at %v", authenticated.Endpoint()))
-			clientState := common.NewClientState(r.context, realm.DeepCopy(), keycloak)
-
-			logKcc.Info(fmt.Sprintf("read client state for keycloak %v/%v, realm %v/%v, client %v/%v",
-				keycloak.Namespace,
-				keycloak.Name,
-				realm.Namespace,
-				realm.Name,
-				instance.Namespace,
-				instance.Name))
-
-			// if keycloak has stored a secret, then this is added to instance here
-			err = clientState.Read(r.context, instance, authenticated, r.Client)
-			if err != nil {
-				logKcc.Error(err, "error reading client state")
-				return r.ManageError(instance, err)
-			}
+	// the first target stays first, so that new targets do not take over its client secret and the status
+	if len(instance.Status.Targets) > 0 {
+		first := instance.Status.Targets[0]
+		index := slices.IndexFunc(targets, func(target clientTarget) bool {
+			return target.keycloak.Namespace+"/"+target.keycloak.Name == first.Keycloak &&
+				target.realm.Namespace+"/"+target.realm.Name == first.KeycloakRealm
+		})
+		if index > 0 {
+			targets = append(append([]clientTarget{targets[index]}, targets[:index]...), targets[index+1:]...)
+		}
+	}
+
+	// A failing realm or Keycloak does not keep the client from being reconciled with the others
+	statuses := make([]kc.KeycloakClientTarget, 0, len(targets))
+	var errs targetErrors
+	for index, target := range targets {
+		status, err := r.reconcileTarget(ctx, instance, target, index)
+		statuses = append(statuses, status)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("keycloak %v, realm %v: %w", status.Keycloak, status.KeycloakRealm, err))
+		}
+	}
+	if instance.DeletionTimestamp == nil && !r.dryRun(instance) {
+		if err := r.deleteStaleClientSecrets(ctx, instance, instance.Status.Targets, statuses); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	instance.Status.Targets = statuses
+	setDriftedCondition(instance)
+	if len(errs) > 0 {
+		return r.ManageError(ctx, instance, errs)
+	}
 
-			// Figure out the actions to keep the realms up to date with
-			// the desired state
-			reconciler := NewDedicatedKeycloakClientReconciler(keycloak)
-			desiredState := reconciler.ReconcileIt(clientState, instance)
-			actionRunner := common.NewClusterAndKeycloakActionRunner(r.context, r.Client, r.Scheme, instance, authenticated)
+	// requeue for the next rotation of the client secret or key pair, if any
+	return reconcile.Result{RequeueAfter: targetRotationRequeueDelay(instance)}, r.manageSuccess(ctx, instance, instance.DeletionTimestamp != nil)
 
-			// Run all actions to keep the realms updated
-			err = actionRunner.RunAll(desiredState)
+}
 
-			sha, errsha := util.GetClientShaCode(instance.Spec.Client.ClientID)
-			if errsha == nil && sha == instance.Spec.Client.Secret {
-				instance.Spec.Client.Secret = ""
+// clientTarget is a realm and Keycloak a client is reconciled with
+type clientTarget struct {
+	realm    kc.KeycloakRealm
+	keycloak kc.Keycloak
+	// distinguishes the client secrets of all but the first target
+	name string
+	// true for all but the first target
+	secondary bool
+}
 
-				logKcc.Info(fmt.Sprintf("Removed sha code secret from keycloakclient %v",
-					instance.Name))
-			}
+// targetErrors are the errors of the realms and Keycloaks a client failed to be reconciled with
+type targetErrors []error
+
+func (e targetErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
 
-			if err != nil {
-				logKcc.Error(err, "error in actionRunner")
+func (e targetErrors) Unwrap() []error {
+	return e
+}
+
+// reconcileTarget reconciles the client with a realm of a Keycloak. Each target starts from the spec of the CR, the
+// values the controller sets in the spec are not shared between the targets. Only the first target is bound in the
+// ID, realm and role IDs of the status and writes to the client secret of a client with a single target. Each other
+// target gets its own client secret, which is rotated on its own schedule.
+func (r *KeycloakClientReconciler) reconcileTarget(ctx context.Context, instance *kc.KeycloakClient, target clientTarget, index int) (kc.KeycloakClientTarget, error) {
+	realm, keycloak := target.realm, target.keycloak
+	status := kc.KeycloakClientTarget{
+		Keycloak:      keycloak.Namespace + "/" + keycloak.Name,
+		KeycloakRealm: realm.Namespace + "/" + realm.Name,
+		Realm:         realm.Spec.Realm.Realm,
+	}
+	if index > 0 {
+		target.name = keycloak.Name + "-" + realm.Spec.Realm.Realm
+	}
+	target.secondary = index > 0
+	if !model.IsSAML(instance) {
+		status.SecretName = model.ClientTargetSecretSelector(instance, target.name).Name
+	}
 
-				return r.ManageError(instance, err)
+	// only a client synced before can drift, new targets are not compared
+	working := instance.DeepCopy()
+	applied := false
+	if index > 0 {
+		// the rotations of the first target are bound in the status of the client, the other targets are rotated on
+		// their own schedule, which starts over for new targets
+		setRotations(&working.Status, nil, nil, nil)
+	}
+	for _, previous := range instance.Status.Targets {
+		if previous.Keycloak == status.Keycloak && previous.KeycloakRealm == status.KeycloakRealm {
+			status.LastSyncTime = previous.LastSyncTime
+			working.Status.ID = previous.ID
+			working.Status.Realm = previous.Realm
+			applied = previous.Synced && specApplied(instance)
+			if index > 0 {
+				setRotations(&working.Status, previous.LastSecretRotation, previous.SecretRotationStart, previous.LastKeyPairRotation)
 			}
 		}
 	}
 
-	return reconcile.Result{Requeue: false}, r.manageSuccess(instance, instance.DeletionTimestamp != nil)
+	err := r.syncTarget(ctx, working, target, applied, &status)
+
+	status.ID = working.Status.ID
+	status.LastSecretRotation = working.Status.LastSecretRotation
+	status.SecretRotationStart = working.Status.SecretRotationStart
+	status.LastKeyPairRotation = working.Status.LastKeyPairRotation
+	if index > 0 {
+		working.Status.ID = instance.Status.ID
+		working.Status.Realm = instance.Status.Realm
+		working.Status.RoleIDs = instance.Status.RoleIDs
+		setRotations(&working.Status, instance.Status.LastSecretRotation, instance.Status.SecretRotationStart, instance.Status.LastKeyPairRotation)
+	}
+	instance.Status = working.Status
+	if err != nil {
+		status.Error = err.Error()
+		return status, err
+	}
+	if r.dryRun(instance) {
+		return status, nil
+	}
+	status.Synced = true
+	status.LastSyncTime = &metav1.Time{Time: time.Now()}
+	return status, nil
+}
+
+// deleteStaleClientSecrets deletes the client secrets of the previous targets which are no longer the client secret
+// of a target, e.g. of a removed target or of a target which became the first one
+func (r *KeycloakClientReconciler) deleteStaleClientSecrets(ctx context.Context, instance *kc.KeycloakClient, previous, current []kc.KeycloakClientTarget) error {
+	names := make(map[string]bool)
+	for _, target := range current {
+		names[target.SecretName] = true
+	}
+	for _, target := range previous {
+		if target.SecretName == "" || names[target.SecretName] {
+			continue
+		}
+		secret := &v1.Secret{}
+		err := r.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: target.SecretName}, secret)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// secrets not created by the controller are left alone
+		if !metav1.IsControlledBy(secret, instance) {
+			continue
+		}
+		logKcc.Info(fmt.Sprintf("deleting client secret %v/%v of a previous target of client %v", secret.Namespace, secret.Name, instance.Name))
+		if err := r.Client.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// setRotations sets the times the rotations of the client secret and key pair are scheduled from
+func setRotations(status *kc.KeycloakClientStatus, lastSecretRotation, secretRotationStart, lastKeyPairRotation *metav1.Time) {
+	status.LastSecretRotation = lastSecretRotation
+	status.SecretRotationStart = secretRotationStart
+	status.LastKeyPairRotation = lastKeyPairRotation
+}
+
+// targetRotationRequeueDelay returns the time until the next rotation of the client secret or key pair in any target
+// of the client. Returns 0 if neither is rotated.
+func targetRotationRequeueDelay(client *kc.KeycloakClient) time.Duration {
+	delay := rotationRequeueDelay(client)
+	for _, target := range client.Status.Targets {
+		scheduled := client.DeepCopy()
+		setRotations(&scheduled.Status, target.LastSecretRotation, target.SecretRotationStart, target.LastKeyPairRotation)
+		if next := rotationRequeueDelay(scheduled); next > 0 && (delay == 0 || next < delay) {
+			delay = next
+		}
+	}
+	return delay
+}
+
+// syncTarget applies the client to a realm of a Keycloak. If the client has been applied before, it records the drift
+// of the client in Keycloak from the CR in the status of the target, and clients with the drift policy reportOnly are
+// left unchanged if they drifted. In a dry run the planned actions are recorded instead of being run.
+func (r *KeycloakClientReconciler) syncTarget(ctx context.Context, instance *kc.KeycloakClient, target clientTarget, applied bool, status *kc.KeycloakClientTarget) error {
+	realm, keycloak := target.realm, target.keycloak
+	// Get an authenticated keycloak api client for the instance
+	authenticated, err := r.keycloakFactory().AuthenticatedClient(ctx, keycloak, false)
+	if err != nil {
+		return keycloakUnreachable(err)
+	}
+	setKeycloakReachableCondition(&instance.Status.Conditions, instance.Generation)
+
+	// Compute the current state of the realm
+	logKcc.Info(fmt.Sprintf("got authenticated client for keycloak at %v", authenticated.Endpoint()))
+	clientState := common.NewClientState(ctx, realm.DeepCopy(), keycloak)
+	clientState.Target = target.name
+	clientState.SecondaryTarget = target.secondary
+
+	logKcc.Info(fmt.Sprintf("read client state for keycloak %v/%v, realm %v/%v, client %v/%v",
+		keycloak.Namespace,
+		keycloak.Name,
+		realm.Namespace,
+		realm.Name,
+		instance.Namespace,
+		instance.Name))
+
+	// if keycloak has stored a secret, then this is added to instance here
+	trace.SpanFromContext(ctx).SetAttributes(common.AttributeRealm.String(realm.Spec.Realm.Realm), common.AttributeEndpoint.String(authenticated.Endpoint()))
+	readCtx, readSpan := common.StartSpan(ctx, "ClientState.Read",
+		common.AttributeRealm.String(realm.Spec.Realm.Realm),
+		common.AttributeEndpoint.String(authenticated.Endpoint()))
+	err = clientState.Read(readCtx, instance, authenticated, r.Client)
+	common.EndSpan(readSpan, err)
+	if err != nil {
+		logKcc.Error(err, "error reading client state")
+		return err
+	}
+
+	// Figure out the actions to keep the realms up to date with
+	// the desired state
+	reconciler := NewDedicatedKeycloakClientReconciler(keycloak)
+	unchanged := instance.Status.DeepCopy()
+	desiredState := reconciler.ReconcileIt(clientState, instance)
 
+	// differences of a client applied before are changes made in Keycloak, not changes of the CR
+	if instance.DeletionTimestamp == nil && applied {
+		status.Drift = reconciler.Drift(clientState, instance)
+	}
+	if drift := status.Drift; len(drift) > 0 {
+		policy := instance.Spec.DriftPolicy
+		if policy == "" {
+			policy = model.DriftPolicyCorrect
+		}
+		common.ObserveClientDrift(keycloak.Namespace+"/"+keycloak.Name, realm.Spec.Realm.Realm, policy)
+		r.recorder.Event(instance, "Warning", "Drifted", fmt.Sprintf("client %v in keycloak %v/%v, realm %v differs from the CR: %v",
+			instance.Spec.Client.ClientID, keycloak.Namespace, keycloak.Name, realm.Spec.Realm.Realm, strings.Join(drift, ", ")))
+		if model.ReportsDriftOnly(instance) {
+			logKcc.Info(fmt.Sprintf("client %v/%v drifted, not correcting it with drift policy %v", instance.Namespace, instance.Name, policy))
+			// no action is run, so the values the planning set in the status, e.g. of a key pair rotation, are dropped
+			instance.Status = *unchanged
+			return nil
+		}
+	}
+
+	if r.dryRun(instance) {
+		// nothing is applied, so the planned status is dropped as well
+		instance.Status = *unchanged
+		status.Plan = common.PlanActions(reconciler.Changes(clientState, instance, desiredState))
+		logKcc.Info(fmt.Sprintf("planned %v action(s) for client %v/%v in dry run", len(status.Plan), instance.Namespace, instance.Name))
+		if len(status.Plan) > 0 {
+			r.recorder.Event(instance, "Normal", "Planned", fmt.Sprintf("dry run of client %v in keycloak %v/%v, realm %v: %v",
+				instance.Spec.Client.ClientID, keycloak.Namespace, keycloak.Name, realm.Spec.Realm.Realm, summarise(status.Plan, maxPlanSummaryActions)))
+		}
+		return nil
+	}
+	actionRunner := common.NewClusterAndKeycloakActionRunner(ctx, r.Client, r.Scheme, instance, authenticated)
+
+	// Run all actions to keep the realms updated
+	err = actionRunner.RunAll(desiredState)
+	if err != nil {
+		logKcc.Error(err, "error in actionRunner")
+		return err
+	}
+
+	if clientState.Client == nil && instance.DeletionTimestamp == nil {
+		// Keycloak assigns default client scopes and realm roles to new clients, which are reconciled right away, so
+		// that the next reconcile does not take them for drift
+		err = r.reconcileNewClientDefaults(ctx, instance, target, authenticated)
+	}
+	return err
+}
+
+func (r *KeycloakClientReconciler) reconcileNewClientDefaults(ctx context.Context, instance *kc.KeycloakClient, target clientTarget, authenticated common.KeycloakInterface) error {
+	clientState := common.NewClientState(ctx, target.realm.DeepCopy(), target.keycloak)
+	clientState.Target = target.name
+	clientState.SecondaryTarget = target.secondary
+	err := clientState.Read(ctx, instance, authenticated, r.Client)
+	if err != nil {
+		return err
+	}
+
+	desiredState := NewDedicatedKeycloakClientReconciler(target.keycloak).ReconcileDefaults(clientState, instance)
+	return common.NewClusterAndKeycloakActionRunner(ctx, r.Client, r.Scheme, instance, authenticated).RunAll(desiredState)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *KeycloakClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	r.context = ctx
-	r.cancel = cancel
 	r.recorder = mgr.GetEventRecorderFor(ClientControllerName)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakv1alpha1.KeycloakClient{}).
+		Watches(&v1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.referencedSecretRequests)).
 		Complete(r)
 }
 
+// referencedSecretRequests returns the requests of the KeycloakClients whose secretRef, client certificate or SAML
+// certificates refer to the secret, so that changes of the secret, e.g. the renewal of a certificate, are pushed to
+// Keycloak
+func (r *KeycloakClientReconciler) referencedSecretRequests(ctx context.Context, secret client.Object) []reconcile.Request {
+	var list kc.KeycloakClientList
+	if err := r.Client.List(ctx, &list); err != nil {
+		logKcc.Error(err, "unable to list keycloak clients")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		key := client.ObjectKeyFromObject(secret)
+		if model.ReferencesClientSecret(&item, key) || model.ReferencesClientCertificate(&item, key) ||
+			model.ReferencesSAMLCertificate(&item, key) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
+// dryRun returns true if the changes of the client are planned only, for all clients or by its annotation
+func (r *KeycloakClientReconciler) dryRun(cr *kc.KeycloakClient) bool {
+	return r.DryRun || model.IsDryRun(cr)
+}
+
+func (r *KeycloakClientReconciler) keycloakFactory() common.KeycloakClientFactory {
+	if r.KeycloakFactory == nil {
+		return &common.LocalConfigKeycloakFactory{}
+	}
+	return r.KeycloakFactory
+}
+
 // Fills the CR with default values. Nils are not acceptable for Kubernetes.
 func (r *KeycloakClientReconciler) adjustCrDefaults(cr *kc.KeycloakClient) {
 	if cr.Spec.Client.Attributes == nil {
@@ -187,14 +495,37 @@ func (r *KeycloakClientReconciler) adjustCrDefaults(cr *kc.KeycloakClient) {
 	}
 }
 
-func (r *KeycloakClientReconciler) manageSuccess(client *kc.KeycloakClient, deleted bool) error {
+func (r *KeycloakClientReconciler) manageSuccess(ctx context.Context, client *kc.KeycloakClient, deleted bool) error {
+	ctx, cancel := statusContext(ctx)
+	defer cancel()
 
 	client.Status.Ready = true
 	client.Status.Message = ""
 	client.Status.Phase = v1alpha1.PhaseReconciling
-	err := r.Client.Status().Update(r.context, client)
+	client.Status.ObservedGeneration = client.Generation
+	if r.dryRun(client) {
+		// the client is planned only, so it has not been synced
+		return r.manageDryRun(ctx, client)
+	}
+	client.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
+	setSyncedConditions(&client.Status.Conditions, client.Generation)
+	if drifted := meta.FindStatusCondition(client.Status.Conditions, v1alpha1.ConditionDrifted); drifted != nil &&
+		drifted.Status == metav1.ConditionTrue && drifted.Reason == v1alpha1.ReasonDriftDetected {
+		// the drift is reported only, so the client in Keycloak still differs from the CR
+		setCondition(&client.Status.Conditions, client.Generation, v1alpha1.ConditionSynced, metav1.ConditionFalse,
+			v1alpha1.ReasonDriftDetected, drifted.Message)
+	}
+	if model.IsSAML(client) {
+		meta.RemoveStatusCondition(&client.Status.Conditions, v1alpha1.ConditionSecretReady)
+	} else {
+		setCondition(&client.Status.Conditions, client.Generation, v1alpha1.ConditionSecretReady, metav1.ConditionTrue,
+			v1alpha1.ReasonSecretWritten, "client secret written to "+strings.Join(clientSecretNames(client), ", "))
+	}
+	err := r.Client.Status().Update(ctx, client)
 	if err != nil {
+		// the status holds the times of the rotations that ran, without them the next reconcile would rotate again
 		logKcc.Error(err, "unable to update status")
+		return err
 	}
 
 	// Finalizer already set?
@@ -208,12 +539,6 @@ func (r *KeycloakClientReconciler) manageSuccess(client *kc.KeycloakClient, dele
 
 	// Resource created and finalizer exists: nothing to do
 	if !deleted && finalizerExists {
-		sha, err := util.GetClientShaCode(client.Spec.Client.ClientID)
-		if err == nil && client.Spec.Client.Secret == sha {
-			logKcc.Info(fmt.Sprintf("UNEXPECTED keycloak client with sha code secret %v/%v",
-				client.Namespace,
-				client.Spec.Client.ClientID))
-		}
 		return nil
 	}
 
@@ -223,14 +548,7 @@ func (r *KeycloakClientReconciler) manageSuccess(client *kc.KeycloakClient, dele
 		logKcc.Info(fmt.Sprintf("added finalizer to keycloak client %v/%v",
 			client.Namespace,
 			client.Spec.Client.ClientID))
-		sha, err := util.GetClientShaCode(client.Spec.Client.ClientID)
-		if err == nil && client.Spec.Client.Secret == sha {
-			client.Spec.Client.Secret = ""
-		}
-		logKcc.Info(fmt.Sprintf("update keycloak client %v/%v",
-			client.Namespace,
-			client.Spec.Client.ClientID))
-		return r.Client.Update(r.context, client)
+		return common.UpdateKeycloakClientFinalizers(ctx, r.Client, client)
 	}
 
 	// Otherwise remove the finalizer
@@ -247,16 +565,44 @@ func (r *KeycloakClientReconciler) manageSuccess(client *kc.KeycloakClient, dele
 	}
 
 	client.Finalizers = newFinalizers
+	return common.UpdateKeycloakClientFinalizers(ctx, r.Client, client)
+}
+
+// manageDryRun records the actions planned for all targets in the Synced condition. The finalizer of a deleted client is
+// kept, as its client has not been deleted in Keycloak.
+func (r *KeycloakClientReconciler) manageDryRun(ctx context.Context, client *kc.KeycloakClient) error {
+	planned := 0
+	for _, target := range client.Status.Targets {
+		planned += len(target.Plan)
+	}
+	setCondition(&client.Status.Conditions, client.Generation, v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonDryRun, "")
+	setCondition(&client.Status.Conditions, client.Generation, v1alpha1.ConditionSynced, metav1.ConditionFalse, v1alpha1.ReasonDryRun,
+		fmt.Sprintf("dry run, %v action(s) planned", planned))
+	err := r.Client.Status().Update(ctx, client)
+	if err != nil {
+		logKcc.Error(err, "unable to update status")
+	}
+	return err
+}
 
-	sha, err := util.GetClientShaCode(client.Spec.Client.ClientID)
-	if err == nil && client.Spec.Client.Secret == sha {
-		client.Spec.Client.Secret = ""
+// clientSecretNames returns the names of the client secrets of all targets of the client
+func clientSecretNames(client *kc.KeycloakClient) []string {
+	var names []string
+	for _, target := range client.Status.Targets {
+		names = append(names, target.SecretName)
+	}
+	if len(names) == 0 {
+		names = append(names, model.ClientSecretSelector(client).Name)
 	}
-	return r.Client.Update(r.context, client)
+	return names
 }
 
-func (r *KeycloakClientReconciler) ManageError(kcc *kc.KeycloakClient, issue error) (reconcile.Result, error) {
+func (r *KeycloakClientReconciler) ManageError(ctx context.Context, kcc *kc.KeycloakClient, issue error) (reconcile.Result, error) {
+	ctx, cancel := statusContext(ctx)
+	defer cancel()
+
 	r.recorder.Event(kcc, "Warning", "ProcessingError", issue.Error())
+	common.RecordSpanError(ctx, issue)
 
 	logKcc.Info(fmt.Sprintf("Manage Error keycloak client with sha code secret %v",
 		kcc.Name))
@@ -264,14 +610,21 @@ func (r *KeycloakClientReconciler) ManageError(kcc *kc.KeycloakClient, issue err
 	kcc.Status.Message = issue.Error()
 	kcc.Status.Ready = false
 	kcc.Status.Phase = v1alpha1.PhaseFailing
+	kcc.Status.ObservedGeneration = kcc.Generation
+	setErrorConditions(&kcc.Status.Conditions, kcc.Generation, issue)
 
-	err := r.Client.Status().Update(r.context, kcc)
+	err := r.Client.Status().Update(ctx, kcc)
 	if err != nil {
 		logKcc.Error(err, "unable to update status")
 	}
 
+	requeueDelay := ClientRequeueDelayError
+	if common.IsPermanentError(issue) {
+		requeueDelay = ClientRequeueDelayPermanentError
+	}
+
 	return reconcile.Result{
-		RequeueAfter: ClientRequeueDelayError,
+		RequeueAfter: requeueDelay,
 		Requeue:      true,
 	}, nil
 }
@@ -0,0 +1,1555 @@
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/movewp3/keycloakclient-controller/pkg/common"
+	"github.com/movewp3/keycloakclient-controller/pkg/model"
+	"github.com/movewp3/keycloakclient-controller/test/fakekeycloak"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newTestClientReconciler returns a reconciler for KeycloakClients working against a fake Kubernetes API,
+// which contains a Keycloak for the fake Keycloak server and a realm "test" selecting it
+func newTestClientReconciler(t *testing.T, server *fakekeycloak.Server, objects ...client.Object) *KeycloakClientReconciler {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	server.AddRealm("test")
+	objects = append(objects,
+		&v1alpha1.Keycloak{
+			ObjectMeta: v13.ObjectMeta{Name: "keycloak", Namespace: "test", Labels: map[string]string{"app": "sso"}},
+			Spec:       v1alpha1.KeycloakSpec{External: v1alpha1.KeycloakExternal{Enabled: true}},
+			Status:     v1alpha1.KeycloakStatus{ExternalURL: server.URL},
+		},
+		&v1.Secret{
+			ObjectMeta: v13.ObjectMeta{Name: "credential-keycloak", Namespace: "test"},
+			Data: map[string][]byte{
+				model.AdminUsernameProperty: []byte(fakekeycloak.AdminUser),
+				model.AdminPasswordProperty: []byte(fakekeycloak.AdminPassword),
+			},
+		},
+		&v1alpha1.KeycloakRealm{
+			ObjectMeta: v13.ObjectMeta{Name: "test", Namespace: "test", Labels: map[string]string{"application": "sso"}},
+			Spec: v1alpha1.KeycloakRealmSpec{
+				InstanceSelector: &v13.LabelSelector{MatchLabels: map[string]string{"app": "sso"}},
+				Realm:            &v1alpha1.KeycloakAPIRealm{Realm: "test"},
+			},
+		},
+	)
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&v1alpha1.KeycloakClient{}).
+		WithInterceptorFuncs(interceptor.Funcs{SubResourceUpdate: updateSubResourceUntilDone}).
+		Build()
+
+	return &KeycloakClientReconciler{
+		Client:          k8sClient,
+		Scheme:          scheme,
+		KeycloakFactory: &common.LocalConfigKeycloakFactory{Client: k8sClient},
+		recorder:        record.NewFakeRecorder(10),
+	}
+}
+
+// updateSubResourceUntilDone fails the update of a subresource with a done context, like the client of the API server
+func updateSubResourceUntilDone(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+}
+
+func TestKeycloakClientController_Lifecycle(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client: &v1alpha1.KeycloakAPIClient{
+				ClientID:               "app",
+				Secret:                 "secret",
+				ServiceAccountsEnabled: true,
+				Description:            "created",
+			},
+			Roles: []v1alpha1.RoleRepresentation{{Name: "read"}},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+
+	// when
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	created := server.Client("test", "app")
+	assert.NotNil(t, created)
+	assert.Equal(t, "created", created.Description)
+	assert.Equal(t, "secret", created.Secret)
+
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.True(t, instance.Status.Ready, instance.Status.Message)
+	assert.Contains(t, instance.Finalizers, ClientFinalizer)
+	assert.Equal(t, instance.Generation, instance.Status.ObservedGeneration)
+	assert.NotNil(t, instance.Status.LastSyncTime)
+	for _, conditionType := range []string{v1alpha1.ConditionReady, v1alpha1.ConditionSynced, v1alpha1.ConditionKeycloakReachable, v1alpha1.ConditionSecretReady} {
+		assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, conditionType), conditionType)
+	}
+
+	secret := &v1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: model.ClientSecret(cr).Name}, secret))
+	assert.Equal(t, []byte("secret"), secret.Data[model.ClientSecretClientSecretProperty])
+
+	// when
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the Keycloak IDs are kept in the status, the spec is never written
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"read"}, server.ClientRoles("test", "app"))
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, cr.Spec, instance.Spec)
+	assert.Equal(t, created.ID, instance.Status.ID)
+	assert.Equal(t, "test", instance.Status.Realm)
+	assert.Contains(t, instance.Status.RoleIDs, "read")
+
+	// when
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.Client.Description = "updated"
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", server.Client("test", "app").Description)
+
+	// when
+	assert.NoError(t, r.Client.Delete(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, server.Client("test", "app"))
+	assert.True(t, k8serrors.IsNotFound(r.Client.Get(context.TODO(), request.NamespacedName, instance)))
+}
+
+func TestKeycloakClientController_KeycloakError(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	server.Fail("POST", "/admin/realms/test/clients", 403)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+
+	// when
+	result, err := r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, ClientRequeueDelayPermanentError, result.RequeueAfter)
+	assert.Nil(t, server.Client("test", "app"))
+
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.False(t, instance.Status.Ready)
+	assert.Equal(t, v1alpha1.PhaseFailing, instance.Status.Phase)
+	ready := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionReady)
+	assert.Equal(t, v13.ConditionFalse, ready.Status)
+	assert.Equal(t, v1alpha1.ReasonReconcileFailed, ready.Reason)
+	assert.Contains(t, ready.Message, "(403)")
+	assert.True(t, meta.IsStatusConditionFalse(instance.Status.Conditions, v1alpha1.ConditionSynced))
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionKeycloakReachable))
+}
+
+func TestKeycloakClientController_KeycloakUnreachable(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test", Generation: 2},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	server.Fail("POST", "/realms/master/protocol/openid-connect/token", 401)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+
+	// when
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, instance.Generation, instance.Status.ObservedGeneration)
+	assert.Nil(t, instance.Status.LastSyncTime)
+	for _, conditionType := range []string{v1alpha1.ConditionReady, v1alpha1.ConditionSynced, v1alpha1.ConditionKeycloakReachable} {
+		condition := meta.FindStatusCondition(instance.Status.Conditions, conditionType)
+		assert.Equal(t, v13.ConditionFalse, condition.Status, conditionType)
+		assert.Equal(t, v1alpha1.ReasonKeycloakUnreachable, condition.Reason, conditionType)
+		assert.Equal(t, instance.Generation, condition.ObservedGeneration, conditionType)
+	}
+	assert.Nil(t, meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionSecretReady))
+}
+
+func TestKeycloakClientController_Targets(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	server.AddRealm("other")
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr, &v1alpha1.KeycloakRealm{
+		ObjectMeta: v13.ObjectMeta{Name: "other", Namespace: "test", Labels: map[string]string{"application": "sso"}},
+		Spec: v1alpha1.KeycloakRealmSpec{
+			InstanceSelector: &v13.LabelSelector{MatchLabels: map[string]string{"app": "sso"}},
+			Realm:            &v1alpha1.KeycloakAPIRealm{Realm: "other"},
+		},
+	})
+	server.Fail("POST", "/admin/realms/other/clients", 500)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+
+	// when
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then the failing realm does not keep the client from being created in the other one
+	assert.NoError(t, err)
+	assert.NotNil(t, server.Client("test", "app"))
+	assert.Nil(t, server.Client("other", "app"))
+
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.False(t, instance.Status.Ready)
+	assert.Contains(t, instance.Status.Message, "keycloak test/keycloak, realm test/other")
+	assert.Len(t, instance.Status.Targets, 2)
+	targets := map[string]v1alpha1.KeycloakClientTarget{}
+	for _, target := range instance.Status.Targets {
+		targets[target.Realm] = target
+	}
+	assert.True(t, targets["test"].Synced)
+	assert.Equal(t, "test/keycloak", targets["test"].Keycloak)
+	assert.Equal(t, server.Client("test", "app").ID, targets["test"].ID)
+	assert.NotNil(t, targets["test"].LastSyncTime)
+	assert.False(t, targets["other"].Synced)
+	assert.Contains(t, targets["other"].Error, "(500)")
+	assert.Empty(t, targets["other"].ID)
+
+	// when
+	server.Fail("POST", "/admin/realms/other/clients", 0)
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then each target has its own client secret
+	assert.NoError(t, err)
+	assert.NotNil(t, server.Client("other", "app"))
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.True(t, instance.Status.Ready, instance.Status.Message)
+	for _, target := range instance.Status.Targets {
+		assert.True(t, target.Synced, target.Realm)
+		assert.Equal(t, server.Client(target.Realm, "app").ID, target.ID)
+		name := "keycloak-" + target.Realm
+		if target.Realm == instance.Status.Targets[0].Realm {
+			name = ""
+		}
+		assert.Equal(t, model.ClientTargetSecretSelector(cr, name).Name, target.SecretName)
+		secret := &v1.Secret{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: target.SecretName}, secret))
+		assert.Equal(t, []byte("secret"), secret.Data[model.ClientSecretClientSecretProperty])
+	}
+	assert.Equal(t, instance.Status.Targets[0].ID, instance.Status.ID)
+	assert.Equal(t, instance.Status.Targets[0].Realm, instance.Status.Realm)
+}
+
+func TestKeycloakClientController_TargetSecrets(t *testing.T) {
+	// given a client with a single target
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	server.AddRealm("other")
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	otherSecret := types.NamespacedName{Namespace: "test", Name: model.ClientTargetSecretSelector(cr, "keycloak-other").Name}
+
+	// when a realm is added, which is listed before the first one
+	realm := &v1alpha1.KeycloakRealm{
+		ObjectMeta: v13.ObjectMeta{Name: "other", Namespace: "test", Labels: map[string]string{"application": "sso"}},
+		Spec: v1alpha1.KeycloakRealmSpec{
+			InstanceSelector: &v13.LabelSelector{MatchLabels: map[string]string{"app": "sso"}},
+			Realm:            &v1alpha1.KeycloakAPIRealm{Realm: "other"},
+		},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), realm))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the first target keeps its client secret and the new one gets its own
+	assert.NoError(t, err)
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Len(t, instance.Status.Targets, 2)
+	assert.Equal(t, "test", instance.Status.Targets[0].Realm)
+	assert.Equal(t, model.ClientSecretSelector(cr).Name, instance.Status.Targets[0].SecretName)
+	assert.Equal(t, otherSecret.Name, instance.Status.Targets[1].SecretName)
+	assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), &v1.Secret{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), otherSecret, &v1.Secret{}))
+
+	// when the realm is removed again
+	assert.NoError(t, r.Client.Delete(context.TODO(), realm))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then its client secret is deleted
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Len(t, instance.Status.Targets, 1)
+	assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), &v1.Secret{}))
+	assert.True(t, k8serrors.IsNotFound(r.Client.Get(context.TODO(), otherSecret, &v1.Secret{})))
+}
+
+func TestKeycloakClientController_ReconcileTimeout(t *testing.T) {
+	// given a Keycloak answering slower than the timeout of the reconcile
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	r.ReconcileTimeout = 200 * time.Millisecond
+	server.Delay(http.MethodGet, "/admin/realms/test/clients/", time.Minute)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+
+	// when
+	result, err := r.Reconcile(context.TODO(), request)
+
+	// then the failure is recorded in the status, although the reconcile ran out of time
+	assert.NoError(t, err)
+	assert.Equal(t, ClientRequeueDelayError, result.RequeueAfter)
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.False(t, instance.Status.Ready)
+	assert.Contains(t, instance.Status.Message, "context deadline exceeded")
+	assert.True(t, meta.IsStatusConditionFalse(instance.Status.Conditions, v1alpha1.ConditionSynced))
+}
+
+func TestKeycloakClientController_Drift(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client: &v1alpha1.KeycloakAPIClient{
+				ClientID:     "app",
+				Secret:       "secret",
+				Description:  "managed",
+				RedirectUris: []string{"https://app.example.com/*", "https://app.example.com/callback"},
+			},
+			Roles:       []v1alpha1.RoleRepresentation{{Name: "read"}},
+			DriftPolicy: model.DriftPolicyReportOnly,
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	// when
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	drifted := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionDrifted)
+	assert.Equal(t, v13.ConditionFalse, drifted.Status)
+	assert.Equal(t, v1alpha1.ReasonNoDrift, drifted.Reason)
+
+	// when the client is changed in the admin console
+	keycloak := v1alpha1.Keycloak{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "keycloak"}, &keycloak))
+	authenticated, err := r.keycloakFactory().AuthenticatedClient(context.TODO(), keycloak, false)
+	assert.NoError(t, err)
+	changed := server.Client("test", "app")
+	changed.Description = "changed"
+	changed.RedirectUris = []string{"https://app.example.com/callback", "https://app.example.com/*"}
+	assert.NoError(t, authenticated.UpdateClient(context.TODO(), changed, "test"))
+	_, err = authenticated.CreateClientRole(context.TODO(), changed.ID, &v1alpha1.RoleRepresentation{Name: "write"}, "test")
+	assert.NoError(t, err)
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the drift is reported, but not corrected
+	assert.NoError(t, err)
+	assert.Equal(t, "changed", server.Client("test", "app").Description)
+	assert.ElementsMatch(t, []string{"read", "write"}, server.ClientRoles("test", "app"))
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.True(t, instance.Status.Ready, instance.Status.Message)
+	assert.Equal(t, []string{"client.description", "roles.write"}, instance.Status.Targets[0].Drift)
+	drifted = meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionDrifted)
+	assert.Equal(t, v13.ConditionTrue, drifted.Status)
+	assert.Equal(t, v1alpha1.ReasonDriftDetected, drifted.Reason)
+	assert.Equal(t, "keycloak test/keycloak, realm test/test: client.description, roles.write", drifted.Message)
+	synced := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionSynced)
+	assert.Equal(t, v13.ConditionFalse, synced.Status)
+	assert.Equal(t, v1alpha1.ReasonDriftDetected, synced.Reason)
+	assert.Contains(t, <-r.recorder.(*record.FakeRecorder).Events, "Warning Drifted client app in keycloak test/keycloak, realm test differs from the CR: client.description, roles.write")
+
+	// when
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.DriftPolicy = model.DriftPolicyCorrect
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "managed", server.Client("test", "app").Description)
+	assert.Equal(t, []string{"read"}, server.ClientRoles("test", "app"))
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	drifted = meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionDrifted)
+	assert.Equal(t, v1alpha1.ReasonDriftCorrected, drifted.Reason)
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionSynced))
+
+	// when
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.True(t, meta.IsStatusConditionFalse(instance.Status.Conditions, v1alpha1.ConditionDrifted))
+}
+
+func TestKeycloakClientController_DriftReportOnlyRotation(t *testing.T) {
+	// given a client with a due key pair rotation
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector:        &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:               &v1alpha1.KeycloakAPIClient{ClientID: "app", Description: "managed"},
+			ClientAuthentication: model.ClientAuthenticationPrivateKeyJWT,
+			PrivateKeyJWT:        &v1alpha1.KeycloakClientPrivateKeyJWT{RotationInterval: &v13.Duration{Duration: 24 * time.Hour}},
+			DriftPolicy:          model.DriftPolicyReportOnly,
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	_, err = r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	secret := &v1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), secret))
+	keyID := string(secret.Data[model.ClientSecretKeyIDProperty])
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	lastRotation := v13.NewTime(time.Now().Add(-25 * time.Hour).Truncate(time.Second))
+	instance.Status.LastKeyPairRotation = &lastRotation
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), instance))
+
+	// when the client drifted
+	keycloak := v1alpha1.Keycloak{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "keycloak"}, &keycloak))
+	authenticated, err := r.keycloakFactory().AuthenticatedClient(context.TODO(), keycloak, false)
+	assert.NoError(t, err)
+	changed := server.Client("test", "app")
+	changed.Description = "changed"
+	assert.NoError(t, authenticated.UpdateClient(context.TODO(), changed, "test"))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the rotation is neither run nor recorded
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, []string{"client.description"}, instance.Status.Targets[0].Drift)
+	assert.True(t, lastRotation.Equal(instance.Status.LastKeyPairRotation))
+	assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), secret))
+	assert.Equal(t, keyID, string(secret.Data[model.ClientSecretKeyIDProperty]))
+	assert.Contains(t, server.Client("test", "app").Attributes["jwks.string"], keyID)
+}
+
+func TestKeycloakClientController_DryRun(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test", Annotations: map[string]string{model.ClientDryRunAnnotation: "true"}},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client: &v1alpha1.KeycloakAPIClient{
+				ClientID: "app",
+				Secret:   "secret",
+			},
+			Roles: []v1alpha1.RoleRepresentation{{Name: "read"}},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+
+	// when
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then the changes are planned, but not applied
+	assert.NoError(t, err)
+	assert.Nil(t, server.Client("test", "app"))
+	assert.True(t, k8serrors.IsNotFound(r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), &v1.Secret{})))
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.True(t, instance.Status.Ready, instance.Status.Message)
+	assert.Nil(t, instance.Status.LastSyncTime)
+	assert.False(t, instance.Status.Targets[0].Synced)
+	assert.Contains(t, instance.Status.Targets[0].Plan, "create client test/app")
+	synced := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionSynced)
+	assert.Equal(t, v13.ConditionFalse, synced.Status)
+	assert.Equal(t, v1alpha1.ReasonDryRun, synced.Reason)
+	assert.Equal(t, fmt.Sprintf("dry run, %v action(s) planned", len(instance.Status.Targets[0].Plan)), synced.Message)
+	assert.Contains(t, <-r.recorder.(*record.FakeRecorder).Events, "Normal Planned dry run of client app in keycloak test/keycloak, realm test: ")
+
+	// when
+	delete(instance.Annotations, model.ClientDryRunAnnotation)
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NotNil(t, server.Client("test", "app"))
+	assert.Equal(t, []string{"read"}, server.ClientRoles("test", "app"))
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Empty(t, instance.Status.Targets[0].Plan)
+	assert.True(t, instance.Status.Targets[0].Synced)
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionSynced))
+	assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), &v1.Secret{}))
+
+	// when the unchanged client is run dry again
+	instance.Annotations = map[string]string{model.ClientDryRunAnnotation: "true"}
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	for range 2 {
+		_, err = r.Reconcile(context.TODO(), request)
+		assert.NoError(t, err)
+	}
+
+	// then nothing is planned
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Empty(t, instance.Status.Targets[0].Plan)
+	synced = meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionSynced)
+	assert.Equal(t, "dry run, 0 action(s) planned", synced.Message)
+
+	// when the CR is changed
+	instance.Spec.Client.Description = "changed"
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then only the update of the client is planned
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, []string{"update client test/app"}, instance.Status.Targets[0].Plan)
+	delete(instance.Annotations, model.ClientDryRunAnnotation)
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+
+	// when the client is deleted with the dry run of the controller
+	r.DryRun = true
+	assert.NoError(t, r.Client.Delete(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the client is kept in Keycloak and the finalizer is kept
+	assert.NoError(t, err)
+	assert.NotNil(t, server.Client("test", "app"))
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Contains(t, instance.Finalizers, ClientFinalizer)
+	assert.Contains(t, instance.Status.Targets[0].Plan, "removing client test/app")
+}
+
+func TestKeycloakClientController_ProtocolMappers(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client: &v1alpha1.KeycloakAPIClient{
+				ClientID:               "app",
+				Secret:                 "secret",
+				ServiceAccountsEnabled: true,
+				ProtocolMappers: []v1alpha1.KeycloakProtocolMapper{
+					{Name: "audience", ProtocolMapper: "oidc-audience-mapper", Config: map[string]string{"included.client.audience": "a"}},
+				},
+			},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	mappers := func() map[string]v1alpha1.KeycloakProtocolMapper {
+		result := map[string]v1alpha1.KeycloakProtocolMapper{}
+		for _, mapper := range server.Client("test", "app").ProtocolMappers {
+			result[mapper.Name] = mapper
+		}
+		return result
+	}
+
+	// when
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, mappers(), 4)
+	assert.Equal(t, "a", mappers()["audience"].Config["included.client.audience"])
+	assert.Contains(t, mappers(), "Client ID")
+
+	// when
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.Client.ProtocolMappers = []v1alpha1.KeycloakProtocolMapper{
+		{Name: "audience", ProtocolMapper: "oidc-audience-mapper", Config: map[string]string{"included.client.audience": "b"}},
+		{Name: "claim", ProtocolMapper: "oidc-hardcoded-claim-mapper", Config: map[string]string{"claim.name": "c"}},
+	}
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, mappers(), 5)
+	assert.Equal(t, "b", mappers()["audience"].Config["included.client.audience"])
+	assert.Equal(t, "openid-connect", mappers()["claim"].Protocol)
+
+	// when
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.Client.ProtocolMappers = instance.Spec.Client.ProtocolMappers[1:]
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, mappers(), 4)
+	assert.NotContains(t, mappers(), "audience")
+	assert.Contains(t, mappers(), "claim")
+}
+
+func TestKeycloakClientController_Authorization(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client: &v1alpha1.KeycloakAPIClient{
+				ClientID:                     "app",
+				Secret:                       "secret",
+				AuthorizationServicesEnabled: true,
+				AuthorizationSettings: &v1alpha1.KeycloakResourceServer{
+					Scopes:    []v1alpha1.KeycloakScope{{Name: "view"}},
+					Resources: []v1alpha1.KeycloakResource{{Name: "documents", Uris: []string{"/documents/*"}}},
+					Policies: []v1alpha1.KeycloakPolicy{
+						{Name: "clients", Type: "client", Config: map[string]string{"clients": `["app"]`}},
+						{Name: "documents permission", Type: "resource", Resources: []string{"documents"}, Policies: []string{"clients"}},
+					},
+				},
+			},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+
+	// when
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	settings := server.AuthorizationSettings("test", "app")
+	assert.NotNil(t, settings)
+	assert.Len(t, settings.Resources, 1)
+	assert.Len(t, settings.Policies, 2)
+	requests := len(server.Requests())
+
+	// when: nothing changed
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	for _, request := range server.Requests()[requests:] {
+		assert.NotRegexp(t, "^(POST|PUT|DELETE) .*/authz/", request)
+	}
+
+	// when
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.Client.AuthorizationSettings = &v1alpha1.KeycloakResourceServer{
+		PolicyEnforcementMode: "PERMISSIVE",
+		Scopes:                []v1alpha1.KeycloakScope{{Name: "view"}, {Name: "edit"}},
+		Resources: []v1alpha1.KeycloakResource{
+			{Name: "documents", Uris: []string{"/documents/*"}, Scopes: []apiextensionsv1.JSON{{Raw: []byte(`{"name":"edit"}`)}}},
+		},
+		Policies: []v1alpha1.KeycloakPolicy{
+			{Name: "clients", Type: "client", Config: map[string]string{"clients": `["app","other"]`}},
+			{Name: "edit permission", Type: "scope", Resources: []string{"documents"}, Scopes: []string{"edit"}, Policies: []string{"clients"}},
+		},
+	}
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	settings = server.AuthorizationSettings("test", "app")
+	assert.Equal(t, "PERMISSIVE", settings.PolicyEnforcementMode)
+	assert.Len(t, settings.Scopes, 2)
+	assert.Len(t, settings.Resources[0].Scopes, 1)
+	assert.Len(t, settings.Policies, 2)
+	assert.Equal(t, `["app","other"]`, settings.Policies[0].Config["clients"])
+	assert.Equal(t, "edit permission", settings.Policies[1].Name)
+	assert.Equal(t, []string{"edit"}, settings.Policies[1].Scopes)
+}
+
+func TestKeycloakClientController_SecretRotation(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	server.Features = append(server.Features, v1alpha1.KeycloakFeature{Name: common.ClientSecretRotationFeature, Enabled: true})
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test", CreationTimestamp: v13.NewTime(time.Now().Add(-48 * time.Hour))},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client: &v1alpha1.KeycloakAPIClient{
+				ClientID: "app",
+				Secret:   "secret",
+			},
+			SecretRotation: &v1alpha1.KeycloakClientSecretRotation{
+				Interval:    v13.Duration{Duration: 24 * time.Hour},
+				GracePeriod: v13.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	keycloak := &v1alpha1.Keycloak{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "keycloak"}, keycloak))
+	keycloak.Status.Features = []string{common.ClientSecretRotationFeature}
+	assert.NoError(t, r.Client.Update(context.TODO(), keycloak))
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	secret := func() map[string][]byte {
+		s := &v1.Secret{}
+		assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), s))
+		return s.Data
+	}
+
+	// when the client is created
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then it is not rotated right away
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", server.Client("test", "app").Secret)
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.NotNil(t, instance.Status.SecretRotationStart)
+
+	// when the interval has elapsed since the rotation was enabled
+	instance.Status.SecretRotationStart = &v13.Time{Time: time.Now().Add(-25 * time.Hour)}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), instance))
+	result, err := r.Reconcile(context.TODO(), request)
+
+	// then the secret is rotated and the previous secret stays valid for the grace period
+	assert.NoError(t, err)
+	rotated := server.Client("test", "app").Secret
+	assert.NotEqual(t, "secret", rotated)
+	assert.Equal(t, "secret", server.ClientRotatedSecret("test", "app"))
+	assert.Equal(t, []byte(rotated), secret()[model.ClientSecretClientSecretProperty])
+	assert.Equal(t, []byte("secret"), secret()[model.ClientSecretPreviousClientSecretProperty])
+	assert.InDelta(t, time.Hour.Seconds(), result.RequeueAfter.Seconds(), 5)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.NotNil(t, instance.Status.LastSecretRotation)
+
+	// when reconciled again within the grace period
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the secret in Keycloak is kept, although the CR still contains the initial secret
+	assert.NoError(t, err)
+	assert.Equal(t, rotated, server.Client("test", "app").Secret)
+	assert.Equal(t, []byte(rotated), secret()[model.ClientSecretClientSecretProperty])
+	assert.Equal(t, []byte("secret"), secret()[model.ClientSecretPreviousClientSecretProperty])
+
+	// when the grace period is over
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Status.LastSecretRotation = &v13.Time{Time: time.Now().Add(-2 * time.Hour)}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), instance))
+	result, err = r.Reconcile(context.TODO(), request)
+
+	// then the previous secret is invalidated
+	assert.NoError(t, err)
+	assert.Empty(t, server.ClientRotatedSecret("test", "app"))
+	assert.Equal(t, []byte(rotated), secret()[model.ClientSecretClientSecretProperty])
+	assert.NotContains(t, secret(), model.ClientSecretPreviousClientSecretProperty)
+	assert.InDelta(t, (22 * time.Hour).Seconds(), result.RequeueAfter.Seconds(), 5)
+}
+
+func TestKeycloakClientController_SecretRotationTargets(t *testing.T) {
+	// given a client in two Keycloaks
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	other := fakekeycloak.NewServer()
+	defer other.Close()
+	other.AddRealm("test")
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+			SecretRotation: &v1alpha1.KeycloakClientSecretRotation{
+				Interval: v13.Duration{Duration: 24 * time.Hour},
+			},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr,
+		&v1alpha1.Keycloak{
+			ObjectMeta: v13.ObjectMeta{Name: "other", Namespace: "test", Labels: map[string]string{"app": "sso"}},
+			Spec:       v1alpha1.KeycloakSpec{External: v1alpha1.KeycloakExternal{Enabled: true}},
+			Status:     v1alpha1.KeycloakStatus{ExternalURL: other.URL},
+		},
+		&v1.Secret{
+			ObjectMeta: v13.ObjectMeta{Name: "credential-other", Namespace: "test"},
+			Data: map[string][]byte{
+				model.AdminUsernameProperty: []byte(fakekeycloak.AdminUser),
+				model.AdminPasswordProperty: []byte(fakekeycloak.AdminPassword),
+			},
+		})
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Len(t, instance.Status.Targets, 2)
+
+	// when the interval has elapsed in both
+	started := &v13.Time{Time: time.Now().Add(-25 * time.Hour)}
+	instance.Status.SecretRotationStart = started
+	for index := range instance.Status.Targets {
+		assert.NotNil(t, instance.Status.Targets[index].SecretRotationStart)
+		instance.Status.Targets[index].SecretRotationStart = started
+	}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), instance))
+	result, err := r.Reconcile(context.TODO(), request)
+
+	// then the secrets of both are rotated
+	assert.NoError(t, err)
+	assert.InDelta(t, (24 * time.Hour).Seconds(), result.RequeueAfter.Seconds(), 5)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	for _, target := range instance.Status.Targets {
+		assert.NotNil(t, target.LastSecretRotation, target.Keycloak)
+		rotated := server.Client("test", "app").Secret
+		if target.Keycloak == "test/other" {
+			rotated = other.Client("test", "app").Secret
+		}
+		assert.NotEqual(t, "secret", rotated, target.Keycloak)
+		secret := &v1.Secret{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: target.SecretName}, secret))
+		assert.Equal(t, []byte(rotated), secret.Data[model.ClientSecretClientSecretProperty], target.Keycloak)
+	}
+}
+
+func TestKeycloakClientController_StatusUpdateFailure(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+		SubResourceUpdate: func(context.Context, client.Client, string, client.Object, ...client.SubResourceUpdateOption) error {
+			return errors.New("conflict")
+		},
+	})
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+
+	// when
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then the reconcile is retried, as e.g. the time of a secret rotation is lost otherwise
+	assert.EqualError(t, err, "conflict")
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.NotContains(t, instance.Finalizers, ClientFinalizer)
+}
+
+func TestKeycloakClientController_SecretRotationEnabled(t *testing.T) {
+	// given an existing client without secret rotation
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test", CreationTimestamp: v13.NewTime(time.Now().Add(-48 * time.Hour))},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	// when the rotation is enabled
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.SecretRotation = &v1alpha1.KeycloakClientSecretRotation{Interval: v13.Duration{Duration: 24 * time.Hour}}
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	result, err := r.Reconcile(context.TODO(), request)
+
+	// then the first rotation is due one interval later, not counted from the creation of the CR
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", server.Client("test", "app").Secret)
+	assert.InDelta(t, (24 * time.Hour).Seconds(), result.RequeueAfter.Seconds(), 5)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.NotNil(t, instance.Status.SecretRotationStart)
+	assert.Nil(t, instance.Status.LastSecretRotation)
+
+	// when the rotation is disabled
+	instance.Spec.SecretRotation = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Nil(t, instance.Status.SecretRotationStart)
+}
+
+func TestKeycloakClientController_ClientDeletedInKeycloak(t *testing.T) {
+	// given a client whose secret has been rotated
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+			SecretRotation: &v1alpha1.KeycloakClientSecretRotation{
+				Interval: v13.Duration{Duration: 24 * time.Hour},
+			},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Status.SecretRotationStart = &v13.Time{Time: time.Now().Add(-25 * time.Hour)}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	rotated := server.Client("test", "app").Secret
+	assert.NotEqual(t, "secret", rotated)
+
+	// when the client is deleted in Keycloak
+	keycloak := v1alpha1.Keycloak{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "keycloak"}, &keycloak))
+	authenticated, err := r.keycloakFactory().AuthenticatedClient(context.TODO(), keycloak, false)
+	assert.NoError(t, err)
+	assert.NoError(t, authenticated.DeleteClient(context.TODO(), server.Client("test", "app").ID, "test"))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then it is recreated with the secret the workloads hold and the existing Secret is kept
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.True(t, instance.Status.Ready, instance.Status.Message)
+	assert.Equal(t, rotated, server.Client("test", "app").Secret)
+	secret := &v1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), secret))
+	assert.Equal(t, []byte(rotated), secret.Data[model.ClientSecretClientSecretProperty])
+}
+
+func TestKeycloakClientController_SecretTemplate(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+			SecretTemplate: &v1alpha1.KeycloakClientSecretTemplate{
+				Data: map[string]string{
+					"clientId":     "{{ .ClientID }}",
+					"clientSecret": "{{ .ClientSecret }}",
+					"issuer":       "{{ .IssuerURL }}",
+				},
+				Annotations: map[string]string{"example.com/token-url": "{{ .TokenURL }}"},
+			},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	issuer := server.URL + "/realms/test"
+	secret := &v1.Secret{}
+
+	// when
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), secret))
+	assert.Equal(t, map[string][]byte{
+		"clientId":     []byte("app"),
+		"clientSecret": []byte("secret"),
+		"issuer":       []byte(issuer),
+	}, secret.Data)
+	assert.Equal(t, issuer+"/protocol/openid-connect/token", secret.Annotations["example.com/token-url"])
+
+	// when the template is changed
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.SecretTemplate.Data = map[string]string{"jwks": "{{ .JWKSURL }}"}
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), secret))
+	assert.Equal(t, map[string][]byte{"jwks": []byte(issuer + "/protocol/openid-connect/certs")}, secret.Data)
+
+	// when the template is invalid
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.SecretTemplate.Data = map[string]string{"jwks": "{{ .JWKS }}"}
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, v1alpha1.PhaseFailing, instance.Status.Phase)
+	assert.Contains(t, instance.Status.Message, `invalid secret template data "jwks"`)
+}
+
+func TestKeycloakClientController_Installations(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+			Installations: []v1alpha1.KeycloakClientInstallation{
+				{ProviderID: "keycloak-oidc-keycloak-json", Key: "keycloak.json"},
+				{ProviderID: "saml-idp-descriptor", Key: "idp.xml", Kind: model.ClientInstallationKindConfigMap, Name: "app-idp"},
+			},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	secret := &v1.Secret{}
+	configMap := &v1.ConfigMap{}
+
+	// when the client is created, the installations are published with the next reconcile
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "keycloak-client-installation-app"}, secret))
+	assert.JSONEq(t, `{"realm":"test","resource":"app","credentials":{"secret":"secret"}}`, string(secret.Data["keycloak.json"]))
+	assert.Equal(t, "app", secret.OwnerReferences[0].Name)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "app-idp"}, configMap))
+	assert.Equal(t, `<EntityDescriptor entityID="test"></EntityDescriptor>`, configMap.Data["idp.xml"])
+
+	// when the client changes
+	instance := &v1alpha1.KeycloakClient{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.Client.Secret = "changed"
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the installation is refreshed in the same reconcile
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "keycloak-client-installation-app"}, secret))
+	assert.JSONEq(t, `{"realm":"test","resource":"app","credentials":{"secret":"changed"}}`, string(secret.Data["keycloak.json"]))
+
+	// when an unknown provider is requested
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.Installations = append(instance.Spec.Installations, v1alpha1.KeycloakClientInstallation{ProviderID: "unknown", Key: "unknown"})
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, v1alpha1.PhaseFailing, instance.Status.Phase)
+	assert.Contains(t, instance.Status.Message, "installation provider unknown is not available")
+}
+
+func TestKeycloakClientController_SecretRef(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app"},
+			SecretRef:     &v1alpha1.KeycloakClientSecretRef{Name: "app-credentials", Key: "password"},
+		},
+	}
+	credentials := &v1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "app-credentials", Namespace: "test"},
+		Data:       map[string][]byte{"password": []byte("from-ref")},
+	}
+	r := newTestClientReconciler(t, server, cr, credentials)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	instance := &v1alpha1.KeycloakClient{}
+	secret := &v1.Secret{}
+
+	// when the client is created
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then it gets the referenced secret, which is never written into the CR
+	assert.NoError(t, err)
+	assert.Equal(t, "from-ref", server.Client("test", "app").Secret)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.NotEmpty(t, instance.Status.ID)
+	assert.Empty(t, instance.Spec.Client.Secret)
+	assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), secret))
+	assert.Equal(t, []byte("from-ref"), secret.Data[model.ClientSecretClientSecretProperty])
+
+	// when the referenced secret changes
+	credentials.Data["password"] = []byte("changed")
+	assert.NoError(t, r.Client.Update(context.TODO(), credentials))
+	requests := r.referencedSecretRequests(context.TODO(), credentials)
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the client is requeued and the secret pushed to Keycloak
+	assert.NoError(t, err)
+	assert.Equal(t, []ctrl.Request{request}, requests)
+	assert.Equal(t, "changed", server.Client("test", "app").Secret)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Empty(t, instance.Spec.Client.Secret)
+	assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), secret))
+	assert.Equal(t, []byte("changed"), secret.Data[model.ClientSecretClientSecretProperty])
+	assert.Empty(t, r.referencedSecretRequests(context.TODO(), secret))
+}
+
+func TestKeycloakClientController_SecretRef_OtherNamespace(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app"},
+			SecretRef:     &v1alpha1.KeycloakClientSecretRef{Name: "shared", Namespace: "secrets"},
+		},
+	}
+	shared := &v1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "shared", Namespace: "secrets"},
+		Data:       map[string][]byte{model.ClientSecretClientSecretProperty: []byte("shared")},
+	}
+	r := newTestClientReconciler(t, server, cr, shared)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	instance := &v1alpha1.KeycloakClient{}
+
+	// when the secret does not allow the namespace of the client
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, server.Client("test", "app"))
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, v1alpha1.PhaseFailing, instance.Status.Phase)
+	assert.Contains(t, instance.Status.Message, "secret secrets/shared does not allow clients of namespace test")
+
+	// when it does
+	shared.Annotations = map[string]string{model.ClientSecretRefAllowedNamespacesAnnotation: "test"}
+	assert.NoError(t, r.Client.Update(context.TODO(), shared))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "shared", server.Client("test", "app").Secret)
+	assert.Equal(t, []ctrl.Request{request}, r.referencedSecretRequests(context.TODO(), shared))
+}
+
+func TestKeycloakClientController_SecretTargets(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+			SecretTargets: []v1alpha1.KeycloakClientSecretTarget{
+				{Name: "app-oidc"},
+				{Name: "app", Namespace: "gateway", Template: &v1alpha1.KeycloakClientSecretTemplate{
+					Data: map[string]string{"client-id": "{{ .ClientID }}", "client-secret": "{{ .ClientSecret }}"},
+				}},
+			},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	instance := &v1alpha1.KeycloakClient{}
+	secret := &v1.Secret{}
+
+	// when the namespace of a target is not allowed
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, server.Client("test", "app"))
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, v1alpha1.PhaseFailing, instance.Status.Phase)
+	assert.Contains(t, instance.Status.Message, "secret target gateway/app is not in an allowed namespace")
+
+	// when the realm allows it
+	realm := &v1alpha1.KeycloakRealm{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "test"}, realm))
+	realm.Spec.AllowedSecretTargetNamespaces = []string{"gateway"}
+	assert.NoError(t, r.Client.Update(context.TODO(), realm))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the targets are written
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "app-oidc"}, secret))
+	assert.Equal(t, []byte("secret"), secret.Data[model.ClientSecretClientSecretProperty])
+	assert.Empty(t, secret.OwnerReferences)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "gateway", Name: "app"}, secret))
+	assert.Equal(t, map[string][]byte{"client-id": []byte("app"), "client-secret": []byte("secret")}, secret.Data)
+	assert.Equal(t, "test", secret.Labels[model.ClientSecretTargetNamespaceLabel])
+	assert.Equal(t, "app", secret.Labels[model.ClientSecretTargetNameLabel])
+
+	// when a target is removed
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.SecretTargets = instance.Spec.SecretTargets[:1]
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then it is deleted
+	assert.NoError(t, err)
+	assert.True(t, k8serrors.IsNotFound(r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "gateway", Name: "app"}, secret)))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "app-oidc"}, secret))
+
+	// when the client is deleted
+	assert.NoError(t, r.Client.Delete(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the remaining targets are deleted as well
+	assert.NoError(t, err)
+	assert.True(t, k8serrors.IsNotFound(r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "app-oidc"}, secret)))
+}
+
+func TestKeycloakClientController_PrivateKeyJWT(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector:        &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:               &v1alpha1.KeycloakAPIClient{ClientID: "app"},
+			ClientAuthentication: model.ClientAuthenticationPrivateKeyJWT,
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	instance := &v1alpha1.KeycloakClient{}
+	secret := func() *v1.Secret {
+		s := &v1.Secret{}
+		assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), s))
+		return s
+	}
+
+	// when the client is created
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then the public key is registered and the private key stored in the client secret
+	assert.NoError(t, err)
+	representation := server.Client("test", "app")
+	assert.Equal(t, model.ClientJWTAuthenticator, representation.ClientAuthenticatorType)
+	keyID := string(secret().Data[model.ClientSecretKeyIDProperty])
+	assert.NotEmpty(t, keyID)
+	assert.Contains(t, representation.Attributes["jwks.string"], keyID)
+	assert.Contains(t, string(secret().Data[model.ClientSecretPrivateKeyProperty]), "PRIVATE KEY")
+	assert.NotContains(t, secret().Data, model.ClientSecretClientSecretProperty)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.NotNil(t, instance.Status.LastKeyPairRotation)
+
+	// when reconciled again
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the key pair is kept
+	assert.NoError(t, err)
+	assert.Equal(t, keyID, string(secret().Data[model.ClientSecretKeyIDProperty]))
+	assert.Contains(t, server.Client("test", "app").Attributes["jwks.string"], keyID)
+
+	// when the algorithm changes
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	instance.Spec.PrivateKeyJWT = &v1alpha1.KeycloakClientPrivateKeyJWT{Algorithm: model.KeyPairAlgorithmES256}
+	assert.NoError(t, r.Client.Update(context.TODO(), instance))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then a new key pair is generated
+	assert.NoError(t, err)
+	newKeyID := string(secret().Data[model.ClientSecretKeyIDProperty])
+	assert.NotEqual(t, keyID, newKeyID)
+	representation = server.Client("test", "app")
+	assert.Contains(t, representation.Attributes["jwks.string"], newKeyID)
+	assert.Equal(t, model.KeyPairAlgorithmES256, representation.Attributes["token.endpoint.auth.signing.alg"])
+}
+
+func TestKeycloakClientController_PrivateKeyJWTGracePeriod(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector:        &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:               &v1alpha1.KeycloakAPIClient{ClientID: "app"},
+			ClientAuthentication: model.ClientAuthenticationPrivateKeyJWT,
+			PrivateKeyJWT: &v1alpha1.KeycloakClientPrivateKeyJWT{
+				RotationInterval: &v13.Duration{Duration: 24 * time.Hour},
+				GracePeriod:      &v13.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	r := newTestClientReconciler(t, server, cr)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	instance := &v1alpha1.KeycloakClient{}
+	keyID := func() string {
+		secret := &v1.Secret{}
+		assert.NoError(t, r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), secret))
+		return string(secret.Data[model.ClientSecretKeyIDProperty])
+	}
+	rotatedBefore := func(age time.Duration) {
+		assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+		lastRotation := v13.NewTime(time.Now().Add(-age))
+		instance.Status.LastKeyPairRotation = &lastRotation
+		assert.NoError(t, r.Client.Status().Update(context.TODO(), instance))
+	}
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	previousKeyID := keyID()
+
+	// when the key pair is rotated
+	rotatedBefore(25 * time.Hour)
+	result, err := r.Reconcile(context.TODO(), request)
+
+	// then the previous public key stays registered until the end of the grace period
+	assert.NoError(t, err)
+	currentKeyID := keyID()
+	assert.NotEqual(t, previousKeyID, currentKeyID)
+	assert.Contains(t, server.Client("test", "app").Attributes["jwks.string"], currentKeyID)
+	assert.Contains(t, server.Client("test", "app").Attributes["jwks.string"], previousKeyID)
+	assert.InDelta(t, time.Hour, result.RequeueAfter, float64(time.Minute))
+
+	// when reconciled again during the grace period
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, currentKeyID, keyID())
+	assert.Contains(t, server.Client("test", "app").Attributes["jwks.string"], previousKeyID)
+
+	// when the grace period has passed
+	rotatedBefore(2 * time.Hour)
+	result, err = r.Reconcile(context.TODO(), request)
+
+	// then the previous public key is dropped
+	assert.NoError(t, err)
+	assert.Equal(t, currentKeyID, keyID())
+	assert.Contains(t, server.Client("test", "app").Attributes["jwks.string"], currentKeyID)
+	assert.NotContains(t, server.Client("test", "app").Attributes["jwks.string"], previousKeyID)
+	assert.InDelta(t, 22*time.Hour, result.RequeueAfter, float64(time.Minute))
+}
+
+func newTestCertificatePEM(t *testing.T, commonName string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestKeycloakClientController_X509(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector:        &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:               &v1alpha1.KeycloakAPIClient{ClientID: "app"},
+			ClientAuthentication: model.ClientAuthenticationX509,
+			X509:                 &v1alpha1.KeycloakClientX509{SecretName: "app-tls"},
+		},
+	}
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	tls := &v1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "app-tls", Namespace: "test"},
+		Type:       v1.SecretTypeTLS,
+		Data:       map[string][]byte{v1.TLSCertKey: newTestCertificatePEM(t, "app", notAfter)},
+	}
+	r := newTestClientReconciler(t, server, cr, tls)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "app"}}
+	instance := &v1alpha1.KeycloakClient{}
+
+	// when the client is created
+	_, err := r.Reconcile(context.TODO(), request)
+
+	// then it authenticates with the certificate
+	assert.NoError(t, err)
+	representation := server.Client("test", "app")
+	assert.Equal(t, model.ClientX509Authenticator, representation.ClientAuthenticatorType)
+	assert.Equal(t, "CN=app", representation.Attributes["x509.subjectdn"])
+	assert.Equal(t, "true", representation.Attributes["tls.client.certificate.bound.access.tokens"])
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.True(t, notAfter.Equal(instance.Status.CertificateNotAfter.Time))
+
+	// when the certificate is renewed
+	renewed := notAfter.Add(24 * time.Hour)
+	tls.Data[v1.TLSCertKey] = newTestCertificatePEM(t, "app-renewed", renewed)
+	assert.NoError(t, r.Client.Update(context.TODO(), tls))
+	requests := r.referencedSecretRequests(context.TODO(), tls)
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the client is requeued and updated
+	assert.NoError(t, err)
+	assert.Equal(t, []ctrl.Request{request}, requests)
+	assert.Equal(t, "CN=app-renewed", server.Client("test", "app").Attributes["x509.subjectdn"])
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.True(t, renewed.Equal(instance.Status.CertificateNotAfter.Time))
+
+	// when the secret is missing
+	assert.NoError(t, r.Client.Delete(context.TODO(), tls))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, v1alpha1.PhaseFailing, instance.Status.Phase)
+	assert.Contains(t, instance.Status.Message, "certificate secret test/app-tls of client app not found")
+}
+
+func TestKeycloakClientController_SAML(t *testing.T) {
+	// given
+	server := fakekeycloak.NewServer()
+	defer server.Close()
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "sp", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &v13.LabelSelector{MatchLabels: map[string]string{"application": "sso"}},
+			Client:        &v1alpha1.KeycloakAPIClient{ClientID: "https://sp.example.com", Protocol: model.SAMLProtocol, PublicClient: true},
+			SAML: &v1alpha1.KeycloakClientSAML{
+				ClientSignatureRequired:      true,
+				SigningCertificateSecretName: "sp-tls",
+				AssertionConsumerURLPost:     "https://sp.example.com/acs",
+				IdPDescriptorConfigMapName:   "sp-idp",
+			},
+		},
+	}
+	tls := &v1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "sp-tls", Namespace: "test"},
+		Type:       v1.SecretTypeTLS,
+		Data:       map[string][]byte{v1.TLSCertKey: newTestCertificatePEM(t, "sp", time.Now().Add(time.Hour))},
+	}
+	r := newTestClientReconciler(t, server, cr, tls)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "sp"}}
+	instance := &v1alpha1.KeycloakClient{}
+
+	// when the client is created, the IdP descriptor is published with the next reconcile
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then the SAML attributes are set and no client secret is written
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, v1alpha1.PhaseReconciling, instance.Status.Phase)
+	assert.Nil(t, meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionSecretReady))
+	assert.Empty(t, instance.Spec.Client.Secret)
+	representation := server.Client("test", "https://sp.example.com")
+	assert.Equal(t, "true", representation.Attributes["saml.client.signature"])
+	assert.Equal(t, "https://sp.example.com/acs", representation.Attributes["saml_assertion_consumer_url_post"])
+	assert.NotEmpty(t, representation.Attributes["saml.signing.certificate"])
+	assert.True(t, k8serrors.IsNotFound(r.Client.Get(context.TODO(), model.ClientSecretSelector(cr), &v1.Secret{})))
+	configMap := &v1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "sp-idp"}, configMap))
+	assert.Contains(t, configMap.Data[model.SAMLIdPDescriptorKey], "EntityDescriptor")
+	assert.Equal(t, []ctrl.Request{request}, r.referencedSecretRequests(context.TODO(), tls))
+
+	// when the signing certificate is missing
+	assert.NoError(t, r.Client.Delete(context.TODO(), tls))
+	_, err = r.Reconcile(context.TODO(), request)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, instance))
+	assert.Equal(t, v1alpha1.PhaseFailing, instance.Status.Phase)
+	assert.Contains(t, instance.Status.Message, "certificate secret test/sp-tls of client https://sp.example.com not found")
+}
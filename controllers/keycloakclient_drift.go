@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"slices"
+	"sort"
+
+	kc "github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/movewp3/keycloakclient-controller/pkg/common"
+	"github.com/movewp3/keycloakclient-controller/pkg/model"
+)
+
+// Drift returns the fields of the existing client in Keycloak which differ from the CR, e.g. client.redirectUris,
+// roles.admin, scopeMappings.realm.offline_access, defaultClientScopes.email or serviceAccountRealmRoles.admin.
+// It is computed after ReconcileIt, which completes the client of the CR with the values set by the controller. A
+// client deleted in Keycloak is reported as client.
+func (i *DedicatedKeycloakClientReconciler) Drift(state *common.ClientState, cr *kc.KeycloakClient) []string {
+	if state.Client == nil {
+		return []string{"client"}
+	}
+
+	drift := model.ClientDrift(cr.Spec.Client, state.Client)
+	drift = append(drift, roleDrift(state, cr)...)
+	drift = append(drift, scopeMappingDrift(state, cr)...)
+
+	defaultClientScopes, optionalClientScopes := i.desiredClientScopes(state, cr)
+	drift = append(drift, clientScopeDrift("defaultClientScopes", defaultClientScopes, state.DefaultClientScopes)...)
+	drift = append(drift, clientScopeDrift("optionalClientScopes", optionalClientScopes, state.OptionalClientScopes)...)
+
+	if cr.Spec.Client.ServiceAccountsEnabled && state.ServiceAccountUserState != nil {
+		drift = append(drift, serviceAccountRoleDrift(state, cr)...)
+	}
+
+	sort.Strings(drift)
+	return slices.Compact(drift)
+}
+
+// roleDrift matches the roles by name, the uma_protection role of clients with authorization services is expected
+func roleDrift(state *common.ClientState, cr *kc.KeycloakClient) []string {
+	var drift []string
+	existing := make(map[string]kc.RoleRepresentation)
+	for _, role := range state.Roles {
+		existing[role.Name] = role
+	}
+	desired := make(map[string]bool)
+	for _, role := range cr.Spec.Roles {
+		desired[role.Name] = true
+		current, found := existing[role.Name]
+		if !found || role.Description != current.Description {
+			drift = append(drift, "roles."+role.Name)
+		}
+	}
+	authorization := cr.Spec.Client.AuthorizationServicesEnabled || cr.Spec.Client.AuthorizationSettings != nil
+	for _, role := range state.Roles {
+		if !desired[role.Name] && !(authorization && role.Name == umaRoleName) {
+			drift = append(drift, "roles."+role.Name)
+		}
+	}
+	return drift
+}
+
+func scopeMappingDrift(state *common.ClientState, cr *kc.KeycloakClient) []string {
+	var drift []string
+	for _, mappings := range []*kc.MappingsRepresentation{
+		scopeMappingDifference(cr.Spec.ScopeMappings, state.ScopeMappings),
+		scopeMappingDifference(state.ScopeMappings, cr.Spec.ScopeMappings),
+	} {
+		for _, role := range mappings.RealmMappings {
+			drift = append(drift, "scopeMappings.realm."+role.Name)
+		}
+		for clientID, clientMappings := range mappings.ClientMappings {
+			for _, role := range clientMappings.Mappings {
+				drift = append(drift, "scopeMappings.client."+clientID+"."+role.Name)
+			}
+		}
+	}
+	return drift
+}
+
+func clientScopeDrift(field string, desired, existing []kc.KeycloakClientScope) []string {
+	var drift []string
+	added, _ := model.ClientScopeDifferenceIntersection(desired, existing)
+	removed, _ := model.ClientScopeDifferenceIntersection(existing, desired)
+	for _, clientScope := range append(added, removed...) {
+		drift = append(drift, field+"."+clientScope.Name)
+	}
+	return drift
+}
+
+// serviceAccountRoleDrift derives the drift from the role assignments the reconcile of the service account would
+// change
+func serviceAccountRoleDrift(state *common.ClientState, cr *kc.KeycloakClient) []string {
+	userState := state.ServiceAccountUserState
+	clientIDs := make(map[string]string)
+	for _, client := range userState.Clients {
+		clientIDs[client.ID] = client.ClientID
+	}
+
+	var drift []string
+	actions := append(GetUserRealmRolesDesiredState(userState, cr.Spec.ServiceAccountRealmRoles, state.Realm.Spec.Realm.Realm),
+		GetUserClientRolesDesiredState(userState, cr.Spec.ServiceAccountClientRoles, state.Realm.Spec.Realm.Realm)...)
+	for _, action := range actions {
+		switch action := action.(type) {
+		case *common.AssignRealmRoleAction:
+			drift = append(drift, "serviceAccountRealmRoles."+action.Ref.Name)
+		case *common.RemoveRealmRoleAction:
+			drift = append(drift, "serviceAccountRealmRoles."+action.Ref.Name)
+		case *common.AssignClientRoleAction:
+			drift = append(drift, "serviceAccountClientRoles."+clientIDs[action.ClientID]+"."+action.Ref.Name)
+		case *common.RemoveClientRoleAction:
+			drift = append(drift, "serviceAccountClientRoles."+clientIDs[action.ClientID]+"."+action.Ref.Name)
+		}
+	}
+	return drift
+}
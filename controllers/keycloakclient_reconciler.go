@@ -2,21 +2,34 @@ package controllers
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	kc "github.com/movewp3/keycloakclient-controller/api/v1alpha1"
 	"github.com/movewp3/keycloakclient-controller/pkg/common"
 	"github.com/movewp3/keycloakclient-controller/pkg/model"
 	"github.com/movewp3/keycloakclient-controller/pkg/util"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	umaRoleName = "uma_protection"
+	// basicClientScope is a default client scope of all clients since Keycloak 25, it adds the sub claim to tokens
+	basicClientScope = "basic"
 )
 
+// serviceAccountProtocolMappers are added by Keycloak to clients with service accounts, they are kept even if the
+// CR does not contain them
+var serviceAccountProtocolMappers = []string{"Client ID", "Client Host", "Client IP Address"}
+
 type ClientReconciler interface {
 	Reconcile(cr *kc.KeycloakClient) error
 }
@@ -37,27 +50,43 @@ func (i *DedicatedKeycloakClientReconciler) ReconcileIt(state *common.ClientStat
 	desired.AddAction(i.pingKeycloak())
 	if cr.DeletionTimestamp != nil {
 		desired.AddAction(i.getDeletedClientState(state, cr))
+		if !state.SecondaryTarget {
+			for _, secret := range state.SecretTargets {
+				desired.AddAction(i.getDeletedClientSecretTargetState(cr, secret.DeepCopy()))
+			}
+		}
 		return desired
 	}
 
-	if state.Client == nil { // no configuration of a keycloakclient in keycloak
-		if cr.Spec.Client.Secret == "" {
+	if model.UsesPrivateKeyJWT(cr) {
+		i.reconcileKeyPair(state, cr)
+	}
+	if model.UsesX509(cr) && state.ClientCertificate != nil {
+		model.SetClientCertificate(cr.Spec.Client, state.ClientCertificate, cr.Spec.X509)
+		cr.Status.CertificateNotAfter = &metav1.Time{Time: state.ClientCertificate.NotAfter}
+	}
+	if model.IsSAML(cr) && cr.Spec.SAML != nil {
+		model.SetClientSAML(cr.Spec.Client, cr.Spec.SAML, state.SAMLSigningCertificate, state.SAMLEncryptionCertificate)
+	}
+	startSecretRotation(cr)
 
+	if state.Client == nil { // no configuration of a keycloakclient in keycloak
+		// a client deleted in Keycloak is recreated with the secret the workloads hold, which differs from the CR
+		// after a rotation
+		if (cr.Spec.Client.Secret == "" || cr.Spec.SecretRotation != nil) && !model.IsSAML(cr) && cr.Spec.SecretRef == nil &&
+			state.ClientSecret != nil && !bytes.Equal(state.ClientSecret.Data[model.ClientSecretClientSecretProperty], []byte("")) {
+			logKcc.Info("reconstruct Secret for " + cr.Spec.Client.ClientID)
+			cr.Spec.Client.Secret = string(state.ClientSecret.Data[model.ClientSecretClientSecretProperty])
+		}
+		if cr.Spec.Client.Secret == "" && !model.IsSAML(cr) {
 			sha, err := util.GetClientShaCode(cr.Spec.Client.ClientID)
 			if err == nil {
 				cr.Spec.Client.Secret = sha
-			} else {
-				if state.ClientSecret != nil {
-					if !bytes.Equal(state.ClientSecret.Data["CLIENT_SECRET"], []byte("")) {
-						logKcc.Info("reconstruct Secret for " + cr.Spec.Client.ID)
-						cr.Spec.Client.Secret = string(state.ClientSecret.Data["CLIENT_SECRET"])
-					}
-				}
 			}
 		}
 		desired.AddAction(i.getCreatedClientState(state, cr))
 	} else { // keycloakclient already exists in keycloak
-		if cr.Spec.Client.Secret == "" {
+		if cr.Spec.Client.Secret == "" && !model.IsSAML(cr) {
 			// at this place the cr has the secret if there was a secret in keycloak, even if nothing was specified in the cr
 			// the secret should stay stable if possible. if a secret was created already, then cont change it.
 			// if it should be changed, then delete the client in keycloak and the controller will create a new one with a secret
@@ -69,11 +98,25 @@ func (i *DedicatedKeycloakClientReconciler) ReconcileIt(state *common.ClientStat
 		desired.AddAction(i.getUpdatedClientState(state, cr))
 	}
 
-	if state.ClientSecret == nil {
-		logKcc.Info("k8s secret for client is missing, create it for " + cr.Spec.Client.ClientID)
-		desired.AddAction(i.getCreatedClientSecretState(state, cr))
-	} else {
-		desired.AddAction(i.getUpdatedClientSecretState(state, cr))
+	// SAML clients have no client secret
+	if !model.IsSAML(cr) {
+		if state.ClientSecret == nil {
+			logKcc.Info("k8s secret for client is missing, create it for " + cr.Spec.Client.ClientID)
+			desired.AddAction(i.getCreatedClientSecretState(state, cr))
+		} else if secretRotationDue(state, cr) {
+			desired.AddAction(i.getRotatedClientSecretState(state, cr))
+		} else {
+			desired.AddAction(i.getUpdatedClientSecretState(state, cr))
+			if state.RotatedClientSecret != "" && cr.Status.LastSecretRotation != nil && !inSecretRotationGracePeriod(cr) {
+				desired.AddAction(i.getInvalidatedClientRotatedSecretState(state, cr))
+			}
+		}
+	}
+
+	// the secret targets and installations are published for the first realm and Keycloak only, otherwise the
+	// targets of the client would overwrite each other
+	if !state.SecondaryTarget {
+		i.ReconcileSecretTargets(state, cr, &desired)
 	}
 
 	if state.DeprecatedClientSecret != nil {
@@ -84,6 +127,15 @@ func (i *DedicatedKeycloakClientReconciler) ReconcileIt(state *common.ClientStat
 
 	i.ReconcileRoles(state, cr, &desired)
 
+	if state.Client != nil {
+		// the protocol mappers and authorization settings of a new client are created together with the client
+		i.ReconcileProtocolMappers(state, cr, &desired)
+		i.ReconcileAuthorization(state, cr, &desired)
+		if !state.SecondaryTarget {
+			i.ReconcileInstallations(state, cr, &desired)
+		}
+	}
+
 	i.ReconcileScopeMappings(state, cr, &desired)
 
 	i.ReconcileClientScopes(state, cr, &desired)
@@ -97,6 +149,16 @@ func (i *DedicatedKeycloakClientReconciler) ReconcileIt(state *common.ClientStat
 	return desired
 }
 
+// ReconcileDefaults reconciles the client scopes and service account roles Keycloak assigns to new clients
+func (i *DedicatedKeycloakClientReconciler) ReconcileDefaults(state *common.ClientState, cr *kc.KeycloakClient) common.DesiredClusterState {
+	desired := common.DesiredClusterState{}
+	i.ReconcileClientScopes(state, cr, &desired)
+	if cr.Spec.Client.ServiceAccountsEnabled {
+		i.ReconcileServiceAccountRoles(state, cr, &desired)
+	}
+	return desired
+}
+
 func (i *DedicatedKeycloakClientReconciler) ReconcileRoles(state *common.ClientState, cr *kc.KeycloakClient, desired *common.DesiredClusterState) {
 	// delete existing roles for which no desired role is found that (matches by ID OR has no ID but matches by name)
 	// this implies that specifying a role with matching name but different ID will result in deletion (and re-creation)
@@ -150,6 +212,271 @@ func (i *DedicatedKeycloakClientReconciler) ReconcileRoles(state *common.ClientS
 	}
 }
 
+// ReconcileProtocolMappers matches the protocol mappers of the CR by name with those of the client, because
+// Keycloak ignores protocol mappers on client updates
+func (i *DedicatedKeycloakClientReconciler) ReconcileProtocolMappers(state *common.ClientState, cr *kc.KeycloakClient, desired *common.DesiredClusterState) {
+	existingMappers := make(map[string]kc.KeycloakProtocolMapper)
+	for _, mapper := range state.ProtocolMappers {
+		existingMappers[mapper.Name] = mapper
+	}
+	desiredMappers := make(map[string]bool)
+	for _, mapper := range cr.Spec.Client.ProtocolMappers {
+		desiredMappers[mapper.Name] = true
+	}
+
+	var mappersDeleted, mappersUpdated, mappersNew []kc.KeycloakProtocolMapper
+	for _, mapper := range state.ProtocolMappers {
+		if desiredMappers[mapper.Name] {
+			continue
+		}
+		if cr.Spec.Client.ServiceAccountsEnabled && slices.Contains(serviceAccountProtocolMappers, mapper.Name) {
+			continue
+		}
+		mappersDeleted = append(mappersDeleted, mapper)
+	}
+
+	for _, mapper := range cr.Spec.Client.ProtocolMappers {
+		if mapper.Protocol == "" {
+			mapper.Protocol = clientProtocol(cr)
+		}
+		existing, found := existingMappers[mapper.Name]
+		switch {
+		case !found:
+			mapper.ID = ""
+			mappersNew = append(mappersNew, mapper)
+		case mapper.Protocol != existing.Protocol || mapper.ProtocolMapper != existing.ProtocolMapper:
+			// the type of a protocol mapper cannot be changed, it is recreated instead
+			mappersDeleted = append(mappersDeleted, existing)
+			mapper.ID = ""
+			mappersNew = append(mappersNew, mapper)
+		case protocolMapperChanged(mapper, existing):
+			mapper.ID = existing.ID
+			mappersUpdated = append(mappersUpdated, mapper)
+		}
+	}
+
+	for _, mapper := range mappersDeleted {
+		desired.AddAction(i.getDeletedClientProtocolMapperState(state, cr, mapper.DeepCopy()))
+	}
+	for _, mapper := range mappersUpdated {
+		desired.AddAction(i.getUpdatedClientProtocolMapperState(state, cr, mapper.DeepCopy()))
+	}
+	for _, mapper := range mappersNew {
+		desired.AddAction(i.getCreatedClientProtocolMapperState(state, cr, mapper.DeepCopy()))
+	}
+}
+
+// clientProtocol returns the protocol of the client, Keycloak defaults to openid-connect
+func clientProtocol(cr *kc.KeycloakClient) string {
+	if cr.Spec.Client.Protocol == "" {
+		return "openid-connect"
+	}
+	return cr.Spec.Client.Protocol
+}
+
+func protocolMapperChanged(mapper, existing kc.KeycloakProtocolMapper) bool {
+	if mapper.ConsentRequired != existing.ConsentRequired || mapper.ConsentText != existing.ConsentText {
+		return true
+	}
+	if len(mapper.Config) != len(existing.Config) {
+		return true
+	}
+	for key, value := range mapper.Config {
+		if existingValue, found := existing.Config[key]; !found || existingValue != value {
+			return true
+		}
+	}
+	return false
+}
+
+// ReconcileAuthorization matches the authorization settings of the CR with those of the client, because Keycloak
+// ignores them on client updates. Scopes, resources, policies and permissions are matched by name. Permissions are
+// deleted before and created after the scopes, resources and policies they refer to.
+func (i *DedicatedKeycloakClientReconciler) ReconcileAuthorization(state *common.ClientState, cr *kc.KeycloakClient, desired *common.DesiredClusterState) {
+	settings := cr.Spec.Client.AuthorizationSettings
+	existing := state.AuthorizationSettings
+	if !cr.Spec.Client.AuthorizationServicesEnabled || settings == nil || existing == nil {
+		return
+	}
+
+	if authorizationSettingsChanged(settings, existing) {
+		updated := &kc.KeycloakResourceServer{
+			ID:                            existing.ID,
+			ClientID:                      existing.ClientID,
+			Name:                          existing.Name,
+			AllowRemoteResourceManagement: settings.AllowRemoteResourceManagement,
+			DecisionStrategy:              settings.DecisionStrategy,
+			PolicyEnforcementMode:         settings.PolicyEnforcementMode,
+		}
+		if updated.DecisionStrategy == "" {
+			updated.DecisionStrategy = existing.DecisionStrategy
+		}
+		if updated.PolicyEnforcementMode == "" {
+			updated.PolicyEnforcementMode = existing.PolicyEnforcementMode
+		}
+		desired.AddAction(i.getUpdatedAuthorizationSettingsState(state, cr, updated))
+	}
+
+	desiredPolicies := make(map[string]kc.KeycloakPolicy)
+	for _, policy := range settings.Policies {
+		desiredPolicies[policy.Name] = policy
+	}
+	existingPolicies := make(map[string]kc.KeycloakPolicy)
+	for _, policy := range existing.Policies {
+		existingPolicies[policy.Name] = policy
+	}
+	for _, permissions := range []bool{true, false} {
+		for _, policy := range existing.Policies {
+			if common.IsAuthorizationPermission(policy.Type) != permissions {
+				continue
+			}
+			desiredPolicy, found := desiredPolicies[policy.Name]
+			if !found || (desiredPolicy.Type != "" && desiredPolicy.Type != policy.Type) {
+				// the type of a policy cannot be changed, it is recreated instead
+				desired.AddAction(i.getDeletedAuthorizationPolicyState(state, cr, policy.DeepCopy()))
+			}
+		}
+	}
+
+	desiredResources := make(map[string]bool)
+	for _, resource := range settings.Resources {
+		desiredResources[resource.Name] = true
+	}
+	existingResources := make(map[string]kc.KeycloakResource)
+	for _, resource := range existing.Resources {
+		existingResources[resource.Name] = resource
+		if !desiredResources[resource.Name] {
+			desired.AddAction(i.getDeletedAuthorizationResourceState(state, cr, resource.DeepCopy()))
+		}
+	}
+
+	desiredScopes := make(map[string]bool)
+	for _, scope := range settings.Scopes {
+		desiredScopes[scope.Name] = true
+	}
+	existingScopes := make(map[string]kc.KeycloakScope)
+	for _, scope := range existing.Scopes {
+		existingScopes[scope.Name] = scope
+		if !desiredScopes[scope.Name] {
+			desired.AddAction(i.getDeletedAuthorizationScopeState(state, cr, scope.DeepCopy()))
+		}
+	}
+
+	for _, scope := range settings.Scopes {
+		scope := kc.KeycloakScope{Name: scope.Name, DisplayName: scope.DisplayName, IconURI: scope.IconURI}
+		current, found := existingScopes[scope.Name]
+		switch {
+		case !found:
+			desired.AddAction(i.getCreatedAuthorizationScopeState(state, cr, &scope))
+		case scope.DisplayName != current.DisplayName || scope.IconURI != current.IconURI:
+			scope.ID = current.ID
+			desired.AddAction(i.getUpdatedAuthorizationScopeState(state, cr, &scope))
+		}
+	}
+
+	for _, resource := range settings.Resources {
+		current, found := existingResources[resource.Name]
+		switch {
+		case !found:
+			resource.ID = ""
+			desired.AddAction(i.getCreatedAuthorizationResourceState(state, cr, resource.DeepCopy()))
+		case authorizationResourceChanged(resource, current):
+			resource.ID = current.ID
+			desired.AddAction(i.getUpdatedAuthorizationResourceState(state, cr, resource.DeepCopy()))
+		}
+	}
+
+	for _, permissions := range []bool{false, true} {
+		for _, policy := range settings.Policies {
+			if common.IsAuthorizationPermission(policy.Type) != permissions {
+				continue
+			}
+			current, found := existingPolicies[policy.Name]
+			switch {
+			case !found || (policy.Type != "" && policy.Type != current.Type):
+				policy.ID = ""
+				desired.AddAction(i.getCreatedAuthorizationPolicyState(state, cr, policy.DeepCopy()))
+			case authorizationPolicyChanged(policy, current):
+				policy.ID = current.ID
+				policy.Type = current.Type
+				desired.AddAction(i.getUpdatedAuthorizationPolicyState(state, cr, policy.DeepCopy()))
+			}
+		}
+	}
+}
+
+// authorizationSettingsChanged compares the settings of a resource server. Unset strategies keep the values of
+// Keycloak.
+func authorizationSettingsChanged(settings, existing *kc.KeycloakResourceServer) bool {
+	return settings.AllowRemoteResourceManagement != existing.AllowRemoteResourceManagement ||
+		(settings.DecisionStrategy != "" && settings.DecisionStrategy != existing.DecisionStrategy) ||
+		(settings.PolicyEnforcementMode != "" && settings.PolicyEnforcementMode != existing.PolicyEnforcementMode)
+}
+
+func authorizationResourceChanged(resource, existing kc.KeycloakResource) bool {
+	if resource.DisplayName != existing.DisplayName || resource.Type != existing.Type || resource.IconURI != existing.IconURI ||
+		resource.OwnerManagedAccess != existing.OwnerManagedAccess {
+		return true
+	}
+	if len(resource.Attributes) != len(existing.Attributes) {
+		return true
+	}
+	for key, value := range resource.Attributes {
+		if existingValue, found := existing.Attributes[key]; !found || existingValue != value {
+			return true
+		}
+	}
+	return !sameElements(resource.Uris, existing.Uris) ||
+		!sameElements(authorizationScopeNames(resource.Scopes), authorizationScopeNames(existing.Scopes))
+}
+
+// authorizationPolicyChanged compares a policy of the CR with the policy in Keycloak. Only the config options of the
+// CR are compared, as Keycloak adds further options depending on the type of the policy.
+func authorizationPolicyChanged(policy, existing kc.KeycloakPolicy) bool {
+	if policy.Description != existing.Description ||
+		(policy.Logic != "" && policy.Logic != existing.Logic) ||
+		(policy.DecisionStrategy != "" && policy.DecisionStrategy != existing.DecisionStrategy) {
+		return true
+	}
+	for key, value := range policy.Config {
+		if existingValue, found := existing.Config[key]; !found || existingValue != value {
+			return true
+		}
+	}
+	if common.HasAuthorizationPolicyAssociations(existing.Type) {
+		return !sameElements(policy.Resources, existing.Resources) ||
+			!sameElements(policy.Scopes, existing.Scopes) ||
+			!sameElements(policy.Policies, existing.Policies)
+	}
+	return false
+}
+
+// authorizationScopeNames returns the names of the scopes of a resource, which are either given as scope
+// representations or by name
+func authorizationScopeNames(scopes []apiextensionsv1.JSON) []string {
+	var names []string
+	for _, scope := range scopes {
+		var representation kc.KeycloakScope
+		if err := json.Unmarshal(scope.Raw, &representation); err == nil {
+			names = append(names, representation.Name)
+			continue
+		}
+		var name string
+		if err := json.Unmarshal(scope.Raw, &name); err == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// sameElements returns true if both slices contain the same strings, regardless of their order
+func sameElements(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	sort.Strings(a)
+	sort.Strings(b)
+	return slices.Equal(a, b)
+}
+
 func (i *DedicatedKeycloakClientReconciler) ReconcileScopeMappings(state *common.ClientState, cr *kc.KeycloakClient, desired *common.DesiredClusterState) {
 	if cr.Spec.ScopeMappings == nil {
 		cr.Spec.ScopeMappings = &kc.MappingsRepresentation{}
@@ -185,25 +512,7 @@ func (i *DedicatedKeycloakClientReconciler) ReconcileClientScopes(state *common.
 
 	logKcc.Info(fmt.Sprintf("ReconcileClientScopes %s", cr.Spec.Client.Name))
 
-	oldClientScopes := cr.Spec.Client.DefaultClientScopes
-	//addedDefaultClientScope := false
-
-	additionalDefaultClientScopes := cr.Spec.Client.DefaultClientScopes
-	if cr.Spec.Client.PublicClient {
-		for _, scope := range getAdditionalDefaultClientScopes() {
-			if !slices.Contains(cr.Spec.Client.DefaultClientScopes, scope) {
-				logKcc.Info(fmt.Sprintf("Add default client scope %v",
-					getAdditionalDefaultClientScopes()))
-				additionalDefaultClientScopes = append(additionalDefaultClientScopes, scope)
-			}
-		}
-		defer func() {
-			cr.Spec.Client.DefaultClientScopes = oldClientScopes
-		}()
-
-	}
-
-	defaultClientScopes := model.FilterClientScopesByNames(state.AvailableClientScopes, additionalDefaultClientScopes)
+	defaultClientScopes, optionalClientScopes := i.desiredClientScopes(state, cr)
 
 	defaultClientScopesNew, _ := model.ClientScopeDifferenceIntersection(defaultClientScopes, state.DefaultClientScopes)
 	for _, clientScope := range defaultClientScopesNew {
@@ -215,8 +524,6 @@ func (i *DedicatedKeycloakClientReconciler) ReconcileClientScopes(state *common.
 		desired.AddAction(i.getDeletedClientDefaultClientScopeState(state, cr, clientScope.DeepCopy()))
 	}
 
-	optionalClientScopes := model.FilterClientScopesByNames(state.AvailableClientScopes, cr.Spec.Client.OptionalClientScopes)
-
 	optionalClientScopesNew, _ := model.ClientScopeDifferenceIntersection(optionalClientScopes, state.OptionalClientScopes)
 	for _, clientScope := range optionalClientScopesNew {
 		desired.AddAction(i.getCreatedClientOptionalClientScopeState(state, cr, clientScope.DeepCopy()))
@@ -228,6 +535,31 @@ func (i *DedicatedKeycloakClientReconciler) ReconcileClientScopes(state *common.
 	}
 }
 
+// desiredClientScopes returns the available default and optional client scopes of the CR. Public clients get the
+// additional default client scopes of the controller, clients of Keycloak 25 and later the basic client scope.
+func (i *DedicatedKeycloakClientReconciler) desiredClientScopes(state *common.ClientState, cr *kc.KeycloakClient) ([]kc.KeycloakClientScope, []kc.KeycloakClientScope) {
+	additionalDefaultClientScopes := slices.Clone(cr.Spec.Client.DefaultClientScopes)
+	if cr.Spec.Client.PublicClient && !model.IsSAML(cr) {
+		for _, scope := range getAdditionalDefaultClientScopes() {
+			if !slices.Contains(cr.Spec.Client.DefaultClientScopes, scope) {
+				logKcc.Info(fmt.Sprintf("Add default client scope %v",
+					getAdditionalDefaultClientScopes()))
+				additionalDefaultClientScopes = append(additionalDefaultClientScopes, scope)
+			}
+		}
+	}
+
+	// Keycloak 25 moved the sub claim into the basic client scope, removing it would break the tokens of the client
+	if common.KeycloakVersionAtLeast(i.Keycloak, 25) && !model.IsSAML(cr) &&
+		!slices.Contains(additionalDefaultClientScopes, basicClientScope) &&
+		!slices.Contains(cr.Spec.Client.OptionalClientScopes, basicClientScope) {
+		additionalDefaultClientScopes = append(additionalDefaultClientScopes, basicClientScope)
+	}
+
+	return model.FilterClientScopesByNames(state.AvailableClientScopes, additionalDefaultClientScopes),
+		model.FilterClientScopesByNames(state.AvailableClientScopes, cr.Spec.Client.OptionalClientScopes)
+}
+
 func (i *DedicatedKeycloakClientReconciler) ReconcileServiceAccountRoles(state *common.ClientState, cr *kc.KeycloakClient, desired *common.DesiredClusterState) {
 	if state.ServiceAccountUserState != nil {
 		logKcc.Info("Reconciling service account roles")
@@ -352,6 +684,63 @@ func (i *DedicatedKeycloakClientReconciler) pingKeycloak() common.ClusterAction
 	}
 }
 
+// ReconcileSecretTargets creates or updates the secret targets of the CR and deletes the Secrets labeled as secret
+// target of the CR which are no longer listed
+func (i *DedicatedKeycloakClientReconciler) ReconcileSecretTargets(state *common.ClientState, cr *kc.KeycloakClient, desired *common.DesiredClusterState) {
+	existing := map[client.ObjectKey]*v1.Secret{}
+	for _, secret := range state.SecretTargets {
+		existing[client.ObjectKeyFromObject(&secret)] = secret.DeepCopy()
+	}
+
+	for _, target := range cr.Spec.SecretTargets {
+		secret := model.ClientSecretTarget(cr, target)
+		key := client.ObjectKeyFromObject(secret)
+		if current, ok := existing[key]; ok {
+			secret = current
+			delete(existing, key)
+		}
+		desired.AddAction(i.getAppliedClientSecretTargetState(state, cr, secret, model.ClientSecretTargetTemplate(cr, target)))
+	}
+
+	for _, secret := range state.SecretTargets {
+		if stale, ok := existing[client.ObjectKeyFromObject(&secret)]; ok {
+			desired.AddAction(i.getDeletedClientSecretTargetState(cr, stale))
+		}
+	}
+}
+
+// ReconcileInstallations publishes the documents of the installation providers in the Secrets and ConfigMaps of the
+// CR, one action per object. The documents are fetched when the actions run, i.e. after the client has been updated.
+func (i *DedicatedKeycloakClientReconciler) ReconcileInstallations(state *common.ClientState, cr *kc.KeycloakClient, desired *common.DesiredClusterState) {
+	targets := map[string]client.Object{}
+	installations := map[string][]kc.KeycloakClientInstallation{}
+	var keys []string
+	for _, installation := range model.ClientInstallations(cr) {
+		name := model.ClientInstallationObjectName(cr, installation)
+		kind := model.ClientInstallationKind(cr, installation)
+		key := kind + "/" + name
+		if _, ok := targets[key]; !ok {
+			if kind == model.ClientInstallationKindConfigMap {
+				targets[key] = model.ClientInstallationConfigMap(cr, name)
+				if existing := state.InstallationConfigMaps[name]; existing != nil {
+					targets[key] = existing.DeepCopy()
+				}
+			} else {
+				targets[key] = model.ClientInstallationSecret(cr, name)
+				if existing := state.InstallationSecrets[name]; existing != nil {
+					targets[key] = existing.DeepCopy()
+				}
+			}
+			keys = append(keys, key)
+		}
+		installations[key] = append(installations[key], installation)
+	}
+
+	for _, key := range keys {
+		desired.AddAction(i.getPublishedClientInstallationsState(state, cr, targets[key], installations[key]))
+	}
+}
+
 func (i *DedicatedKeycloakClientReconciler) getDeletedClientState(state *common.ClientState, cr *kc.KeycloakClient) common.ClusterAction {
 	return common.DeleteClientAction{
 		Ref:   cr,
@@ -376,12 +765,96 @@ func (i *DedicatedKeycloakClientReconciler) getDeletedDeprecatedClientSecretStat
 }
 
 func (i *DedicatedKeycloakClientReconciler) getUpdatedClientSecretState(state *common.ClientState, cr *kc.KeycloakClient) common.ClusterAction {
+	data := clientSecretTemplateData(state, cr)
+	if state.RotatedClientSecret != "" && inSecretRotationGracePeriod(cr) {
+		data.PreviousClientSecret = state.RotatedClientSecret
+	}
 	return common.GenericUpdateAction{
-		Ref: model.ClientSecretReconciled(cr, state.ClientSecret),
+		Ref: renderClientSecret(model.ClientSecretReconciled(cr, state.ClientSecret), cr, data),
 		Msg: fmt.Sprintf("update client secret %v/%v", cr.Namespace, cr.Name),
 	}
 }
 
+func (i *DedicatedKeycloakClientReconciler) getRotatedClientSecretState(state *common.ClientState, cr *kc.KeycloakClient) common.ClusterAction {
+	return common.RotateClientSecretAction{
+		Secret:       model.ClientSecretReconciled(cr, state.ClientSecret),
+		Data:         clientSecretTemplateData(state, cr),
+		KeepPrevious: slices.Contains(state.Keycloak.Status.Features, common.ClientSecretRotationFeature),
+		Ref:          cr,
+		Realm:        state.Realm.Spec.Realm.Realm,
+		Msg:          fmt.Sprintf("rotate client secret %v/%v", cr.Namespace, cr.Spec.Client.ClientID),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getInvalidatedClientRotatedSecretState(state *common.ClientState, cr *kc.KeycloakClient) common.ClusterAction {
+	return common.InvalidateClientRotatedSecretAction{
+		Ref:   cr,
+		Realm: state.Realm.Spec.Realm.Realm,
+		Msg:   fmt.Sprintf("invalidate previous client secret %v/%v", cr.Namespace, cr.Spec.Client.ClientID),
+	}
+}
+
+// secretRotationDue returns true if the interval of the secret rotation has elapsed since the last rotation or,
+// before the first rotation, since the rotation was enabled. Only existing confidential clients are rotated.
+func secretRotationDue(state *common.ClientState, cr *kc.KeycloakClient) bool {
+	rotation := cr.Spec.SecretRotation
+	if rotation == nil || rotation.Interval.Duration <= 0 || state.Client == nil || cr.Spec.Client.PublicClient {
+		return false
+	}
+	return !time.Now().Before(lastSecretRotation(cr).Add(rotation.Interval.Duration))
+}
+
+// startSecretRotation records when the secret rotation was enabled, so that enabling it for an existing client does
+// not rotate the secret right away. Disabling the rotation resets it, the previous secret is not published then.
+func startSecretRotation(cr *kc.KeycloakClient) {
+	if cr.Spec.SecretRotation == nil {
+		cr.Status.SecretRotationStart = nil
+		cr.Status.LastSecretRotation = nil
+	} else if cr.Status.SecretRotationStart == nil {
+		now := metav1.Now()
+		cr.Status.SecretRotationStart = &now
+	}
+}
+
+// lastSecretRotation returns the time of the last rotation of the client secret, the start of the rotation before the
+// first rotation
+func lastSecretRotation(cr *kc.KeycloakClient) time.Time {
+	if cr.Status.LastSecretRotation != nil {
+		return cr.Status.LastSecretRotation.Time
+	}
+	if cr.Status.SecretRotationStart != nil {
+		return cr.Status.SecretRotationStart.Time
+	}
+	return time.Now()
+}
+
+// inSecretRotationGracePeriod returns true while the previous secret of the last rotation should stay valid
+func inSecretRotationGracePeriod(cr *kc.KeycloakClient) bool {
+	if cr.Spec.SecretRotation == nil || cr.Status.LastSecretRotation == nil {
+		return false
+	}
+	return time.Now().Before(cr.Status.LastSecretRotation.Add(cr.Spec.SecretRotation.GracePeriod.Duration))
+}
+
+// secretRotationRequeueDelay returns the time until the next rotation of the client secret or the end of the grace
+// period of the last rotation, whatever comes first. Returns 0 if the secret is not rotated.
+func secretRotationRequeueDelay(cr *kc.KeycloakClient) time.Duration {
+	rotation := cr.Spec.SecretRotation
+	if rotation == nil || rotation.Interval.Duration <= 0 || cr.Spec.Client.PublicClient || cr.DeletionTimestamp != nil {
+		return 0
+	}
+	next := lastSecretRotation(cr).Add(rotation.Interval.Duration)
+	if inSecretRotationGracePeriod(cr) {
+		if end := cr.Status.LastSecretRotation.Add(rotation.GracePeriod.Duration); end.Before(next) {
+			next = end
+		}
+	}
+	if delay := time.Until(next); delay > time.Second {
+		return delay
+	}
+	return time.Second
+}
+
 func (i *DedicatedKeycloakClientReconciler) getUpdatedClientState(state *common.ClientState, cr *kc.KeycloakClient) common.ClusterAction {
 	return common.UpdateClientAction{
 		Ref:   cr,
@@ -392,11 +865,152 @@ func (i *DedicatedKeycloakClientReconciler) getUpdatedClientState(state *common.
 
 func (i *DedicatedKeycloakClientReconciler) getCreatedClientSecretState(state *common.ClientState, cr *kc.KeycloakClient) common.ClusterAction {
 	return common.GenericCreateAction{
-		Ref: model.ClientSecret(cr),
+		Ref: renderClientSecret(model.ClientTargetSecret(cr, state.Target), cr, clientSecretTemplateData(state, cr)),
 		Msg: fmt.Sprintf("create client secret %v/%v", cr.Namespace, cr.Name),
 	}
 }
 
+func (i *DedicatedKeycloakClientReconciler) getAppliedClientSecretTargetState(state *common.ClientState, cr *kc.KeycloakClient, secret *v1.Secret, template *kc.KeycloakClientSecretTemplate) common.ClusterAction {
+	data := clientSecretTemplateData(state, cr)
+	rotationDue := state.ClientSecret != nil && secretRotationDue(state, cr)
+	if rotationDue && slices.Contains(state.Keycloak.Status.Features, common.ClientSecretRotationFeature) {
+		// the current secret becomes the previous one with the rotation
+		data.PreviousClientSecret = cr.Spec.Client.Secret
+	} else if !rotationDue && state.RotatedClientSecret != "" && inSecretRotationGracePeriod(cr) {
+		data.PreviousClientSecret = state.RotatedClientSecret
+	}
+	return common.ApplyClientSecretTargetAction{
+		Secret:         secret,
+		Template:       template,
+		Data:           data,
+		FollowRotation: rotationDue,
+		Ref:            cr,
+		Msg:            fmt.Sprintf("apply client secret target %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, client.ObjectKeyFromObject(secret)),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getDeletedClientSecretTargetState(cr *kc.KeycloakClient, secret *v1.Secret) common.ClusterAction {
+	return common.GenericDeleteAction{
+		Ref: secret,
+		Msg: fmt.Sprintf("delete client secret target %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, client.ObjectKeyFromObject(secret)),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getPublishedClientInstallationsState(state *common.ClientState, cr *kc.KeycloakClient, target client.Object, installations []kc.KeycloakClientInstallation) common.ClusterAction {
+	return common.PublishClientInstallationsAction{
+		Target:        target,
+		Installations: installations,
+		Ref:           cr,
+		Realm:         state.Realm.Spec.Realm.Realm,
+		Msg:           fmt.Sprintf("publish client installations %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, target.GetName()),
+	}
+}
+
+// reconcileKeyPair generates a new key pair if the client has none, the algorithm changed or the rotation is due,
+// and registers the public key with the client. The private key only ends up in the client secret.
+func (i *DedicatedKeycloakClientReconciler) reconcileKeyPair(state *common.ClientState, cr *kc.KeycloakClient) {
+	algorithm := model.ClientKeyPairAlgorithm(cr)
+	// the public key of the previous key pair stays registered during the grace period of the last rotation
+	var previous map[string]string
+	if state.KeyPair == nil || state.KeyPair.Algorithm != algorithm || keyPairRotationDue(cr) {
+		keyPair, err := model.GenerateClientKeyPair(algorithm)
+		if err != nil {
+			logKcc.Error(err, "cannot generate key pair for "+cr.Spec.Client.ClientID)
+			return
+		}
+		if state.KeyPair != nil && keyPairGracePeriod(cr) > 0 {
+			previous = state.KeyPair.JWK()
+		}
+		state.KeyPair = keyPair
+		now := metav1.Now()
+		cr.Status.LastKeyPairRotation = &now
+	} else if inKeyPairRotationGracePeriod(cr) {
+		previous = model.PreviousClientJWK(state.Client, state.KeyPair.KeyID)
+	}
+	if err := model.SetClientKeyPair(cr.Spec.Client, state.KeyPair, previous); err != nil {
+		logKcc.Error(err, "cannot register key pair for "+cr.Spec.Client.ClientID)
+	}
+}
+
+// keyPairRotationDue returns true if the rotation interval of the key pair has passed since its generation
+func keyPairRotationDue(cr *kc.KeycloakClient) bool {
+	interval := keyPairRotationInterval(cr)
+	return interval > 0 && !time.Now().Before(lastKeyPairRotation(cr).Add(interval))
+}
+
+func keyPairRotationInterval(cr *kc.KeycloakClient) time.Duration {
+	if cr.Spec.PrivateKeyJWT == nil || cr.Spec.PrivateKeyJWT.RotationInterval == nil || !model.UsesPrivateKeyJWT(cr) {
+		return 0
+	}
+	return cr.Spec.PrivateKeyJWT.RotationInterval.Duration
+}
+
+func keyPairGracePeriod(cr *kc.KeycloakClient) time.Duration {
+	if cr.Spec.PrivateKeyJWT == nil || cr.Spec.PrivateKeyJWT.GracePeriod == nil || !model.UsesPrivateKeyJWT(cr) {
+		return 0
+	}
+	return cr.Spec.PrivateKeyJWT.GracePeriod.Duration
+}
+
+// inKeyPairRotationGracePeriod returns true while the public key of the previous key pair should stay registered
+func inKeyPairRotationGracePeriod(cr *kc.KeycloakClient) bool {
+	return cr.Status.LastKeyPairRotation != nil && time.Now().Before(cr.Status.LastKeyPairRotation.Add(keyPairGracePeriod(cr)))
+}
+
+// lastKeyPairRotation returns the time the key pair was generated, the creation of the CR before the first rotation
+func lastKeyPairRotation(cr *kc.KeycloakClient) time.Time {
+	if cr.Status.LastKeyPairRotation != nil {
+		return cr.Status.LastKeyPairRotation.Time
+	}
+	return cr.CreationTimestamp.Time
+}
+
+// rotationRequeueDelay returns the time until the next rotation of the client secret or key pair or the end of the
+// grace period of the last key pair rotation, whatever comes first. Returns 0 if neither is rotated.
+func rotationRequeueDelay(cr *kc.KeycloakClient) time.Duration {
+	delay := secretRotationRequeueDelay(cr)
+	if cr.DeletionTimestamp != nil {
+		return delay
+	}
+	var next []time.Time
+	if interval := keyPairRotationInterval(cr); interval > 0 {
+		next = append(next, lastKeyPairRotation(cr).Add(interval))
+	}
+	if inKeyPairRotationGracePeriod(cr) {
+		next = append(next, cr.Status.LastKeyPairRotation.Add(keyPairGracePeriod(cr)))
+	}
+	for _, at := range next {
+		keyPairDelay := max(time.Until(at), time.Second)
+		if delay == 0 || keyPairDelay < delay {
+			delay = keyPairDelay
+		}
+	}
+	return delay
+}
+
+// clientSecretTemplateData returns the data the secret of the client is rendered with
+func clientSecretTemplateData(state *common.ClientState, cr *kc.KeycloakClient) model.ClientSecretTemplateData {
+	data := model.NewClientSecretTemplateData(cr, state.Realm.Spec.Realm.Realm, state.OpenIDConfiguration)
+	if state.KeyPair != nil && model.UsesPrivateKeyJWT(cr) {
+		privateKey, err := state.KeyPair.PrivateKeyPEM()
+		if err != nil {
+			logKcc.Error(err, "cannot encode private key for "+cr.Spec.Client.ClientID)
+		}
+		data.PrivateKey = privateKey
+		data.KeyID = state.KeyPair.KeyID
+	}
+	return data
+}
+
+// renderClientSecret renders the secret template of the CR onto the secret. The templates are validated before the
+// state is reconciled, if rendering fails nevertheless the secret keeps CLIENT_ID and CLIENT_SECRET.
+func renderClientSecret(secret *v1.Secret, cr *kc.KeycloakClient, data model.ClientSecretTemplateData) *v1.Secret {
+	if err := model.RenderClientSecret(secret, cr.Spec.SecretTemplate, data); err != nil {
+		logKcc.Error(err, "unable to render secret template of client "+cr.Spec.Client.ClientID)
+	}
+	return secret
+}
+
 func (i *DedicatedKeycloakClientReconciler) getCreatedClientRoleState(state *common.ClientState, cr *kc.KeycloakClient, role *kc.RoleRepresentation) common.ClusterAction {
 	return common.CreateClientRoleAction{
 		Role:  role,
@@ -425,6 +1039,123 @@ func (i *DedicatedKeycloakClientReconciler) getDeletedClientRoleState(state *com
 	}
 }
 
+func (i *DedicatedKeycloakClientReconciler) getCreatedClientProtocolMapperState(state *common.ClientState, cr *kc.KeycloakClient, mapper *kc.KeycloakProtocolMapper) common.ClusterAction {
+	return common.CreateClientProtocolMapperAction{
+		Mapper: mapper,
+		Ref:    cr,
+		Realm:  state.Realm.Spec.Realm.Realm,
+		Msg:    fmt.Sprintf("create client protocol mapper %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, mapper.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getUpdatedClientProtocolMapperState(state *common.ClientState, cr *kc.KeycloakClient, mapper *kc.KeycloakProtocolMapper) common.ClusterAction {
+	return common.UpdateClientProtocolMapperAction{
+		Mapper: mapper,
+		Ref:    cr,
+		Realm:  state.Realm.Spec.Realm.Realm,
+		Msg:    fmt.Sprintf("update client protocol mapper %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, mapper.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getDeletedClientProtocolMapperState(state *common.ClientState, cr *kc.KeycloakClient, mapper *kc.KeycloakProtocolMapper) common.ClusterAction {
+	return common.DeleteClientProtocolMapperAction{
+		Mapper: mapper,
+		Ref:    cr,
+		Realm:  state.Realm.Spec.Realm.Realm,
+		Msg:    fmt.Sprintf("delete client protocol mapper %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, mapper.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getUpdatedAuthorizationSettingsState(state *common.ClientState, cr *kc.KeycloakClient, settings *kc.KeycloakResourceServer) common.ClusterAction {
+	return common.UpdateAuthorizationSettingsAction{
+		Settings: settings,
+		Ref:      cr,
+		Realm:    state.Realm.Spec.Realm.Realm,
+		Msg:      fmt.Sprintf("update client authorization settings %v/%v", cr.Namespace, cr.Spec.Client.ClientID),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getCreatedAuthorizationScopeState(state *common.ClientState, cr *kc.KeycloakClient, scope *kc.KeycloakScope) common.ClusterAction {
+	return common.CreateAuthorizationScopeAction{
+		Scope: scope,
+		Ref:   cr,
+		Realm: state.Realm.Spec.Realm.Realm,
+		Msg:   fmt.Sprintf("create client authorization scope %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, scope.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getUpdatedAuthorizationScopeState(state *common.ClientState, cr *kc.KeycloakClient, scope *kc.KeycloakScope) common.ClusterAction {
+	return common.UpdateAuthorizationScopeAction{
+		Scope: scope,
+		Ref:   cr,
+		Realm: state.Realm.Spec.Realm.Realm,
+		Msg:   fmt.Sprintf("update client authorization scope %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, scope.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getDeletedAuthorizationScopeState(state *common.ClientState, cr *kc.KeycloakClient, scope *kc.KeycloakScope) common.ClusterAction {
+	return common.DeleteAuthorizationScopeAction{
+		Scope: scope,
+		Ref:   cr,
+		Realm: state.Realm.Spec.Realm.Realm,
+		Msg:   fmt.Sprintf("delete client authorization scope %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, scope.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getCreatedAuthorizationResourceState(state *common.ClientState, cr *kc.KeycloakClient, resource *kc.KeycloakResource) common.ClusterAction {
+	return common.CreateAuthorizationResourceAction{
+		Resource: resource,
+		Ref:      cr,
+		Realm:    state.Realm.Spec.Realm.Realm,
+		Msg:      fmt.Sprintf("create client authorization resource %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, resource.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getUpdatedAuthorizationResourceState(state *common.ClientState, cr *kc.KeycloakClient, resource *kc.KeycloakResource) common.ClusterAction {
+	return common.UpdateAuthorizationResourceAction{
+		Resource: resource,
+		Ref:      cr,
+		Realm:    state.Realm.Spec.Realm.Realm,
+		Msg:      fmt.Sprintf("update client authorization resource %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, resource.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getDeletedAuthorizationResourceState(state *common.ClientState, cr *kc.KeycloakClient, resource *kc.KeycloakResource) common.ClusterAction {
+	return common.DeleteAuthorizationResourceAction{
+		Resource: resource,
+		Ref:      cr,
+		Realm:    state.Realm.Spec.Realm.Realm,
+		Msg:      fmt.Sprintf("delete client authorization resource %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, resource.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getCreatedAuthorizationPolicyState(state *common.ClientState, cr *kc.KeycloakClient, policy *kc.KeycloakPolicy) common.ClusterAction {
+	return common.CreateAuthorizationPolicyAction{
+		Policy: policy,
+		Ref:    cr,
+		Realm:  state.Realm.Spec.Realm.Realm,
+		Msg:    fmt.Sprintf("create client authorization policy %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, policy.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getUpdatedAuthorizationPolicyState(state *common.ClientState, cr *kc.KeycloakClient, policy *kc.KeycloakPolicy) common.ClusterAction {
+	return common.UpdateAuthorizationPolicyAction{
+		Policy: policy,
+		Ref:    cr,
+		Realm:  state.Realm.Spec.Realm.Realm,
+		Msg:    fmt.Sprintf("update client authorization policy %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, policy.Name),
+	}
+}
+
+func (i *DedicatedKeycloakClientReconciler) getDeletedAuthorizationPolicyState(state *common.ClientState, cr *kc.KeycloakClient, policy *kc.KeycloakPolicy) common.ClusterAction {
+	return common.DeleteAuthorizationPolicyAction{
+		Policy: policy,
+		Ref:    cr,
+		Realm:  state.Realm.Spec.Realm.Realm,
+		Msg:    fmt.Sprintf("delete client authorization policy %v/%v/%v", cr.Namespace, cr.Spec.Client.ClientID, policy.Name),
+	}
+}
+
 func (i *DedicatedKeycloakClientReconciler) getAddedDefaultClientRolesState(state *common.ClientState, cr *kc.KeycloakClient, roles *[]kc.RoleRepresentation) common.ClusterAction {
 	return common.AddDefaultRolesAction{
 		Roles:              roles,
@@ -12,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -417,3 +418,550 @@ func TestKeycloakClientReconciler_Test_Remove_Client_ID_Client_Secret(t *testing
 	assert.IsType(t, model.DeprecatedClientSecret(cr), desiredState[3].(common.GenericDeleteAction).Ref)
 	assert.Equal(t, oldSecretName, desiredState[3].(common.GenericDeleteAction).Ref.(*v1.Secret).Name)
 }
+
+func TestKeycloakClientReconciler_Test_Basic_Client_Scope(t *testing.T) {
+	// given
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{
+				ClientID:            "test",
+				Secret:              "test",
+				DefaultClientScopes: []string{"profile"},
+			},
+		},
+	}
+
+	basic := v1alpha1.KeycloakClientScope{Name: "basic", ID: "111"}
+	profile := v1alpha1.KeycloakClientScope{Name: "profile", ID: "314"}
+	currentState := &common.ClientState{
+		Client:       &v1alpha1.KeycloakAPIClient{},
+		ClientSecret: &v1.Secret{},
+		Realm: &v1alpha1.KeycloakRealm{
+			Spec: v1alpha1.KeycloakRealmSpec{
+				Realm: &v1alpha1.KeycloakAPIRealm{
+					Realm: "test",
+				},
+			},
+		},
+		AvailableClientScopes: []v1alpha1.KeycloakClientScope{basic, profile},
+		DefaultClientScopes:   []v1alpha1.KeycloakClientScope{basic, profile},
+	}
+
+	deletedDefaultClientScopes := func(version string) []string {
+		keycloakCr := v1alpha1.Keycloak{Status: v1alpha1.KeycloakStatus{Version: version}}
+		desiredState := NewDedicatedKeycloakClientReconciler(keycloakCr).ReconcileIt(currentState, cr)
+
+		var deleted []string
+		for _, action := range desiredState {
+			if deleteAction, ok := action.(common.DeleteClientDefaultClientScopeAction); ok {
+				deleted = append(deleted, deleteAction.ClientScope.Name)
+			}
+		}
+		return deleted
+	}
+
+	// when, then: the basic scope is kept on Keycloak 25 and newer
+	assert.Empty(t, deletedDefaultClientScopes("25.0.6"))
+	assert.Empty(t, deletedDefaultClientScopes("26.1.0"))
+
+	// when, then: older or unknown versions have no basic scope to keep
+	assert.Equal(t, []string{"basic"}, deletedDefaultClientScopes("24.0.5"))
+	assert.Equal(t, []string{"basic"}, deletedDefaultClientScopes("main"))
+	assert.Equal(t, []string{"profile"}, cr.Spec.Client.DefaultClientScopes)
+}
+
+func TestKeycloakClientReconciler_Test_Protocol_Mappers(t *testing.T) {
+	// given
+	keycloakCr := v1alpha1.Keycloak{}
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{
+				ClientID:               "test",
+				Secret:                 "test",
+				ServiceAccountsEnabled: true,
+				ProtocolMappers: []v1alpha1.KeycloakProtocolMapper{
+					{Name: "unchanged", ProtocolMapper: "oidc-audience-mapper", Config: map[string]string{"included.client.audience": "a"}},
+					{Name: "update", ProtocolMapper: "oidc-audience-mapper", Config: map[string]string{"included.client.audience": "new"}},
+					{Name: "recreate", ProtocolMapper: "oidc-hardcoded-claim-mapper"},
+					{ID: "staleID", Name: "create", ProtocolMapper: "oidc-audience-mapper"},
+				},
+			},
+		},
+	}
+
+	currentState := &common.ClientState{
+		Client:       &v1alpha1.KeycloakAPIClient{},
+		ClientSecret: &v1.Secret{},
+		Realm: &v1alpha1.KeycloakRealm{
+			Spec: v1alpha1.KeycloakRealmSpec{
+				Realm: &v1alpha1.KeycloakAPIRealm{
+					Realm: "test",
+				},
+			},
+		},
+		ProtocolMappers: []v1alpha1.KeycloakProtocolMapper{
+			{ID: "unchangedID", Name: "unchanged", Protocol: "openid-connect", ProtocolMapper: "oidc-audience-mapper", Config: map[string]string{"included.client.audience": "a"}},
+			{ID: "updateID", Name: "update", Protocol: "openid-connect", ProtocolMapper: "oidc-audience-mapper", Config: map[string]string{"included.client.audience": "old"}},
+			{ID: "recreateID", Name: "recreate", Protocol: "openid-connect", ProtocolMapper: "oidc-audience-mapper"},
+			{ID: "deleteID", Name: "delete", Protocol: "openid-connect", ProtocolMapper: "oidc-audience-mapper"},
+			{ID: "clientIdID", Name: "Client ID", Protocol: "openid-connect", ProtocolMapper: "oidc-usersessionmodel-note-mapper"},
+		},
+	}
+
+	// when
+	reconciler := NewDedicatedKeycloakClientReconciler(keycloakCr)
+	desiredState := reconciler.ReconcileIt(currentState, cr)
+
+	// then
+	var mapperActions []common.ClusterAction
+	for _, action := range desiredState {
+		switch action.(type) {
+		case common.CreateClientProtocolMapperAction, common.UpdateClientProtocolMapperAction, common.DeleteClientProtocolMapperAction:
+			mapperActions = append(mapperActions, action)
+		}
+	}
+
+	assert.Len(t, mapperActions, 5)
+	assert.IsType(t, common.DeleteClientProtocolMapperAction{}, mapperActions[0])
+	assert.Equal(t, "deleteID", mapperActions[0].(common.DeleteClientProtocolMapperAction).Mapper.ID)
+	assert.Equal(t, "test", mapperActions[0].(common.DeleteClientProtocolMapperAction).Realm)
+	assert.IsType(t, common.DeleteClientProtocolMapperAction{}, mapperActions[1])
+	assert.Equal(t, "recreateID", mapperActions[1].(common.DeleteClientProtocolMapperAction).Mapper.ID)
+	assert.IsType(t, common.UpdateClientProtocolMapperAction{}, mapperActions[2])
+	assert.Equal(t, "updateID", mapperActions[2].(common.UpdateClientProtocolMapperAction).Mapper.ID)
+	assert.Equal(t, "new", mapperActions[2].(common.UpdateClientProtocolMapperAction).Mapper.Config["included.client.audience"])
+	assert.IsType(t, common.CreateClientProtocolMapperAction{}, mapperActions[3])
+	assert.Equal(t, "recreate", mapperActions[3].(common.CreateClientProtocolMapperAction).Mapper.Name)
+	assert.Equal(t, "oidc-hardcoded-claim-mapper", mapperActions[3].(common.CreateClientProtocolMapperAction).Mapper.ProtocolMapper)
+	assert.IsType(t, common.CreateClientProtocolMapperAction{}, mapperActions[4])
+	assert.Equal(t, "create", mapperActions[4].(common.CreateClientProtocolMapperAction).Mapper.Name)
+	assert.Empty(t, mapperActions[4].(common.CreateClientProtocolMapperAction).Mapper.ID)
+	assert.Equal(t, "openid-connect", mapperActions[4].(common.CreateClientProtocolMapperAction).Mapper.Protocol)
+	assert.Equal(t, "staleID", cr.Spec.Client.ProtocolMappers[3].ID)
+
+	// when, then: the protocol mappers of a new client are created together with the client
+	currentState.Client = nil
+	desiredState = reconciler.ReconcileIt(currentState, cr)
+	for _, action := range desiredState {
+		switch action.(type) {
+		case common.CreateClientProtocolMapperAction, common.UpdateClientProtocolMapperAction, common.DeleteClientProtocolMapperAction:
+			assert.Fail(t, "unexpected protocol mapper action", "%T", action)
+		}
+	}
+}
+
+func TestKeycloakClientReconciler_Test_Authorization(t *testing.T) {
+	// given
+	keycloakCr := v1alpha1.Keycloak{}
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{
+				ClientID:                     "test",
+				Secret:                       "test",
+				AuthorizationServicesEnabled: true,
+				AuthorizationSettings: &v1alpha1.KeycloakResourceServer{
+					PolicyEnforcementMode: "PERMISSIVE",
+					Scopes: []v1alpha1.KeycloakScope{
+						{Name: "view"},
+						{Name: "edit", DisplayName: "Edit"},
+					},
+					Resources: []v1alpha1.KeycloakResource{
+						{Name: "documents", Uris: []string{"/b", "/a"}, Scopes: []apiextensionsv1.JSON{{Raw: []byte(`{"name":"view"}`)}}},
+						{Name: "reports", Uris: []string{"/reports"}},
+					},
+					Policies: []v1alpha1.KeycloakPolicy{
+						{Name: "documents permission", Type: "resource", Resources: []string{"documents"}, Policies: []string{"admins"}},
+						{Name: "admins", Type: "role", Config: map[string]string{"roles": `[{"id":"admin"}]`}},
+						{Name: "users", Type: "client", Config: map[string]string{"clients": `["other"]`}},
+					},
+				},
+			},
+		},
+	}
+
+	currentState := &common.ClientState{
+		Client:       &v1alpha1.KeycloakAPIClient{},
+		ClientSecret: &v1.Secret{},
+		Realm: &v1alpha1.KeycloakRealm{
+			Spec: v1alpha1.KeycloakRealmSpec{
+				Realm: &v1alpha1.KeycloakAPIRealm{
+					Realm: "test",
+				},
+			},
+		},
+		AuthorizationSettings: &v1alpha1.KeycloakResourceServer{
+			ID:                    "serverID",
+			PolicyEnforcementMode: "ENFORCING",
+			DecisionStrategy:      "UNANIMOUS",
+			Scopes: []v1alpha1.KeycloakScope{
+				{ID: "viewID", Name: "view"},
+				{ID: "editID", Name: "edit"},
+				{ID: "deleteID", Name: "delete"},
+			},
+			Resources: []v1alpha1.KeycloakResource{
+				{ID: "documentsID", Name: "documents", Uris: []string{"/a", "/b"}, Scopes: []apiextensionsv1.JSON{{Raw: []byte(`{"id":"viewID","name":"view"}`)}}},
+				{ID: "defaultResourceID", Name: "Default Resource", Uris: []string{"/*"}},
+			},
+			Policies: []v1alpha1.KeycloakPolicy{
+				{ID: "permissionID", Name: "documents permission", Type: "resource", Logic: "POSITIVE", DecisionStrategy: "UNANIMOUS", Resources: []string{"documents"}},
+				{ID: "adminsID", Name: "admins", Type: "role", Logic: "POSITIVE", DecisionStrategy: "UNANIMOUS", Config: map[string]string{"roles": `[{"id":"admin"}]`, "fetchRoles": "false"}},
+				{ID: "usersID", Name: "users", Type: "user", Logic: "POSITIVE", DecisionStrategy: "UNANIMOUS"},
+				{ID: "defaultPolicyID", Name: "Default Policy", Type: "js", Logic: "POSITIVE", DecisionStrategy: "AFFIRMATIVE"},
+				{ID: "defaultPermissionID", Name: "Default Permission", Type: "resource", Logic: "POSITIVE", DecisionStrategy: "UNANIMOUS", Policies: []string{"Default Policy"}},
+			},
+		},
+	}
+
+	// when
+	reconciler := NewDedicatedKeycloakClientReconciler(keycloakCr)
+	desiredState := reconciler.ReconcileIt(currentState, cr)
+
+	// then
+	var authorizationActions []common.ClusterAction
+	for _, action := range desiredState {
+		switch action.(type) {
+		case common.UpdateAuthorizationSettingsAction,
+			common.CreateAuthorizationScopeAction, common.UpdateAuthorizationScopeAction, common.DeleteAuthorizationScopeAction,
+			common.CreateAuthorizationResourceAction, common.UpdateAuthorizationResourceAction, common.DeleteAuthorizationResourceAction,
+			common.CreateAuthorizationPolicyAction, common.UpdateAuthorizationPolicyAction, common.DeleteAuthorizationPolicyAction:
+			authorizationActions = append(authorizationActions, action)
+		}
+	}
+
+	assert.Len(t, authorizationActions, 10)
+	assert.IsType(t, common.UpdateAuthorizationSettingsAction{}, authorizationActions[0])
+	assert.Equal(t, "PERMISSIVE", authorizationActions[0].(common.UpdateAuthorizationSettingsAction).Settings.PolicyEnforcementMode)
+	assert.Equal(t, "UNANIMOUS", authorizationActions[0].(common.UpdateAuthorizationSettingsAction).Settings.DecisionStrategy)
+	assert.Equal(t, "test", authorizationActions[0].(common.UpdateAuthorizationSettingsAction).Realm)
+
+	// permissions are deleted first
+	assert.IsType(t, common.DeleteAuthorizationPolicyAction{}, authorizationActions[1])
+	assert.Equal(t, "defaultPermissionID", authorizationActions[1].(common.DeleteAuthorizationPolicyAction).Policy.ID)
+	assert.IsType(t, common.DeleteAuthorizationPolicyAction{}, authorizationActions[2])
+	assert.Equal(t, "usersID", authorizationActions[2].(common.DeleteAuthorizationPolicyAction).Policy.ID)
+	assert.IsType(t, common.DeleteAuthorizationPolicyAction{}, authorizationActions[3])
+	assert.Equal(t, "defaultPolicyID", authorizationActions[3].(common.DeleteAuthorizationPolicyAction).Policy.ID)
+	assert.IsType(t, common.DeleteAuthorizationResourceAction{}, authorizationActions[4])
+	assert.Equal(t, "defaultResourceID", authorizationActions[4].(common.DeleteAuthorizationResourceAction).Resource.ID)
+	assert.IsType(t, common.DeleteAuthorizationScopeAction{}, authorizationActions[5])
+	assert.Equal(t, "deleteID", authorizationActions[5].(common.DeleteAuthorizationScopeAction).Scope.ID)
+
+	// scopes, resources and policies are created or updated before the permissions
+	assert.IsType(t, common.UpdateAuthorizationScopeAction{}, authorizationActions[6])
+	assert.Equal(t, "editID", authorizationActions[6].(common.UpdateAuthorizationScopeAction).Scope.ID)
+	assert.Equal(t, "Edit", authorizationActions[6].(common.UpdateAuthorizationScopeAction).Scope.DisplayName)
+	assert.IsType(t, common.CreateAuthorizationResourceAction{}, authorizationActions[7])
+	assert.Equal(t, "reports", authorizationActions[7].(common.CreateAuthorizationResourceAction).Resource.Name)
+	assert.IsType(t, common.CreateAuthorizationPolicyAction{}, authorizationActions[8])
+	assert.Equal(t, "users", authorizationActions[8].(common.CreateAuthorizationPolicyAction).Policy.Name)
+	assert.Equal(t, "client", authorizationActions[8].(common.CreateAuthorizationPolicyAction).Policy.Type)
+	assert.IsType(t, common.UpdateAuthorizationPolicyAction{}, authorizationActions[9])
+	assert.Equal(t, "permissionID", authorizationActions[9].(common.UpdateAuthorizationPolicyAction).Policy.ID)
+	assert.Equal(t, []string{"admins"}, authorizationActions[9].(common.UpdateAuthorizationPolicyAction).Policy.Policies)
+
+	// when, then: authorization settings without authorization services are not reconciled
+	cr.Spec.Client.AuthorizationServicesEnabled = false
+	desiredState = reconciler.ReconcileIt(currentState, cr)
+	for _, action := range desiredState {
+		switch action.(type) {
+		case common.UpdateAuthorizationSettingsAction, common.DeleteAuthorizationPolicyAction, common.DeleteAuthorizationResourceAction, common.DeleteAuthorizationScopeAction:
+			assert.Fail(t, "unexpected authorization action", "%T", action)
+		}
+	}
+}
+
+func TestKeycloakClientReconciler_Test_Secret_Rotation(t *testing.T) {
+	// given
+	keycloakCr := v1alpha1.Keycloak{Status: v1alpha1.KeycloakStatus{Features: []string{common.ClientSecretRotationFeature}}}
+	newCr := func(lastRotation time.Time) *v1alpha1.KeycloakClient {
+		return &v1alpha1.KeycloakClient{
+			ObjectMeta: v13.ObjectMeta{
+				Name:              "test",
+				Namespace:         "test",
+				CreationTimestamp: v13.NewTime(time.Now().Add(-48 * time.Hour)),
+			},
+			Spec: v1alpha1.KeycloakClientSpec{
+				Client: &v1alpha1.KeycloakAPIClient{
+					ID:       "id",
+					ClientID: "test",
+					Secret:   "current",
+				},
+				SecretRotation: &v1alpha1.KeycloakClientSecretRotation{
+					Interval:    v13.Duration{Duration: 24 * time.Hour},
+					GracePeriod: v13.Duration{Duration: time.Hour},
+				},
+			},
+			Status: v1alpha1.KeycloakClientStatus{
+				LastSecretRotation: &v13.Time{Time: lastRotation},
+			},
+		}
+	}
+	newState := func(rotatedSecret string) *common.ClientState {
+		return &common.ClientState{
+			Client:              &v1alpha1.KeycloakAPIClient{ID: "id", ClientID: "test"},
+			ClientSecret:        &v1.Secret{},
+			RotatedClientSecret: rotatedSecret,
+			Realm: &v1alpha1.KeycloakRealm{
+				Spec: v1alpha1.KeycloakRealmSpec{
+					Realm: &v1alpha1.KeycloakAPIRealm{
+						Realm: "test",
+					},
+				},
+			},
+			Keycloak: keycloakCr,
+		}
+	}
+	reconciler := NewDedicatedKeycloakClientReconciler(keycloakCr)
+
+	// when the interval has elapsed
+	desiredState := reconciler.ReconcileIt(newState(""), newCr(time.Now().Add(-25*time.Hour)))
+
+	// then the secret is rotated after the client is updated, instead of updating the k8s secret
+	assert.IsType(t, common.UpdateClientAction{}, desiredState[1])
+	assert.IsType(t, common.RotateClientSecretAction{}, desiredState[2])
+	rotate := desiredState[2].(common.RotateClientSecretAction)
+	assert.True(t, rotate.KeepPrevious)
+	assert.Equal(t, "test", rotate.Realm)
+	assert.Equal(t, []byte("current"), rotate.Secret.Data[model.ClientSecretClientSecretProperty])
+
+	// when the rotation is enabled for a CR created before the interval
+	cr := newCr(time.Time{})
+	cr.Status.LastSecretRotation = nil
+	desiredState = reconciler.ReconcileIt(newState(""), cr)
+
+	// then the rotation is started, but not due
+	assert.IsType(t, common.GenericUpdateAction{}, desiredState[2])
+	assert.NotNil(t, cr.Status.SecretRotationStart)
+
+	// when the interval has elapsed since the rotation was enabled, before the first rotation
+	cr.Status.SecretRotationStart = &v13.Time{Time: time.Now().Add(-25 * time.Hour)}
+	desiredState = reconciler.ReconcileIt(newState(""), cr)
+
+	// then
+	assert.IsType(t, common.RotateClientSecretAction{}, desiredState[2])
+
+	// when within the grace period
+	desiredState = reconciler.ReconcileIt(newState("previous"), newCr(time.Now().Add(-30*time.Minute)))
+
+	// then the previous secret is published
+	assert.IsType(t, common.GenericUpdateAction{}, desiredState[2])
+	secret := desiredState[2].(common.GenericUpdateAction).Ref.(*v1.Secret)
+	assert.Equal(t, []byte("current"), secret.Data[model.ClientSecretClientSecretProperty])
+	assert.Equal(t, []byte("previous"), secret.Data[model.ClientSecretPreviousClientSecretProperty])
+	for _, action := range desiredState {
+		if _, ok := action.(common.InvalidateClientRotatedSecretAction); ok {
+			assert.Fail(t, "unexpected invalidation of the previous secret")
+		}
+	}
+
+	// when the grace period is over
+	desiredState = reconciler.ReconcileIt(newState("previous"), newCr(time.Now().Add(-2*time.Hour)))
+
+	// then the previous secret is removed and invalidated
+	secret = desiredState[2].(common.GenericUpdateAction).Ref.(*v1.Secret)
+	assert.NotContains(t, secret.Data, model.ClientSecretPreviousClientSecretProperty)
+	assert.IsType(t, common.InvalidateClientRotatedSecretAction{}, desiredState[3])
+
+	// when the rotation is disabled
+	cr = newCr(time.Now().Add(-25 * time.Hour))
+	cr.Spec.SecretRotation = nil
+	desiredState = reconciler.ReconcileIt(newState(""), cr)
+
+	// then
+	assert.IsType(t, common.GenericUpdateAction{}, desiredState[2])
+}
+
+func TestKeycloakClientReconciler_Test_Secret_Rotation_Requeue_Delay(t *testing.T) {
+	// given
+	cr := &v1alpha1.KeycloakClient{
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{ClientID: "test"},
+			SecretRotation: &v1alpha1.KeycloakClientSecretRotation{
+				Interval:    v13.Duration{Duration: 24 * time.Hour},
+				GracePeriod: v13.Duration{Duration: time.Hour},
+			},
+		},
+		Status: v1alpha1.KeycloakClientStatus{
+			LastSecretRotation: &v13.Time{Time: time.Now()},
+		},
+	}
+
+	// when within the grace period
+	delay := secretRotationRequeueDelay(cr)
+
+	// then the end of the grace period comes first
+	assert.InDelta(t, time.Hour.Seconds(), delay.Seconds(), 5)
+
+	// when the grace period is over
+	cr.Status.LastSecretRotation = &v13.Time{Time: time.Now().Add(-2 * time.Hour)}
+	delay = secretRotationRequeueDelay(cr)
+
+	// then
+	assert.InDelta(t, (22 * time.Hour).Seconds(), delay.Seconds(), 5)
+
+	// when the rotation is disabled
+	cr.Spec.SecretRotation = nil
+
+	// then
+	assert.Equal(t, time.Duration(0), secretRotationRequeueDelay(cr))
+}
+
+func TestKeycloakClientReconciler_Test_Installations(t *testing.T) {
+	// given
+	keycloakCr := v1alpha1.Keycloak{}
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{
+				ID:       "id",
+				ClientID: "test",
+				Secret:   "test",
+			},
+			Installations: []v1alpha1.KeycloakClientInstallation{
+				{ProviderID: "keycloak-oidc-keycloak-json", Key: "keycloak.json"},
+				{ProviderID: "keycloak-oidc-jboss-subsystem", Key: "subsystem.xml"},
+				{ProviderID: "saml-idp-descriptor", Key: "idp.xml", Kind: model.ClientInstallationKindConfigMap, Name: "idp"},
+			},
+		},
+	}
+
+	currentState := &common.ClientState{
+		Client:       &v1alpha1.KeycloakAPIClient{ID: "id", ClientID: "test"},
+		ClientSecret: &v1.Secret{},
+		Realm: &v1alpha1.KeycloakRealm{
+			Spec: v1alpha1.KeycloakRealmSpec{
+				Realm: &v1alpha1.KeycloakAPIRealm{
+					Realm: "test",
+				},
+			},
+		},
+		InstallationSecrets: map[string]*v1.Secret{
+			"keycloak-client-installation-test": {
+				ObjectMeta: v13.ObjectMeta{Name: "keycloak-client-installation-test", Namespace: "test", ResourceVersion: "1"},
+				Data:       map[string][]byte{"removed.json": []byte(`{}`)},
+			},
+		},
+		InstallationConfigMaps: map[string]*v1.ConfigMap{"idp": nil},
+	}
+
+	// when
+	reconciler := NewDedicatedKeycloakClientReconciler(keycloakCr)
+	desiredState := reconciler.ReconcileIt(currentState, cr)
+
+	// then
+	var published []common.PublishClientInstallationsAction
+	for _, action := range desiredState {
+		if a, ok := action.(common.PublishClientInstallationsAction); ok {
+			published = append(published, a)
+		}
+	}
+	assert.Len(t, published, 2)
+
+	secret, ok := published[0].Target.(*v1.Secret)
+	assert.True(t, ok)
+	assert.Equal(t, "keycloak-client-installation-test", secret.Name)
+	assert.Equal(t, "1", secret.ResourceVersion)
+	assert.Equal(t, cr.Spec.Installations[:2], published[0].Installations)
+	assert.Equal(t, "test", published[0].Realm)
+
+	configMap, ok := published[1].Target.(*v1.ConfigMap)
+	assert.True(t, ok)
+	assert.Equal(t, "idp", configMap.Name)
+	assert.Equal(t, "test", configMap.Namespace)
+	assert.Empty(t, configMap.ResourceVersion)
+	assert.Equal(t, cr.Spec.Installations[2:], published[1].Installations)
+}
+
+func TestKeycloakClientReconciler_Test_Secret_Targets(t *testing.T) {
+	// given
+	keycloakCr := v1alpha1.Keycloak{}
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{
+				ID:       "id",
+				ClientID: "test",
+				Secret:   "test",
+			},
+			SecretTargets: []v1alpha1.KeycloakClientSecretTarget{
+				{Name: "test"},
+				{Name: "test", Namespace: "gateway"},
+			},
+		},
+	}
+	labels := model.ClientSecretTargetLabels(cr)
+
+	currentState := &common.ClientState{
+		Client:       &v1alpha1.KeycloakAPIClient{ID: "id", ClientID: "test"},
+		ClientSecret: &v1.Secret{},
+		Realm: &v1alpha1.KeycloakRealm{
+			Spec: v1alpha1.KeycloakRealmSpec{
+				Realm: &v1alpha1.KeycloakAPIRealm{
+					Realm: "test",
+				},
+			},
+		},
+		SecretTargets: []v1.Secret{
+			{ObjectMeta: v13.ObjectMeta{Name: "test", Namespace: "gateway", Labels: labels, ResourceVersion: "1"}},
+			{ObjectMeta: v13.ObjectMeta{Name: "removed", Namespace: "other", Labels: labels, ResourceVersion: "1"}},
+		},
+	}
+
+	// when
+	reconciler := NewDedicatedKeycloakClientReconciler(keycloakCr)
+	desiredState := reconciler.ReconcileIt(currentState, cr)
+
+	// then
+	var applied []common.ApplyClientSecretTargetAction
+	var deleted []*v1.Secret
+	for _, action := range desiredState {
+		switch a := action.(type) {
+		case common.ApplyClientSecretTargetAction:
+			applied = append(applied, a)
+		case common.GenericDeleteAction:
+			if s, ok := a.Ref.(*v1.Secret); ok {
+				deleted = append(deleted, s)
+			}
+		}
+	}
+	assert.Len(t, applied, 2)
+	assert.Equal(t, "test", applied[0].Secret.Namespace)
+	assert.Empty(t, applied[0].Secret.ResourceVersion)
+	assert.Equal(t, "gateway", applied[1].Secret.Namespace)
+	assert.Equal(t, "1", applied[1].Secret.ResourceVersion)
+	assert.Equal(t, "test", applied[1].Data.ClientSecret)
+	assert.False(t, applied[1].FollowRotation)
+	assert.Len(t, deleted, 1)
+	assert.Equal(t, "removed", deleted[0].Name)
+
+	// when the client is deleted
+	cr.DeletionTimestamp = &v13.Time{Time: time.Now()}
+	desiredState = reconciler.ReconcileIt(currentState, cr)
+
+	// then all secret targets are deleted
+	deleted = nil
+	for _, action := range desiredState {
+		if a, ok := action.(common.GenericDeleteAction); ok {
+			deleted = append(deleted, a.Ref.(*v1.Secret))
+		}
+	}
+	assert.Len(t, deleted, 2)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/movewp3/keycloakclient-controller/pkg/common"
 	"github.com/pkg/errors"
 	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -43,32 +44,32 @@ import (
 
 // KeycloakRealmReconciler reconciles a KeycloakRealm object
 type KeycloakRealmReconciler struct {
-	Client   client.Client
-	Scheme   *runtime.Scheme
-	context  context.Context
-	cancel   context.CancelFunc
-	recorder record.EventRecorder
+	Client client.Client
+	Scheme *runtime.Scheme
+	// Maximum duration of a single reconcile, including all requests against Keycloak
+	ReconcileTimeout time.Duration
+	// Creates the clients for the admin API of Keycloak, defaults to a LocalConfigKeycloakFactory
+	KeycloakFactory common.KeycloakClientFactory
+	recorder        record.EventRecorder
 }
 
 const (
 	RealmFinalizer         = "realm.cleanup"
 	RealmRequeueDelayError = 60 * time.Second
-	RealmControllerName    = "controller_keycloakrealm"
+	// RealmRequeueDelayPermanentError is used for errors retrying does not resolve, e.g. a rejected representation
+	RealmRequeueDelayPermanentError = 5 * time.Minute
+	RealmControllerName             = "controller_keycloakrealm"
 )
 
 var logKcr = logf.Log.WithName(RealmControllerName)
 
 // newReconciler returns a new reconcile.Reconciler
 func newRealmReconciler(mgr manager.Manager) reconcile.Reconciler {
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-
 	return &KeycloakRealmReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		cancel:   cancel,
-		context:  ctx,
-		recorder: mgr.GetEventRecorderFor(RealmControllerName),
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		ReconcileTimeout: DefaultReconcileTimeout,
+		recorder:         mgr.GetEventRecorderFor(RealmControllerName),
 	}
 }
 
@@ -87,12 +88,21 @@ func newRealmReconciler(mgr manager.Manager) reconcile.Reconciler {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
 func (r *KeycloakRealmReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	_ = logf.FromContext(ctx)
+
+	// bound the reconcile, so that calls against an unresponsive Keycloak are cancelled
+	ctx, cancel := reconcileContext(ctx, r.ReconcileTimeout)
+	defer cancel()
+	ctx, span := common.StartSpan(ctx, "KeycloakRealm.Reconcile",
+		common.AttributeKind.String("KeycloakRealm"),
+		common.AttributeNamespace.String(request.Namespace),
+		common.AttributeName.String(request.Name))
+	defer span.End()
 	reqLogger := logKcr.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling KeycloakRealm")
 
 	// Fetch the KeycloakRealm instance
 	instance := &kc.KeycloakRealm{}
-	err := r.Client.Get(context.TODO(), request.NamespacedName, instance)
+	err := r.Client.Get(ctx, request.NamespacedName, instance)
 	if err != nil {
 		if kubeerrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -105,7 +115,7 @@ func (r *KeycloakRealmReconciler) Reconcile(ctx context.Context, request ctrl.Re
 	}
 
 	if instance.Spec.Unmanaged {
-		return reconcile.Result{Requeue: false}, r.manageSuccess(instance, instance.DeletionTimestamp != nil)
+		return reconcile.Result{Requeue: false}, r.manageSuccess(ctx, instance, instance.DeletionTimestamp != nil)
 	}
 
 	// If no selector is set we can't figure out which Keycloak instance this realm should
@@ -115,9 +125,9 @@ func (r *KeycloakRealmReconciler) Reconcile(ctx context.Context, request ctrl.Re
 		return reconcile.Result{Requeue: false}, nil
 	}
 
-	keycloaks, err := common.GetMatchingKeycloaks(r.context, r.Client, instance.Spec.InstanceSelector)
+	keycloaks, err := common.GetMatchingKeycloaks(ctx, r.Client, instance.Spec.InstanceSelector)
 	if err != nil {
-		return r.ManageError(instance, err)
+		return r.ManageError(ctx, instance, err)
 	}
 
 	logKcr.Info(fmt.Sprintf("found %v matching keycloak(s) for realm %v/%v", len(keycloaks.Items), instance.Namespace, instance.Name))
@@ -125,21 +135,25 @@ func (r *KeycloakRealmReconciler) Reconcile(ctx context.Context, request ctrl.Re
 	// The realm may be applicable to multiple keycloak instances,
 	// process all of them
 	for _, keycloak := range keycloaks.Items {
-		// Get an authenticated keycloak api client for the instance
-		keycloakFactory := common.LocalConfigKeycloakFactory{}
-
 		if keycloak.Spec.Unmanaged {
-			return r.ManageError(instance, errors.Errorf("realms cannot be created for unmanaged keycloak instances"))
+			return r.ManageError(ctx, instance, errors.Errorf("realms cannot be created for unmanaged keycloak instances"))
 		}
 
-		authenticated, err := keycloakFactory.AuthenticatedClient(keycloak, false)
+		// Get an authenticated keycloak api client for the instance
+		authenticated, err := r.keycloakFactory().AuthenticatedClient(ctx, keycloak, false)
 
 		if err != nil {
-			return r.ManageError(instance, err)
+			return r.ManageError(ctx, instance, keycloakUnreachable(err))
 		}
+		setKeycloakReachableCondition(&instance.Status.Conditions, instance.Generation)
+
+		span.SetAttributes(common.AttributeRealm.String(instance.Spec.Realm.Realm), common.AttributeEndpoint.String(authenticated.Endpoint()))
+		readCtx, readSpan := common.StartSpan(ctx, "RealmState.Read",
+			common.AttributeRealm.String(instance.Spec.Realm.Realm),
+			common.AttributeEndpoint.String(authenticated.Endpoint()))
 
 		// Compute the current state of the realm
-		realmState := common.NewRealmState(r.context, keycloak)
+		realmState := common.NewRealmState(readCtx, keycloak)
 
 		logKcr.Info(fmt.Sprintf("read state for keycloak %v/%v, realm %v/%v",
 			keycloak.Namespace,
@@ -148,24 +162,25 @@ func (r *KeycloakRealmReconciler) Reconcile(ctx context.Context, request ctrl.Re
 			instance.Spec.Realm.Realm))
 
 		err = realmState.Read(instance, authenticated, r.Client)
+		common.EndSpan(readSpan, err)
 		if err != nil {
-			return r.ManageError(instance, err)
+			return r.ManageError(ctx, instance, err)
 		}
 
 		// Figure out the actions to keep the realms up to date with
 		// the desired state
 		reconciler := NewDedicatedKeycloakRealmReconciler(keycloak)
 		desiredState := reconciler.Reconcile(realmState, instance)
-		actionRunner := common.NewClusterAndKeycloakActionRunner(r.context, r.Client, r.Scheme, instance, authenticated)
+		actionRunner := common.NewClusterAndKeycloakActionRunner(ctx, r.Client, r.Scheme, instance, authenticated)
 
 		// Run all actions to keep the realms updated
 		err = actionRunner.RunAll(desiredState)
 		if err != nil {
-			return r.ManageError(instance, err)
+			return r.ManageError(ctx, instance, err)
 		}
 	}
 
-	return reconcile.Result{Requeue: false}, r.manageSuccess(instance, instance.DeletionTimestamp != nil)
+	return reconcile.Result{Requeue: false}, r.manageSuccess(ctx, instance, instance.DeletionTimestamp != nil)
 
 }
 
@@ -182,25 +197,34 @@ func (r *KeycloakRealmReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	r.context = ctx
-	r.cancel = cancel
 	r.recorder = mgr.GetEventRecorderFor(RealmControllerName)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakv1alpha1.KeycloakRealm{}).
 		Complete(r)
 }
 
+func (r *KeycloakRealmReconciler) keycloakFactory() common.KeycloakClientFactory {
+	if r.KeycloakFactory == nil {
+		return &common.LocalConfigKeycloakFactory{}
+	}
+	return r.KeycloakFactory
+}
+
 // blank assignment to verify that ReconcileKeycloakRealm implements reconcile.Reconciler
 var _ reconcile.Reconciler = &KeycloakRealmReconciler{}
 
-func (r *KeycloakRealmReconciler) manageSuccess(realm *kc.KeycloakRealm, deleted bool) error {
+func (r *KeycloakRealmReconciler) manageSuccess(ctx context.Context, realm *kc.KeycloakRealm, deleted bool) error {
+	ctx, cancel := statusContext(ctx)
+	defer cancel()
+
 	realm.Status.Ready = true
 	realm.Status.Message = ""
 	realm.Status.Phase = keycloakv1alpha1.PhaseReconciling
+	realm.Status.ObservedGeneration = realm.Generation
+	realm.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
+	setSyncedConditions(&realm.Status.Conditions, realm.Generation)
 
-	err := r.Client.Status().Update(r.context, realm)
+	err := r.Client.Status().Update(ctx, realm)
 	if err != nil {
 		logKcr.Error(err, "unable to update status")
 	}
@@ -226,7 +250,7 @@ func (r *KeycloakRealmReconciler) manageSuccess(realm *kc.KeycloakRealm, deleted
 			realm.Namespace,
 			realm.Spec.Realm.Realm))
 
-		return r.Client.Update(r.context, realm)
+		return r.Client.Update(ctx, realm)
 	}
 
 	// Otherwise remove the finalizer
@@ -243,23 +267,34 @@ func (r *KeycloakRealmReconciler) manageSuccess(realm *kc.KeycloakRealm, deleted
 	}
 
 	realm.Finalizers = newFinalizers
-	return r.Client.Update(r.context, realm)
+	return r.Client.Update(ctx, realm)
 }
 
-func (r *KeycloakRealmReconciler) ManageError(realm *kc.KeycloakRealm, issue error) (reconcile.Result, error) {
+func (r *KeycloakRealmReconciler) ManageError(ctx context.Context, realm *kc.KeycloakRealm, issue error) (reconcile.Result, error) {
+	ctx, cancel := statusContext(ctx)
+	defer cancel()
+
 	r.recorder.Event(realm, "Warning", "ProcessingError", issue.Error())
+	common.RecordSpanError(ctx, issue)
 
 	realm.Status.Message = issue.Error()
 	realm.Status.Ready = false
 	realm.Status.Phase = keycloakv1alpha1.PhaseFailing
+	realm.Status.ObservedGeneration = realm.Generation
+	setErrorConditions(&realm.Status.Conditions, realm.Generation, issue)
 
-	err := r.Client.Status().Update(r.context, realm)
+	err := r.Client.Status().Update(ctx, realm)
 	if err != nil {
 		logKcr.Error(err, "unable to update status")
 	}
 
+	requeueDelay := RealmRequeueDelayError
+	if common.IsPermanentError(issue) {
+		requeueDelay = RealmRequeueDelayPermanentError
+	}
+
 	return reconcile.Result{
-		RequeueAfter: RealmRequeueDelayError,
+		RequeueAfter: requeueDelay,
 		Requeue:      true,
 	}, nil
 }
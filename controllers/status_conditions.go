@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/movewp3/keycloakclient-controller/pkg/common"
+	"github.com/movewp3/keycloakclient-controller/pkg/model"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// keycloakUnreachableError marks errors of the authentication against the admin API of Keycloak, e.g. because
+// Keycloak is down or the admin credentials are invalid
+type keycloakUnreachableError struct {
+	error
+}
+
+func (e *keycloakUnreachableError) Unwrap() error {
+	return e.error
+}
+
+func keycloakUnreachable(err error) error {
+	return &keycloakUnreachableError{err}
+}
+
+// isKeycloakUnreachable returns true if the authentication failed, Keycloak rejected the token of the controller or
+// a request did not reach Keycloak at all
+func isKeycloakUnreachable(err error) bool {
+	var unreachable *keycloakUnreachableError
+	var netErr net.Error
+	return errors.As(err, &unreachable) || common.IsUnauthorized(err) || errors.As(err, &netErr)
+}
+
+func setCondition(conditions *[]metav1.Condition, generation int64, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setKeycloakReachableCondition records that the controller authenticated against the admin API of a Keycloak
+func setKeycloakReachableCondition(conditions *[]metav1.Condition, generation int64) {
+	setCondition(conditions, generation, v1alpha1.ConditionKeycloakReachable, metav1.ConditionTrue, v1alpha1.ReasonKeycloakReachable, "")
+}
+
+// setSyncedConditions marks a realm or client as applied to all matching Keycloaks
+func setSyncedConditions(conditions *[]metav1.Condition, generation int64) {
+	setCondition(conditions, generation, v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonReconciled, "")
+	setCondition(conditions, generation, v1alpha1.ConditionSynced, metav1.ConditionTrue, v1alpha1.ReasonReconciled, "")
+}
+
+// setErrorConditions marks a realm or client as not ready and not synced because of the issue. Authentication
+// failures against Keycloak mark Keycloak as unreachable as well.
+func setErrorConditions(conditions *[]metav1.Condition, generation int64, issue error) {
+	reason := v1alpha1.ReasonReconcileFailed
+	if isKeycloakUnreachable(issue) {
+		reason = v1alpha1.ReasonKeycloakUnreachable
+		setCondition(conditions, generation, v1alpha1.ConditionKeycloakReachable, metav1.ConditionFalse, reason, issue.Error())
+	}
+	setCondition(conditions, generation, v1alpha1.ConditionReady, metav1.ConditionFalse, reason, issue.Error())
+	setCondition(conditions, generation, v1alpha1.ConditionSynced, metav1.ConditionFalse, reason, issue.Error())
+}
+
+const (
+	// maxDriftSummaryFields limits the fields listed per target in the message of the Drifted condition
+	maxDriftSummaryFields = 5
+	// maxPlanSummaryActions limits the actions listed per target in the event of a dry run
+	maxPlanSummaryActions = 10
+)
+
+// summarise joins the items, listing at most max of them
+func summarise(items []string, max int) string {
+	if len(items) > max {
+		items = append(slices.Clone(items[:max]), fmt.Sprintf("%v more", len(items)-max))
+	}
+	return strings.Join(items, ", ")
+}
+
+// specApplied returns true if the current generation of the client has been applied to all matching Keycloaks,
+// possibly leaving drift unchanged with the drift policy reportOnly
+func specApplied(client *v1alpha1.KeycloakClient) bool {
+	if client.Status.ObservedGeneration != client.Generation {
+		return false
+	}
+	synced := meta.FindStatusCondition(client.Status.Conditions, v1alpha1.ConditionSynced)
+	return synced != nil && (synced.Status == metav1.ConditionTrue || synced.Reason == v1alpha1.ReasonDriftDetected)
+}
+
+// setDriftedCondition summarises the drift of the targets of a client, which is corrected or, with the drift policy
+// reportOnly, only detected
+func setDriftedCondition(client *v1alpha1.KeycloakClient) {
+	var summaries []string
+	for _, target := range client.Status.Targets {
+		if len(target.Drift) == 0 {
+			continue
+		}
+		summaries = append(summaries, fmt.Sprintf("keycloak %v, realm %v: %v", target.Keycloak, target.KeycloakRealm, summarise(target.Drift, maxDriftSummaryFields)))
+	}
+	if len(summaries) == 0 {
+		setCondition(&client.Status.Conditions, client.Generation, v1alpha1.ConditionDrifted, metav1.ConditionFalse, v1alpha1.ReasonNoDrift, "")
+		return
+	}
+	reason := v1alpha1.ReasonDriftCorrected
+	if model.ReportsDriftOnly(client) {
+		reason = v1alpha1.ReasonDriftDetected
+	}
+	setCondition(&client.Status.Conditions, client.Generation, v1alpha1.ConditionDrifted, metav1.ConditionTrue, reason, strings.Join(summaries, "; "))
+}
@@ -17,9 +17,11 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -42,6 +44,7 @@ import (
 
 	keycloakv1alpha1 "github.com/movewp3/keycloakclient-controller/api/v1alpha1"
 	"github.com/movewp3/keycloakclient-controller/controllers"
+	"github.com/movewp3/keycloakclient-controller/pkg/common"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -67,8 +70,23 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var reconcileTimeout time.Duration
+	var otlpEndpoint string
+	var secretTargetNamespaces string
+	var dryRun bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8383", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", controllers.DefaultReconcileTimeout,
+		"Maximum duration of a single reconcile of a realm or client, including all requests against Keycloak.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"The OTLP/HTTP endpoint reconciles and requests against Keycloak are traced to, e.g. http://otel-collector:4318. "+
+			"Defaults to OTEL_EXPORTER_OTLP_ENDPOINT, tracing is disabled if neither is set.")
+	flag.StringVar(&secretTargetNamespaces, "secret-target-namespaces", "",
+		"Comma separated namespaces all KeycloakClients may write secret targets to, in addition to those allowed by "+
+			"their KeycloakRealm. \"*\" allows all namespaces.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Plan the changes of all KeycloakClients without applying them. The planned actions are written to the status "+
+			"and reported as events, like for KeycloakClients with the annotation keycloak.org/dry-run: \"true\".")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -89,6 +107,12 @@ func main() {
 	*/
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	shutdownTracing, err := common.SetupTracing(context.Background(), otlpEndpoint)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	namespace, err := k8sutil.GetWatchNamespace()
 	if err != nil {
 		setupLog.Error(err, "Failed to get watch namespace")
@@ -136,15 +160,19 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&controllers.KeycloakRealmReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		ReconcileTimeout: reconcileTimeout,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KeycloakRealm")
 		os.Exit(1)
 	}
 	if err = (&controllers.KeycloakClientReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                 mgr.GetClient(),
+		Scheme:                 mgr.GetScheme(),
+		ReconcileTimeout:       reconcileTimeout,
+		SecretTargetNamespaces: splitNamespaces(secretTargetNamespaces),
+		DryRun:                 dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KeycloakClient")
 		os.Exit(1)
@@ -161,8 +189,26 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	// flush the remaining spans
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "unable to shut down tracing")
+	}
+	cancel()
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// splitNamespaces splits a comma separated list of namespaces
+func splitNamespaces(value string) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(value, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
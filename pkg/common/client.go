@@ -12,20 +12,31 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
 	"github.com/movewp3/keycloakclient-controller/pkg/model"
 	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
-	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	config2 "sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
-	authURL = "auth/realms/master/protocol/openid-connect/token"
+	authURL = "realms/%s/protocol/openid-connect/token"
+
+	// DefaultAdminRealm is the realm the controller authenticates against if none is configured
+	DefaultAdminRealm = "master"
+	// LegacyContextPath is the context path used by Keycloak 16 and older (WildFly distribution)
+	LegacyContextPath = "/auth"
+	// wellKnownPath is used to probe whether Keycloak is served from a given context path
+	wellKnownPath = "realms/%s/.well-known/openid-configuration"
+	// tokenExpiryLeeway is the time before the expiry of a token at which it is renewed
+	tokenExpiryLeeway = 10 * time.Second
 )
 
 var logClient = logf.Log.WithName("common.client")
@@ -35,25 +46,47 @@ type Requester interface {
 }
 
 type Client struct {
-	requester Requester
-	URL       string
-	token     string
+	requester   Requester
+	URL         string
+	contextPath string
+	adminRealm  string
+	tokenURL    string
+	credentials *credentials
+	// namespace/name of the Keycloak CR, used to label metrics
+	keycloak string
+
+	mu            sync.Mutex
+	token         string
+	tokenExpiry   time.Time
+	refreshToken  string
+	refreshExpiry time.Time
+	// client id and secret of the login the current tokens were issued to, needed to refresh them
+	tokenClientID     string
+	tokenClientSecret string
+}
+
+// credentials the controller uses to (re-)authenticate against Keycloak
+type credentials struct {
+	user         string
+	password     string
+	clientName   string
+	clientSecret string
 }
 
 // T is a generic type for keycloak spec resources
 type T interface{}
 
 // Generic create function for creating new Keycloak resources
-func (c *Client) create(obj T, resourcePath, resourceName string) (string, error) {
+func (c *Client) create(ctx context.Context, obj T, resourcePath, resourceName string) (string, error) {
 	jsonValue, err := json.Marshal(obj)
 	if err != nil {
 		log.Error(err, "error marshalling object", err)
 		return "", nil
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(ctx,
 		"POST",
-		fmt.Sprintf("%s/auth/admin/%s", c.URL, resourcePath),
+		c.adminURL(resourcePath),
 		bytes.NewBuffer(jsonValue),
 	)
 	if err != nil {
@@ -62,8 +95,7 @@ func (c *Client) create(obj T, resourcePath, resourceName string) (string, error
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	res, err := c.requester.Do(req)
+	res, err := c.do(req, resourceName)
 
 	if err != nil {
 		log.Error(err, "error on request ")
@@ -72,7 +104,7 @@ func (c *Client) create(obj T, resourcePath, resourceName string) (string, error
 	defer res.Body.Close()
 
 	if res.StatusCode != 201 && res.StatusCode != 204 {
-		return "", errors.Errorf("failed to create %s: (%d) %s", resourceName, res.StatusCode, res.Status)
+		return "", newKeycloakAPIError(res, "create", resourceName)
 	}
 
 	if resourceName == "client" {
@@ -89,43 +121,65 @@ func (c *Client) Endpoint() string {
 	return c.URL
 }
 
-func (c *Client) CreateRealm(realm *v1alpha1.KeycloakRealm) (string, error) {
-	return c.create(realm.Spec.Realm, "realms", "realm")
+// adminURL returns the URL of a resource of the admin REST API
+func (c *Client) adminURL(resourcePath string) string {
+	return fmt.Sprintf("%s%s/admin/%s", c.URL, c.contextPath, resourcePath)
+}
+
+// tokenEndpoint returns the URL the controller requests its access tokens from. Unless an explicit
+// token URL is configured, this is the token endpoint of the admin realm.
+func (c *Client) tokenEndpoint() string {
+	if c.tokenURL != "" {
+		return c.tokenURL
+	}
+	adminRealm := c.adminRealm
+	if adminRealm == "" {
+		adminRealm = DefaultAdminRealm
+	}
+	return fmt.Sprintf("%s%s/%s", c.URL, c.contextPath, fmt.Sprintf(authURL, adminRealm))
+}
+
+func (c *Client) CreateRealm(ctx context.Context, realm *v1alpha1.KeycloakRealm) (string, error) {
+	return c.create(ctx, realm.Spec.Realm, "realms", "realm")
 }
 
-func (c *Client) CreateClient(specClient *v1alpha1.KeycloakAPIClient, realmName string) (string, error) {
-	return c.create(specClient, fmt.Sprintf("realms/%s/clients", realmName), "client")
+func (c *Client) CreateClient(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, realmName string) (string, error) {
+	return c.create(ctx, specClient, fmt.Sprintf("realms/%s/clients", realmName), "client")
 }
 
-func (c *Client) CreateClientRole(clientID string, role *v1alpha1.RoleRepresentation, realmName string) (string, error) {
-	return c.create(role, fmt.Sprintf("realms/%s/clients/%s/roles", realmName, clientID), "client role")
+func (c *Client) CreateClientRole(ctx context.Context, clientID string, role *v1alpha1.RoleRepresentation, realmName string) (string, error) {
+	return c.create(ctx, role, fmt.Sprintf("realms/%s/clients/%s/roles", realmName, clientID), "client role")
 }
 
-func (c *Client) AddRealmRoleComposites(realmName, roleID string, roles *[]v1alpha1.RoleRepresentation) error {
-	_, err := c.create(roles, fmt.Sprintf("realms/%s/roles-by-id/%s/composites", realmName, roleID), "realm role composites")
+func (c *Client) CreateClientProtocolMapper(ctx context.Context, clientID string, mapper *v1alpha1.KeycloakProtocolMapper, realmName string) (string, error) {
+	return c.create(ctx, mapper, fmt.Sprintf("realms/%s/clients/%s/protocol-mappers/models", realmName, clientID), "client protocol mapper")
+}
+
+func (c *Client) AddRealmRoleComposites(ctx context.Context, realmName, roleID string, roles *[]v1alpha1.RoleRepresentation) error {
+	_, err := c.create(ctx, roles, fmt.Sprintf("realms/%s/roles-by-id/%s/composites", realmName, roleID), "realm role composites")
 	return err
 }
 
-func (c *Client) CreateClientRealmScopeMappings(specClient *v1alpha1.KeycloakAPIClient, mappings *[]v1alpha1.RoleRepresentation, realmName string) error {
-	_, err := c.create(mappings, fmt.Sprintf("realms/%s/clients/%s/scope-mappings/realm", realmName, specClient.ID), "client realm scope mappings")
+func (c *Client) CreateClientRealmScopeMappings(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, mappings *[]v1alpha1.RoleRepresentation, realmName string) error {
+	_, err := c.create(ctx, mappings, fmt.Sprintf("realms/%s/clients/%s/scope-mappings/realm", realmName, specClient.ID), "client realm scope mappings")
 	return err
 }
 
-func (c *Client) CreateClientClientScopeMappings(specClient *v1alpha1.KeycloakAPIClient, mappings *v1alpha1.ClientMappingsRepresentation, realmName string) error {
-	_, err := c.create(mappings.Mappings, fmt.Sprintf("realms/%s/clients/%s/scope-mappings/clients/%s", realmName, specClient.ID, mappings.ID), "client client scope mappings")
+func (c *Client) CreateClientClientScopeMappings(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, mappings *v1alpha1.ClientMappingsRepresentation, realmName string) error {
+	_, err := c.create(ctx, mappings.Mappings, fmt.Sprintf("realms/%s/clients/%s/scope-mappings/clients/%s", realmName, specClient.ID, mappings.ID), "client client scope mappings")
 	return err
 }
 
-func (c *Client) CreateFederatedIdentity(fid v1alpha1.FederatedIdentity, userID string, realmName string) (string, error) {
-	return c.create(fid, fmt.Sprintf("realms/%s/users/%s/federated-identity/%s", realmName, userID, fid.IdentityProvider), "federated-identity")
+func (c *Client) CreateFederatedIdentity(ctx context.Context, fid v1alpha1.FederatedIdentity, userID string, realmName string) (string, error) {
+	return c.create(ctx, fid, fmt.Sprintf("realms/%s/users/%s/federated-identity/%s", realmName, userID, fid.IdentityProvider), "federated-identity")
 }
 
-func (c *Client) RemoveFederatedIdentity(fid v1alpha1.FederatedIdentity, userID string, realmName string) error {
-	return c.delete(fmt.Sprintf("realms/%s/users/%s/federated-identity/%s", realmName, userID, fid.IdentityProvider), "federated-identity", fid)
+func (c *Client) RemoveFederatedIdentity(ctx context.Context, fid v1alpha1.FederatedIdentity, userID string, realmName string) error {
+	return c.delete(ctx, fmt.Sprintf("realms/%s/users/%s/federated-identity/%s", realmName, userID, fid.IdentityProvider), "federated-identity", fid)
 }
 
-func (c *Client) GetUserFederatedIdentities(userID string, realmName string) ([]v1alpha1.FederatedIdentity, error) {
-	result, err := c.get(fmt.Sprintf("realms/%s/users/%s/federated-identity", realmName, userID), "federated-identity", func(body []byte) (T, error) {
+func (c *Client) GetUserFederatedIdentities(ctx context.Context, userID string, realmName string) ([]v1alpha1.FederatedIdentity, error) {
+	result, err := c.get(ctx, fmt.Sprintf("realms/%s/users/%s/federated-identity", realmName, userID), "federated-identity", func(body []byte) (T, error) {
 		var fids []v1alpha1.FederatedIdentity
 		err := json.Unmarshal(body, &fids)
 		return fids, err
@@ -136,23 +190,23 @@ func (c *Client) GetUserFederatedIdentities(userID string, realmName string) ([]
 	return result.([]v1alpha1.FederatedIdentity), err
 }
 
-func (c *Client) CreateUserClientRole(role *v1alpha1.KeycloakUserRole, realmName, clientID, userID string) (string, error) {
-	return c.create(
+func (c *Client) CreateUserClientRole(ctx context.Context, role *v1alpha1.KeycloakUserRole, realmName, clientID, userID string) (string, error) {
+	return c.create(ctx,
 		[]*v1alpha1.KeycloakUserRole{role},
 		fmt.Sprintf("realms/%s/users/%s/role-mappings/clients/%s", realmName, userID, clientID),
 		"user-client-role",
 	)
 }
-func (c *Client) CreateUserRealmRole(role *v1alpha1.KeycloakUserRole, realmName, userID string) (string, error) {
-	return c.create(
+func (c *Client) CreateUserRealmRole(ctx context.Context, role *v1alpha1.KeycloakUserRole, realmName, userID string) (string, error) {
+	return c.create(ctx,
 		[]*v1alpha1.KeycloakUserRole{role},
 		fmt.Sprintf("realms/%s/users/%s/role-mappings/realm", realmName, userID),
 		"user-realm-role",
 	)
 }
 
-func (c *Client) DeleteUserClientRole(role *v1alpha1.KeycloakUserRole, realmName, clientID, userID string) error {
-	err := c.delete(
+func (c *Client) DeleteUserClientRole(ctx context.Context, role *v1alpha1.KeycloakUserRole, realmName, clientID, userID string) error {
+	err := c.delete(ctx,
 		fmt.Sprintf("realms/%s/users/%s/role-mappings/clients/%s", realmName, userID, clientID),
 		"user-client-role",
 		[]*v1alpha1.KeycloakUserRole{role},
@@ -160,8 +214,8 @@ func (c *Client) DeleteUserClientRole(role *v1alpha1.KeycloakUserRole, realmName
 	return err
 }
 
-func (c *Client) DeleteUserRealmRole(role *v1alpha1.KeycloakUserRole, realmName, userID string) error {
-	err := c.delete(
+func (c *Client) DeleteUserRealmRole(ctx context.Context, role *v1alpha1.KeycloakUserRole, realmName, userID string) error {
+	err := c.delete(ctx,
 		fmt.Sprintf("realms/%s/users/%s/role-mappings/realm", realmName, userID),
 		"user-realm-role",
 		[]*v1alpha1.KeycloakUserRole{role},
@@ -170,9 +224,9 @@ func (c *Client) DeleteUserRealmRole(role *v1alpha1.KeycloakUserRole, realmName,
 }
 
 // Generic get function for returning a Keycloak resource
-func (c *Client) get(resourcePath, resourceName string, unMarshalFunc func(body []byte) (T, error)) (T, error) {
-	u := fmt.Sprintf("%s/auth/admin/%s", c.URL, resourcePath)
-	req, err := http.NewRequest(
+func (c *Client) get(ctx context.Context, resourcePath, resourceName string, unMarshalFunc func(body []byte) (T, error)) (T, error) {
+	u := c.adminURL(resourcePath)
+	req, err := http.NewRequestWithContext(ctx,
 		"GET",
 		u,
 		nil,
@@ -182,8 +236,7 @@ func (c *Client) get(resourcePath, resourceName string, unMarshalFunc func(body
 		return nil, errors.Wrapf(err, "error creating GET %s request", resourceName)
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	res, err := c.requester.Do(req)
+	res, err := c.do(req, resourceName)
 	if err != nil {
 
 		logClient.Error(err, "error on request")
@@ -197,7 +250,7 @@ func (c *Client) get(resourcePath, resourceName string, unMarshalFunc func(body
 	}
 
 	if res.StatusCode != 200 {
-		return nil, errors.Errorf("failed to GET %s: (%d) %s", resourceName, res.StatusCode, res.Status)
+		return nil, newKeycloakAPIError(res, "GET", resourceName)
 	}
 
 	body, err := ioutil.ReadAll(res.Body)
@@ -214,14 +267,14 @@ func (c *Client) get(resourcePath, resourceName string, unMarshalFunc func(body
 	return obj, nil
 }
 
-func (c *Client) GetRealm(realmName string) (*v1alpha1.KeycloakRealm, error) {
-	result, err := c.get(fmt.Sprintf("realms/%s", realmName), "realm", func(body []byte) (T, error) {
+func (c *Client) GetRealm(ctx context.Context, realmName string) (*v1alpha1.KeycloakRealm, error) {
+	result, err := c.get(ctx, fmt.Sprintf("realms/%s", realmName), "realm", func(body []byte) (T, error) {
 		realm := &v1alpha1.KeycloakAPIRealm{}
 		err := json.Unmarshal(body, realm)
 		return realm, err
 	})
-	if result == nil {
-		return nil, nil
+	if err != nil || result == nil {
+		return nil, err
 	}
 	ret := &v1alpha1.KeycloakRealm{
 		Spec: v1alpha1.KeycloakRealmSpec{
@@ -231,8 +284,55 @@ func (c *Client) GetRealm(realmName string) (*v1alpha1.KeycloakRealm, error) {
 	return ret, err
 }
 
-func (c *Client) GetClient(clientID, realmName string) (*v1alpha1.KeycloakAPIClient, error) {
-	result, err := c.get(fmt.Sprintf("realms/%s/clients/%s", realmName, clientID), "client", func(body []byte) (T, error) {
+// GetServerInfo returns the version, the features and the protocol mapper types of the Keycloak server
+func (c *Client) GetServerInfo(ctx context.Context) (*v1alpha1.KeycloakServerInfo, error) {
+	result, err := c.get(ctx, "serverinfo", "serverinfo", func(body []byte) (T, error) {
+		serverInfo := &v1alpha1.KeycloakServerInfo{}
+		err := json.Unmarshal(body, serverInfo)
+		return serverInfo, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.Errorf("server info not available at %s", c.URL)
+	}
+	return result.(*v1alpha1.KeycloakServerInfo), nil
+}
+
+// GetOpenIDConfiguration returns the OpenID Connect discovery document of the realm, nil if the realm does not exist
+func (c *Client) GetOpenIDConfiguration(ctx context.Context, realmName string) (*v1alpha1.KeycloakOpenIDConfiguration, error) {
+	req, err := http.NewRequestWithContext(ctx,
+		"GET",
+		fmt.Sprintf("%s%s/%s", c.URL, c.contextPath, fmt.Sprintf(wellKnownPath, realmName)),
+		nil,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating GET openid-configuration request")
+	}
+
+	res, err := c.do(req, "openid-configuration")
+	if err != nil {
+		logClient.Error(err, "error on request")
+		return nil, errors.Wrap(err, "error performing GET openid-configuration request")
+	}
+	defer res.Body.Close()
+	if res.StatusCode == 404 {
+		return nil, nil
+	}
+	if res.StatusCode != 200 {
+		return nil, newKeycloakAPIError(res, "GET", "openid-configuration")
+	}
+
+	config := &v1alpha1.KeycloakOpenIDConfiguration{}
+	if err := json.NewDecoder(res.Body).Decode(config); err != nil {
+		return nil, errors.Wrap(err, "error reading openid-configuration GET response")
+	}
+	return config, nil
+}
+
+func (c *Client) GetClient(ctx context.Context, clientID, realmName string) (*v1alpha1.KeycloakAPIClient, error) {
+	result, err := c.get(ctx, fmt.Sprintf("realms/%s/clients/%s", realmName, clientID), "client", func(body []byte) (T, error) {
 		client := &v1alpha1.KeycloakAPIClient{}
 		err := json.Unmarshal(body, client)
 		return client, err
@@ -247,11 +347,13 @@ func (c *Client) GetClient(clientID, realmName string) (*v1alpha1.KeycloakAPICli
 	return ret, err
 }
 
-func (c *Client) GetClientID(name, realmName string) (string, error) {
-	result, err := c.get(fmt.Sprintf("realms/%s/clients/?clientId=%s", realmName, name), "client", func(body []byte) (T, error) {
+func (c *Client) GetClientID(ctx context.Context, name, realmName string) (string, error) {
+	result, err := c.get(ctx, fmt.Sprintf("realms/%s/clients/?clientId=%s", realmName, url.QueryEscape(name)), "client", func(body []byte) (T, error) {
 		clients := []*v1alpha1.KeycloakAPIClient{}
-		err := json.Unmarshal(body, &clients)
-		return clients[0].ID, err
+		if err := json.Unmarshal(body, &clients); err != nil || len(clients) == 0 {
+			return "", err
+		}
+		return clients[0].ID, nil
 	})
 	if err != nil {
 		return "", err
@@ -263,9 +365,9 @@ func (c *Client) GetClientID(name, realmName string) (string, error) {
 	return ret, err
 }
 
-func (c *Client) GetClientSecret(clientID, realmName string) (string, error) {
+func (c *Client) GetClientSecret(ctx context.Context, clientID, realmName string) (string, error) {
 	//"https://{{ rhsso_route }}/auth/admin/realms/{{ rhsso_realm }}/clients/{{ rhsso_client_id }}/client-secret"
-	result, err := c.get(fmt.Sprintf("realms/%s/clients/%s/client-secret", realmName, clientID), "client-secret", func(body []byte) (T, error) {
+	result, err := c.get(ctx, fmt.Sprintf("realms/%s/clients/%s/client-secret", realmName, clientID), "client-secret", func(body []byte) (T, error) {
 		res := map[string]string{}
 		if err := json.Unmarshal(body, &res); err != nil {
 			return nil, err
@@ -281,9 +383,69 @@ func (c *Client) GetClientSecret(clientID, realmName string) (string, error) {
 	return result.(string), nil
 }
 
-func (c *Client) GetClientInstall(clientID, realmName string) ([]byte, error) {
+// RegenerateClientSecret generates a new secret for the client and returns it. With the client secret rotation of
+// Keycloak the previous secret stays valid as rotated secret.
+func (c *Client) RegenerateClientSecret(ctx context.Context, clientID, realmName string) (string, error) {
+	resourcePath := fmt.Sprintf("realms/%s/clients/%s/client-secret", realmName, clientID)
+	req, err := http.NewRequestWithContext(ctx,
+		"POST",
+		c.adminURL(resourcePath),
+		nil,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "error creating POST client-secret request")
+	}
+
+	res, err := c.do(req, "client-secret")
+	if err != nil {
+		logClient.Error(err, "error on request")
+		return "", errors.Wrap(err, "error performing POST client-secret request")
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		return "", newKeycloakAPIError(res, "POST", "client-secret")
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "error reading client-secret POST response")
+	}
+	credential := map[string]string{}
+	if err := json.Unmarshal(body, &credential); err != nil {
+		return "", errors.Wrap(err, "failed to regenerate: "+resourcePath)
+	}
+	return credential["value"], nil
+}
+
+// GetClientRotatedSecret returns the previous secret of the client which Keycloak keeps valid after a rotation,
+// empty if there is none
+func (c *Client) GetClientRotatedSecret(ctx context.Context, clientID, realmName string) (string, error) {
+	result, err := c.get(ctx, fmt.Sprintf("realms/%s/clients/%s/client-secret/rotated", realmName, clientID), "client-rotated-secret", func(body []byte) (T, error) {
+		res := map[string]string{}
+		if err := json.Unmarshal(body, &res); err != nil {
+			return nil, err
+		}
+		return res["value"], nil
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get: "+fmt.Sprintf("realms/%s/clients/%s/client-secret/rotated", realmName, clientID))
+	}
+	if result == nil {
+		return "", nil
+	}
+	return result.(string), nil
+}
+
+// InvalidateClientRotatedSecret invalidates the previous secret of the client before it expires
+func (c *Client) InvalidateClientRotatedSecret(ctx context.Context, clientID, realmName string) error {
+	return c.delete(ctx, fmt.Sprintf("realms/%s/clients/%s/client-secret/rotated", realmName, clientID), "client-rotated-secret", nil)
+}
+
+// GetClientInstall returns the document of an installation provider for the client, e.g. the keycloak.json of the
+// provider "keycloak-oidc-keycloak-json". Returns nil if the client or the provider does not exist.
+func (c *Client) GetClientInstall(ctx context.Context, clientID, providerID, realmName string) ([]byte, error) {
 	var response []byte
-	if _, err := c.get(fmt.Sprintf("realms/%s/clients/%s/installation/providers/keycloak-oidc-keycloak-json", realmName, clientID), "client-installation", func(body []byte) (T, error) {
+	if _, err := c.get(ctx, fmt.Sprintf("realms/%s/clients/%s/installation/providers/%s", realmName, clientID, providerID), "client-installation", func(body []byte) (T, error) {
 		response = body
 		return body, nil
 	}); err != nil {
@@ -293,15 +455,15 @@ func (c *Client) GetClientInstall(clientID, realmName string) ([]byte, error) {
 }
 
 // Generic put function for updating Keycloak resources
-func (c *Client) update(obj T, resourcePath, resourceName string) error {
+func (c *Client) update(ctx context.Context, obj T, resourcePath, resourceName string) error {
 	jsonValue, err := json.Marshal(obj)
 	if err != nil {
 		return nil
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(ctx,
 		"PUT",
-		fmt.Sprintf("%s/auth/admin/%s", c.URL, resourcePath),
+		c.adminURL(resourcePath),
 		bytes.NewBuffer(jsonValue),
 	)
 	if err != nil {
@@ -310,46 +472,50 @@ func (c *Client) update(obj T, resourcePath, resourceName string) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+c.token)
-	res, err := c.requester.Do(req)
+	res, err := c.do(req, resourceName)
 	if err != nil {
 		logClient.Error(err, "error on request")
 		return errors.Wrapf(err, "error performing UPDATE %s request", resourceName)
 	}
 	defer res.Body.Close()
 	if res.StatusCode < 200 || res.StatusCode > 299 {
-		logClient.Error(err, "failed to UPDATE %s %v", resourceName, res.Status)
-		return errors.Errorf("failed to UPDATE %s: (%d) %s", resourceName, res.StatusCode, res.Status)
+		apiErr := newKeycloakAPIError(res, "UPDATE", resourceName)
+		logClient.Error(apiErr, "failed to UPDATE "+resourceName)
+		return apiErr
 	}
 
 	return nil
 }
 
-func (c *Client) UpdateRealm(realm *v1alpha1.KeycloakRealm) error {
-	return c.update(realm, fmt.Sprintf("realms/%s", realm.Spec.Realm.ID), "realm")
+func (c *Client) UpdateRealm(ctx context.Context, realm *v1alpha1.KeycloakRealm) error {
+	return c.update(ctx, realm, fmt.Sprintf("realms/%s", realm.Spec.Realm.ID), "realm")
 }
 
-func (c *Client) UpdateClient(specClient *v1alpha1.KeycloakAPIClient, realmName string) error {
-	return c.update(specClient, fmt.Sprintf("realms/%s/clients/%s", realmName, specClient.ID), "client")
+func (c *Client) UpdateClient(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, realmName string) error {
+	return c.update(ctx, specClient, fmt.Sprintf("realms/%s/clients/%s", realmName, specClient.ID), "client")
 }
 
-func (c *Client) UpdateClientRole(clientID string, role, oldRole *v1alpha1.RoleRepresentation, realmName string) error {
-	return c.update(role, fmt.Sprintf("realms/%s/clients/%s/roles/%s", realmName, clientID, oldRole.Name), "client role")
+func (c *Client) UpdateClientRole(ctx context.Context, clientID string, role, oldRole *v1alpha1.RoleRepresentation, realmName string) error {
+	return c.update(ctx, role, fmt.Sprintf("realms/%s/clients/%s/roles/%s", realmName, clientID, oldRole.Name), "client role")
 }
 
-func (c *Client) UpdateClientDefaultClientScope(specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error {
-	return c.update(clientScope, fmt.Sprintf("realms/%s/clients/%s/default-client-scopes/%s", realmName, specClient.ID, clientScope.ID), "client default client scope")
+func (c *Client) UpdateClientProtocolMapper(ctx context.Context, clientID string, mapper *v1alpha1.KeycloakProtocolMapper, realmName string) error {
+	return c.update(ctx, mapper, fmt.Sprintf("realms/%s/clients/%s/protocol-mappers/models/%s", realmName, clientID, mapper.ID), "client protocol mapper")
 }
 
-func (c *Client) UpdateClientOptionalClientScope(specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error {
-	return c.update(clientScope, fmt.Sprintf("realms/%s/clients/%s/optional-client-scopes/%s", realmName, specClient.ID, clientScope.ID), "client optional client scope")
+func (c *Client) UpdateClientDefaultClientScope(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error {
+	return c.update(ctx, clientScope, fmt.Sprintf("realms/%s/clients/%s/default-client-scopes/%s", realmName, specClient.ID, clientScope.ID), "client default client scope")
+}
+
+func (c *Client) UpdateClientOptionalClientScope(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error {
+	return c.update(ctx, clientScope, fmt.Sprintf("realms/%s/clients/%s/optional-client-scopes/%s", realmName, specClient.ID, clientScope.ID), "client optional client scope")
 }
 
 // Generic delete function for deleting Keycloak resources
-func (c *Client) delete(resourcePath, resourceName string, obj T) error {
-	req, err := http.NewRequest(
+func (c *Client) delete(ctx context.Context, resourcePath, resourceName string, obj T) error {
+	req, err := http.NewRequestWithContext(ctx,
 		"DELETE",
-		fmt.Sprintf("%s/auth/admin/%s", c.URL, resourcePath),
+		c.adminURL(resourcePath),
 		nil,
 	)
 
@@ -358,9 +524,9 @@ func (c *Client) delete(resourcePath, resourceName string, obj T) error {
 		if err != nil {
 			return nil
 		}
-		req, err = http.NewRequest(
+		req, err = http.NewRequestWithContext(ctx,
 			"DELETE",
-			fmt.Sprintf("%s/auth/admin/%s", c.URL, resourcePath),
+			c.adminURL(resourcePath),
 			bytes.NewBuffer(jsonValue),
 		)
 		if err != nil {
@@ -374,8 +540,7 @@ func (c *Client) delete(resourcePath, resourceName string, obj T) error {
 		return errors.Wrapf(err, "error creating DELETE %s request", resourceName)
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	res, err := c.requester.Do(req)
+	res, err := c.do(req, resourceName)
 	if err != nil {
 		logClient.Error(err, "error on request")
 		return errors.Wrapf(err, "error performing DELETE %s request", resourceName)
@@ -385,52 +550,56 @@ func (c *Client) delete(resourcePath, resourceName string, obj T) error {
 		logClient.Error(err, "Resource %v/%v already deleted", resourcePath, resourceName)
 	}
 	if res.StatusCode != 204 && res.StatusCode != 404 {
-		return errors.Errorf("failed to DELETE %s: (%d) %s", resourceName, res.StatusCode, res.Status)
+		return newKeycloakAPIError(res, "DELETE", resourceName)
 	}
 
 	return nil
 }
 
-func (c *Client) DeleteRealm(realmName string) error {
-	err := c.delete(fmt.Sprintf("realms/%s", realmName), "realm", nil)
+func (c *Client) DeleteRealm(ctx context.Context, realmName string) error {
+	err := c.delete(ctx, fmt.Sprintf("realms/%s", realmName), "realm", nil)
 	return err
 }
 
-func (c *Client) DeleteClient(clientID, realmName string) error {
-	err := c.delete(fmt.Sprintf("realms/%s/clients/%s", realmName, clientID), "client", nil)
+func (c *Client) DeleteClient(ctx context.Context, clientID, realmName string) error {
+	err := c.delete(ctx, fmt.Sprintf("realms/%s/clients/%s", realmName, clientID), "client", nil)
 	return err
 }
 
-func (c *Client) DeleteClientRole(clientID, role, realmName string) error {
-	err := c.delete(fmt.Sprintf("realms/%s/clients/%s/roles/%s", realmName, clientID, role), "client role", nil)
+func (c *Client) DeleteClientRole(ctx context.Context, clientID, role, realmName string) error {
+	err := c.delete(ctx, fmt.Sprintf("realms/%s/clients/%s/roles/%s", realmName, clientID, role), "client role", nil)
 	return err
 }
 
-func (c *Client) DeleteRealmRoleComposites(realmName, roleID string, roles *[]v1alpha1.RoleRepresentation) error {
-	return c.delete(fmt.Sprintf("realms/%s/roles-by-id/%s/composites", realmName, roleID), "realm role composites", roles)
+func (c *Client) DeleteClientProtocolMapper(ctx context.Context, clientID, mapperID, realmName string) error {
+	return c.delete(ctx, fmt.Sprintf("realms/%s/clients/%s/protocol-mappers/models/%s", realmName, clientID, mapperID), "client protocol mapper", nil)
 }
 
-func (c *Client) DeleteClientRealmScopeMappings(specClient *v1alpha1.KeycloakAPIClient, mappings *[]v1alpha1.RoleRepresentation, realmName string) error {
-	return c.delete(fmt.Sprintf("realms/%s/clients/%s/scope-mappings/realm", realmName, specClient.ID), "client realm scope mappings", mappings)
+func (c *Client) DeleteRealmRoleComposites(ctx context.Context, realmName, roleID string, roles *[]v1alpha1.RoleRepresentation) error {
+	return c.delete(ctx, fmt.Sprintf("realms/%s/roles-by-id/%s/composites", realmName, roleID), "realm role composites", roles)
 }
 
-func (c *Client) DeleteClientClientScopeMappings(specClient *v1alpha1.KeycloakAPIClient, mappings *v1alpha1.ClientMappingsRepresentation, realmName string) error {
-	return c.delete(fmt.Sprintf("realms/%s/clients/%s/scope-mappings/clients/%s", realmName, specClient.ID, mappings.ID), "client client scope mappings", mappings.Mappings)
+func (c *Client) DeleteClientRealmScopeMappings(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, mappings *[]v1alpha1.RoleRepresentation, realmName string) error {
+	return c.delete(ctx, fmt.Sprintf("realms/%s/clients/%s/scope-mappings/realm", realmName, specClient.ID), "client realm scope mappings", mappings)
 }
 
-func (c *Client) DeleteClientDefaultClientScope(specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error {
-	return c.delete(fmt.Sprintf("realms/%s/clients/%s/default-client-scopes/%s", realmName, specClient.ID, clientScope.ID), "client default client scope", clientScope)
+func (c *Client) DeleteClientClientScopeMappings(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, mappings *v1alpha1.ClientMappingsRepresentation, realmName string) error {
+	return c.delete(ctx, fmt.Sprintf("realms/%s/clients/%s/scope-mappings/clients/%s", realmName, specClient.ID, mappings.ID), "client client scope mappings", mappings.Mappings)
 }
 
-func (c *Client) DeleteClientOptionalClientScope(specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error {
-	return c.delete(fmt.Sprintf("realms/%s/clients/%s/optional-client-scopes/%s", realmName, specClient.ID, clientScope.ID), "client optional client scope", clientScope)
+func (c *Client) DeleteClientDefaultClientScope(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error {
+	return c.delete(ctx, fmt.Sprintf("realms/%s/clients/%s/default-client-scopes/%s", realmName, specClient.ID, clientScope.ID), "client default client scope", clientScope)
+}
+
+func (c *Client) DeleteClientOptionalClientScope(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error {
+	return c.delete(ctx, fmt.Sprintf("realms/%s/clients/%s/optional-client-scopes/%s", realmName, specClient.ID, clientScope.ID), "client optional client scope", clientScope)
 }
 
 // Generic list function for listing Keycloak resources
-func (c *Client) list(resourcePath, resourceName string, unMarshalListFunc func(body []byte) (T, error)) (T, error) {
-	req, err := http.NewRequest(
+func (c *Client) list(ctx context.Context, resourcePath, resourceName string, unMarshalListFunc func(body []byte) (T, error)) (T, error) {
+	req, err := http.NewRequestWithContext(ctx,
 		"GET",
-		fmt.Sprintf("%s/auth/admin/%s", c.URL, resourcePath),
+		c.adminURL(resourcePath),
 		nil,
 	)
 	if err != nil {
@@ -438,8 +607,7 @@ func (c *Client) list(resourcePath, resourceName string, unMarshalListFunc func(
 		return nil, errors.Wrapf(err, "error creating LIST %s request", resourceName)
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	res, err := c.requester.Do(req)
+	res, err := c.do(req, resourceName)
 	if err != nil {
 		logClient.Error(err, "error on request")
 		return nil, errors.Wrapf(err, "error performing LIST %s request", resourceName)
@@ -447,7 +615,7 @@ func (c *Client) list(resourcePath, resourceName string, unMarshalListFunc func(
 	defer res.Body.Close()
 
 	if res.StatusCode < 200 || res.StatusCode > 299 {
-		return nil, errors.Errorf("failed to LIST %s: (%d) %s", resourceName, res.StatusCode, res.Status)
+		return nil, newKeycloakAPIError(res, "LIST", resourceName)
 	}
 
 	body, err := ioutil.ReadAll(res.Body)
@@ -464,8 +632,8 @@ func (c *Client) list(resourcePath, resourceName string, unMarshalListFunc func(
 	return objs, nil
 }
 
-func (c *Client) ListRealms() ([]*v1alpha1.KeycloakRealm, error) {
-	result, err := c.list("realms", "realm", func(body []byte) (T, error) {
+func (c *Client) ListRealms(ctx context.Context) ([]*v1alpha1.KeycloakRealm, error) {
+	result, err := c.list(ctx, "realms", "realm", func(body []byte) (T, error) {
 		var realms []*v1alpha1.KeycloakRealm
 		err := json.Unmarshal(body, &realms)
 		return realms, err
@@ -477,8 +645,8 @@ func (c *Client) ListRealms() ([]*v1alpha1.KeycloakRealm, error) {
 	return resultAsRealm, err
 }
 
-func (c *Client) ListRealmRoleClientRoleComposites(realmName, roleID, clientID string) ([]v1alpha1.RoleRepresentation, error) {
-	result, err := c.list(fmt.Sprintf("realms/%s/roles-by-id/%s/composites/clients/%s", realmName, roleID, clientID), "realm role client role composites", func(body []byte) (T, error) {
+func (c *Client) ListRealmRoleClientRoleComposites(ctx context.Context, realmName, roleID, clientID string) ([]v1alpha1.RoleRepresentation, error) {
+	result, err := c.list(ctx, fmt.Sprintf("realms/%s/roles-by-id/%s/composites/clients/%s", realmName, roleID, clientID), "realm role client role composites", func(body []byte) (T, error) {
 		var roles []v1alpha1.RoleRepresentation
 		err := json.Unmarshal(body, &roles)
 		return roles, err
@@ -497,8 +665,8 @@ func (c *Client) ListRealmRoleClientRoleComposites(realmName, roleID, clientID s
 	return res, nil
 }
 
-func (c *Client) ListClients(realmName string) ([]*v1alpha1.KeycloakAPIClient, error) {
-	result, err := c.list(fmt.Sprintf("realms/%s/clients", realmName), "clients", func(body []byte) (T, error) {
+func (c *Client) ListClients(ctx context.Context, realmName string) ([]*v1alpha1.KeycloakAPIClient, error) {
+	result, err := c.list(ctx, fmt.Sprintf("realms/%s/clients", realmName), "clients", func(body []byte) (T, error) {
 		var clients []*v1alpha1.KeycloakAPIClient
 		err := json.Unmarshal(body, &clients)
 		return clients, err
@@ -517,8 +685,8 @@ func (c *Client) ListClients(realmName string) ([]*v1alpha1.KeycloakAPIClient, e
 	return res, nil
 }
 
-func (c *Client) ListClientRoles(clientID, realmName string) ([]v1alpha1.RoleRepresentation, error) {
-	result, err := c.list(fmt.Sprintf("realms/%s/clients/%s/roles", realmName, clientID), "client roles", func(body []byte) (T, error) {
+func (c *Client) ListClientRoles(ctx context.Context, clientID, realmName string) ([]v1alpha1.RoleRepresentation, error) {
+	result, err := c.list(ctx, fmt.Sprintf("realms/%s/clients/%s/roles", realmName, clientID), "client roles", func(body []byte) (T, error) {
 		var roles []v1alpha1.RoleRepresentation
 		err := json.Unmarshal(body, &roles)
 		return roles, err
@@ -537,8 +705,28 @@ func (c *Client) ListClientRoles(clientID, realmName string) ([]v1alpha1.RoleRep
 	return res, nil
 }
 
-func (c *Client) ListScopeMappings(clientID, realmName string) (*v1alpha1.MappingsRepresentation, error) {
-	result, err := c.list(fmt.Sprintf("realms/%s/clients/%s/scope-mappings", realmName, clientID), "client scope mappings", func(body []byte) (T, error) {
+func (c *Client) ListClientProtocolMappers(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakProtocolMapper, error) {
+	result, err := c.list(ctx, fmt.Sprintf("realms/%s/clients/%s/protocol-mappers/models", realmName, clientID), "client protocol mappers", func(body []byte) (T, error) {
+		var mappers []v1alpha1.KeycloakProtocolMapper
+		err := json.Unmarshal(body, &mappers)
+		return mappers, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, ok := result.([]v1alpha1.KeycloakProtocolMapper)
+
+	if !ok {
+		return nil, errors.Errorf("error decoding list client protocol mappers response")
+	}
+
+	return res, nil
+}
+
+func (c *Client) ListScopeMappings(ctx context.Context, clientID, realmName string) (*v1alpha1.MappingsRepresentation, error) {
+	result, err := c.list(ctx, fmt.Sprintf("realms/%s/clients/%s/scope-mappings", realmName, clientID), "client scope mappings", func(body []byte) (T, error) {
 		var mappings v1alpha1.MappingsRepresentation
 		err := json.Unmarshal(body, &mappings)
 		return mappings, err
@@ -557,8 +745,8 @@ func (c *Client) ListScopeMappings(clientID, realmName string) (*v1alpha1.Mappin
 	return &res, nil
 }
 
-func (c *Client) listClientScopes(path string, msg string) ([]v1alpha1.KeycloakClientScope, error) {
-	result, err := c.list(path, msg, func(body []byte) (T, error) {
+func (c *Client) listClientScopes(ctx context.Context, path string, msg string) ([]v1alpha1.KeycloakClientScope, error) {
+	result, err := c.list(ctx, path, msg, func(body []byte) (T, error) {
 		var assignedClientScopes []v1alpha1.KeycloakClientScope
 		err := json.Unmarshal(body, &assignedClientScopes)
 		return assignedClientScopes, err
@@ -577,20 +765,20 @@ func (c *Client) listClientScopes(path string, msg string) ([]v1alpha1.KeycloakC
 	return res, nil
 }
 
-func (c *Client) ListAvailableClientScopes(realmName string) ([]v1alpha1.KeycloakClientScope, error) {
-	return c.listClientScopes(fmt.Sprintf("realms/%s/client-scopes", realmName), "available client scopes")
+func (c *Client) ListAvailableClientScopes(ctx context.Context, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+	return c.listClientScopes(ctx, fmt.Sprintf("realms/%s/client-scopes", realmName), "available client scopes")
 }
 
-func (c *Client) ListDefaultClientScopes(clientID, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
-	return c.listClientScopes(fmt.Sprintf("realms/%s/clients/%s/default-client-scopes", realmName, clientID), "default client scopes")
+func (c *Client) ListDefaultClientScopes(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+	return c.listClientScopes(ctx, fmt.Sprintf("realms/%s/clients/%s/default-client-scopes", realmName, clientID), "default client scopes")
 }
 
-func (c *Client) ListOptionalClientScopes(clientID, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
-	return c.listClientScopes(fmt.Sprintf("realms/%s/clients/%s/optional-client-scopes", realmName, clientID), "optional client scopes")
+func (c *Client) ListOptionalClientScopes(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+	return c.listClientScopes(ctx, fmt.Sprintf("realms/%s/clients/%s/optional-client-scopes", realmName, clientID), "optional client scopes")
 }
 
-func (c *Client) ListUserClientRoles(realmName, clientID, userID string) ([]*v1alpha1.KeycloakUserRole, error) {
-	objects, err := c.list("realms/"+realmName+"/users/"+userID+"/role-mappings/clients/"+clientID, "userClientRoles", func(body []byte) (t T, e error) {
+func (c *Client) ListUserClientRoles(ctx context.Context, realmName, clientID, userID string) ([]*v1alpha1.KeycloakUserRole, error) {
+	objects, err := c.list(ctx, "realms/"+realmName+"/users/"+userID+"/role-mappings/clients/"+clientID, "userClientRoles", func(body []byte) (t T, e error) {
 		var userClientRoles []*v1alpha1.KeycloakUserRole
 		err := json.Unmarshal(body, &userClientRoles)
 		return userClientRoles, err
@@ -604,8 +792,8 @@ func (c *Client) ListUserClientRoles(realmName, clientID, userID string) ([]*v1a
 	return objects.([]*v1alpha1.KeycloakUserRole), err
 }
 
-func (c *Client) ListAvailableUserClientRoles(realmName, clientID, userID string) ([]*v1alpha1.KeycloakUserRole, error) {
-	objects, err := c.list("realms/"+realmName+"/users/"+userID+"/role-mappings/clients/"+clientID+"/available", "userClientRoles", func(body []byte) (t T, e error) {
+func (c *Client) ListAvailableUserClientRoles(ctx context.Context, realmName, clientID, userID string) ([]*v1alpha1.KeycloakUserRole, error) {
+	objects, err := c.list(ctx, "realms/"+realmName+"/users/"+userID+"/role-mappings/clients/"+clientID+"/available", "userClientRoles", func(body []byte) (t T, e error) {
 		var userClientRoles []*v1alpha1.KeycloakUserRole
 		err := json.Unmarshal(body, &userClientRoles)
 		return userClientRoles, err
@@ -619,8 +807,8 @@ func (c *Client) ListAvailableUserClientRoles(realmName, clientID, userID string
 	return objects.([]*v1alpha1.KeycloakUserRole), err
 }
 
-func (c *Client) ListUserRealmRoles(realmName, userID string) ([]*v1alpha1.KeycloakUserRole, error) {
-	objects, err := c.list("realms/"+realmName+"/users/"+userID+"/role-mappings/realm", "userRealmRoles", func(body []byte) (t T, e error) {
+func (c *Client) ListUserRealmRoles(ctx context.Context, realmName, userID string) ([]*v1alpha1.KeycloakUserRole, error) {
+	objects, err := c.list(ctx, "realms/"+realmName+"/users/"+userID+"/role-mappings/realm", "userRealmRoles", func(body []byte) (t T, e error) {
 		var userRealmRoles []*v1alpha1.KeycloakUserRole
 		err := json.Unmarshal(body, &userRealmRoles)
 		return userRealmRoles, err
@@ -634,8 +822,8 @@ func (c *Client) ListUserRealmRoles(realmName, userID string) ([]*v1alpha1.Keycl
 	return objects.([]*v1alpha1.KeycloakUserRole), err
 }
 
-func (c *Client) ListAvailableUserRealmRoles(realmName, userID string) ([]*v1alpha1.KeycloakUserRole, error) {
-	objects, err := c.list("realms/"+realmName+"/users/"+userID+"/role-mappings/realm/available", "userClientRoles", func(body []byte) (t T, e error) {
+func (c *Client) ListAvailableUserRealmRoles(ctx context.Context, realmName, userID string) ([]*v1alpha1.KeycloakUserRole, error) {
+	objects, err := c.list(ctx, "realms/"+realmName+"/users/"+userID+"/role-mappings/realm/available", "userClientRoles", func(body []byte) (t T, e error) {
 		var userRealmRoles []*v1alpha1.KeycloakUserRole
 		err := json.Unmarshal(body, &userRealmRoles)
 		return userRealmRoles, err
@@ -649,9 +837,9 @@ func (c *Client) ListAvailableUserRealmRoles(realmName, userID string) ([]*v1alp
 	return objects.([]*v1alpha1.KeycloakUserRole), err
 }
 
-func (c *Client) Ping() error {
-	u := c.URL + "/auth/"
-	req, err := http.NewRequest("GET", u, nil)
+func (c *Client) Ping(ctx context.Context) error {
+	u := c.URL + c.contextPath + "/"
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		logClient.Error(err, "error creating ping request")
 		return errors.Wrap(err, "error creating ping request")
@@ -672,8 +860,8 @@ func (c *Client) Ping() error {
 	return nil
 }
 
-func (c *Client) GetServiceAccountUser(realmName, clientID string) (*v1alpha1.KeycloakAPIUser, error) {
-	result, err := c.get(fmt.Sprintf("realms/%s/clients/%s/service-account-user", realmName, clientID), "service-account-user", func(body []byte) (T, error) {
+func (c *Client) GetServiceAccountUser(ctx context.Context, realmName, clientID string) (*v1alpha1.KeycloakAPIUser, error) {
+	result, err := c.get(ctx, fmt.Sprintf("realms/%s/clients/%s/service-account-user", realmName, clientID), "service-account-user", func(body []byte) (T, error) {
 		user := &v1alpha1.KeycloakAPIUser{}
 		err := json.Unmarshal(body, user)
 		return user, err
@@ -688,102 +876,445 @@ func (c *Client) GetServiceAccountUser(realmName, clientID string) (*v1alpha1.Ke
 	return ret, err
 }
 
-// login requests a new auth token from Keycloak
-func (c *Client) login_admin(user, pass string) error {
-	logClient.Info("start login with adminuser")
-	form := url.Values{}
-	form.Add("username", user)
-	form.Add("password", pass)
-	form.Add("client_id", "admin-cli")
-	form.Add("grant_type", "password")
+// authorizationPath returns the path of a resource of the authorization services of a client
+func authorizationPath(realmName, clientID, resourcePath string) string {
+	return fmt.Sprintf("realms/%s/clients/%s/authz/resource-server%s", realmName, clientID, resourcePath)
+}
 
-	req, err := http.NewRequest(
-		"POST",
-		fmt.Sprintf("%s/%s", c.URL, authURL),
-		strings.NewReader(form.Encode()),
-	)
+// authorizationSettings is the representation of the settings of a resource server. Its resources, scopes and
+// policies are read and written through their own endpoints.
+type authorizationSettings struct {
+	*v1alpha1.KeycloakResourceServer
+	Policies  json.RawMessage `json:"policies,omitempty"`
+	Resources json.RawMessage `json:"resources,omitempty"`
+	Scopes    json.RawMessage `json:"scopes,omitempty"`
+}
+
+// authorizationResource is the representation of a resource, whose attributes are multi-valued in Keycloak
+type authorizationResource struct {
+	v1alpha1.KeycloakResource
+	Attributes map[string][]string `json:"attributes,omitempty"`
+}
+
+func newAuthorizationResource(resource *v1alpha1.KeycloakResource) authorizationResource {
+	representation := authorizationResource{KeycloakResource: *resource}
+	for key, value := range resource.Attributes {
+		if representation.Attributes == nil {
+			representation.Attributes = map[string][]string{}
+		}
+		representation.Attributes[key] = []string{value}
+	}
+	return representation
+}
+
+func (r authorizationResource) toKeycloakResource() v1alpha1.KeycloakResource {
+	resource := r.KeycloakResource
+	resource.Attributes = nil
+	for key, values := range r.Attributes {
+		if resource.Attributes == nil {
+			resource.Attributes = map[string]string{}
+		}
+		resource.Attributes[key] = strings.Join(values, ",")
+	}
+	return resource
+}
+
+// IsAuthorizationPermission returns true if policies of the given type are permissions
+func IsAuthorizationPermission(policyType string) bool {
+	return policyType == "resource" || policyType == "scope"
+}
+
+// HasAuthorizationPolicyAssociations returns true if the resources, scopes and policies associated with policies of
+// the given type are read, i.e. for permissions and aggregated policies
+func HasAuthorizationPolicyAssociations(policyType string) bool {
+	return IsAuthorizationPermission(policyType) || policyType == "aggregate"
+}
+
+// GetAuthorizationSettings returns the settings of the resource server of a client, without its resources,
+// scopes and policies
+func (c *Client) GetAuthorizationSettings(ctx context.Context, clientID, realmName string) (*v1alpha1.KeycloakResourceServer, error) {
+	result, err := c.get(ctx, authorizationPath(realmName, clientID, ""), "client authorization settings", func(body []byte) (T, error) {
+		settings := &v1alpha1.KeycloakResourceServer{}
+		err := json.Unmarshal(body, &authorizationSettings{KeycloakResourceServer: settings})
+		return settings, err
+	})
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return result.(*v1alpha1.KeycloakResourceServer), nil
+}
+
+func (c *Client) UpdateAuthorizationSettings(ctx context.Context, clientID string, settings *v1alpha1.KeycloakResourceServer, realmName string) error {
+	return c.update(ctx, authorizationSettings{KeycloakResourceServer: settings}, authorizationPath(realmName, clientID, ""), "client authorization settings")
+}
+
+func (c *Client) ListAuthorizationScopes(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakScope, error) {
+	result, err := c.list(ctx, authorizationPath(realmName, clientID, "/scope?first=0&max=-1"), "client authorization scopes", func(body []byte) (T, error) {
+		var scopes []v1alpha1.KeycloakScope
+		err := json.Unmarshal(body, &scopes)
+		return scopes, err
+	})
 	if err != nil {
-		return errors.Wrap(err, "error creating login request")
+		return nil, err
 	}
 
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	res, err := c.requester.Do(req)
+	res, ok := result.([]v1alpha1.KeycloakScope)
+	if !ok {
+		return nil, errors.Errorf("error decoding list client authorization scopes response")
+	}
+	return res, nil
+}
+
+func (c *Client) CreateAuthorizationScope(ctx context.Context, clientID string, scope *v1alpha1.KeycloakScope, realmName string) error {
+	_, err := c.create(ctx, scope, authorizationPath(realmName, clientID, "/scope"), "client authorization scope")
+	return err
+}
+
+func (c *Client) UpdateAuthorizationScope(ctx context.Context, clientID string, scope *v1alpha1.KeycloakScope, realmName string) error {
+	return c.update(ctx, scope, authorizationPath(realmName, clientID, "/scope/"+scope.ID), "client authorization scope")
+}
+
+func (c *Client) DeleteAuthorizationScope(ctx context.Context, clientID, scopeID, realmName string) error {
+	return c.delete(ctx, authorizationPath(realmName, clientID, "/scope/"+scopeID), "client authorization scope", nil)
+}
+
+func (c *Client) ListAuthorizationResources(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakResource, error) {
+	result, err := c.list(ctx, authorizationPath(realmName, clientID, "/resource?deep=true&first=0&max=-1"), "client authorization resources", func(body []byte) (T, error) {
+		var representations []authorizationResource
+		err := json.Unmarshal(body, &representations)
+		resources := []v1alpha1.KeycloakResource{}
+		for _, representation := range representations {
+			resources = append(resources, representation.toKeycloakResource())
+		}
+		return resources, err
+	})
 	if err != nil {
-		logClient.Error(err, "error on request ")
-		return errors.Wrap(err, "error performing token request")
+		return nil, err
 	}
-	defer res.Body.Close()
-	body, err := ioutil.ReadAll(res.Body)
+
+	res, ok := result.([]v1alpha1.KeycloakResource)
+	if !ok {
+		return nil, errors.Errorf("error decoding list client authorization resources response")
+	}
+	return res, nil
+}
+
+func (c *Client) CreateAuthorizationResource(ctx context.Context, clientID string, resource *v1alpha1.KeycloakResource, realmName string) error {
+	_, err := c.create(ctx, newAuthorizationResource(resource), authorizationPath(realmName, clientID, "/resource"), "client authorization resource")
+	return err
+}
+
+func (c *Client) UpdateAuthorizationResource(ctx context.Context, clientID string, resource *v1alpha1.KeycloakResource, realmName string) error {
+	return c.update(ctx, newAuthorizationResource(resource), authorizationPath(realmName, clientID, "/resource/"+resource.ID), "client authorization resource")
+}
+
+func (c *Client) DeleteAuthorizationResource(ctx context.Context, clientID, resourceID, realmName string) error {
+	return c.delete(ctx, authorizationPath(realmName, clientID, "/resource/"+resourceID), "client authorization resource", nil)
+}
+
+// ListAuthorizationPolicies returns the policies and permissions of a client. Permissions and aggregated policies
+// contain the names of their resources, scopes and policies.
+func (c *Client) ListAuthorizationPolicies(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakPolicy, error) {
+	result, err := c.list(ctx, authorizationPath(realmName, clientID, "/policy?first=0&max=-1"), "client authorization policies", func(body []byte) (T, error) {
+		var policies []v1alpha1.KeycloakPolicy
+		err := json.Unmarshal(body, &policies)
+		return policies, err
+	})
 	if err != nil {
-		logClient.Error(err, "error reading response")
-		return errors.Wrap(err, "error reading token response")
+		return nil, err
 	}
 
-	tokenRes := &v1alpha1.TokenResponse{}
-	err = json.Unmarshal(body, tokenRes)
+	policies, ok := result.([]v1alpha1.KeycloakPolicy)
+	if !ok {
+		return nil, errors.Errorf("error decoding list client authorization policies response")
+	}
+
+	for i := range policies {
+		if !HasAuthorizationPolicyAssociations(policies[i].Type) {
+			continue
+		}
+		if policies[i].Resources, err = c.listAuthorizationPolicyAssociations(ctx, clientID, policies[i].ID, "resources", realmName); err != nil {
+			return nil, err
+		}
+		if policies[i].Scopes, err = c.listAuthorizationPolicyAssociations(ctx, clientID, policies[i].ID, "scopes", realmName); err != nil {
+			return nil, err
+		}
+		if policies[i].Policies, err = c.listAuthorizationPolicyAssociations(ctx, clientID, policies[i].ID, "associatedPolicies", realmName); err != nil {
+			return nil, err
+		}
+	}
+	return policies, nil
+}
+
+// listAuthorizationPolicyAssociations returns the names of the resources, scopes or policies associated with a policy
+func (c *Client) listAuthorizationPolicyAssociations(ctx context.Context, clientID, policyID, association, realmName string) ([]string, error) {
+	result, err := c.list(ctx, authorizationPath(realmName, clientID, "/policy/"+policyID+"/"+association), "client authorization policy "+association, func(body []byte) (T, error) {
+		var associations []struct {
+			Name string `json:"name"`
+		}
+		err := json.Unmarshal(body, &associations)
+		var names []string
+		for _, association := range associations {
+			names = append(names, association.Name)
+		}
+		return names, err
+	})
 	if err != nil {
-		return errors.Wrap(err, "error parsing token response")
+		return nil, err
 	}
+	names, _ := result.([]string)
+	return names, nil
+}
 
-	if tokenRes.Error != "" {
-		logClient.Error(errors.New(tokenRes.Error), "error with request: %s", tokenRes.ErrorDescription)
-		return errors.Errorf(tokenRes.ErrorDescription)
+func (c *Client) CreateAuthorizationPolicy(ctx context.Context, clientID string, policy *v1alpha1.KeycloakPolicy, realmName string) error {
+	_, err := c.create(ctx, policy, authorizationPath(realmName, clientID, "/policy"), "client authorization policy")
+	return err
+}
+
+func (c *Client) UpdateAuthorizationPolicy(ctx context.Context, clientID string, policy *v1alpha1.KeycloakPolicy, realmName string) error {
+	return c.update(ctx, policy, authorizationPath(realmName, clientID, "/policy/"+policy.ID), "client authorization policy")
+}
+
+func (c *Client) DeleteAuthorizationPolicy(ctx context.Context, clientID, policyID, realmName string) error {
+	return c.delete(ctx, authorizationPath(realmName, clientID, "/policy/"+policyID), "client authorization policy", nil)
+}
+
+// login requests a new auth token from Keycloak
+func (c *Client) login_admin(ctx context.Context, user, pass string) error {
+	logClient.Info("start login with adminuser")
+	form := url.Values{}
+	form.Add("username", user)
+	form.Add("password", pass)
+	form.Add("client_id", "admin-cli")
+	form.Add("grant_type", "password")
+
+	tokenRes, err := c.requestToken(ctx, form)
+	if err != nil {
+		return err
 	}
 
-	c.token = tokenRes.AccessToken
+	c.setToken(tokenRes, "admin-cli", "")
 	logClient.Info("login with adminuser succeeded")
 
 	return nil
 }
 
 // login requests a new auth token from Keycloak
-func (c *Client) login(client, credential string) error {
+func (c *Client) login(ctx context.Context, client, credential string) error {
 	form := url.Values{}
 
 	form.Add("client_id", client)
 	form.Add("client_secret", credential)
 	form.Add("grant_type", "client_credentials")
 
-	req, err := http.NewRequest(
+	tokenRes, err := c.requestToken(ctx, form)
+	if err != nil {
+		return err
+	}
+
+	c.setToken(tokenRes, client, credential)
+	logClient.Info("login with serviceaccount " + client + " succeeded")
+	return nil
+}
+
+// refresh exchanges the refresh token for a new access token
+func (c *Client) refresh(ctx context.Context) error {
+	form := url.Values{}
+	form.Add("client_id", c.tokenClientID)
+	if c.tokenClientSecret != "" {
+		form.Add("client_secret", c.tokenClientSecret)
+	}
+	form.Add("grant_type", "refresh_token")
+	form.Add("refresh_token", c.refreshToken)
+
+	tokenRes, err := c.requestToken(ctx, form)
+	if err != nil {
+		return err
+	}
+
+	c.setToken(tokenRes, c.tokenClientID, c.tokenClientSecret)
+	logClient.Info("refreshed token of " + c.tokenClientID)
+	return nil
+}
+
+// authenticate logs in with the credentials of the client. The service account of a client is preferred
+// over the admin user if both are configured.
+func (c *Client) authenticate(ctx context.Context) error {
+	if c.credentials == nil {
+		return errors.Errorf("no credentials available to authenticate against keycloak")
+	}
+
+	if c.credentials.clientName != "" && c.credentials.clientSecret != "" {
+		err := c.login(ctx, c.credentials.clientName, c.credentials.clientSecret)
+		if err == nil {
+			return nil
+		}
+		logClient.Info(fmt.Sprintf("login with serviceaccount %s failed, falling back to adminuser: %s", c.credentials.clientName, err))
+	}
+	return c.login_admin(ctx, c.credentials.user, c.credentials.password)
+}
+
+// requestToken posts the given form to the token endpoint and returns the parsed response
+func (c *Client) requestToken(ctx context.Context, form url.Values) (*v1alpha1.TokenResponse, error) {
+	req, err := http.NewRequestWithContext(ctx,
 		"POST",
-		fmt.Sprintf("%s/%s", c.URL, authURL),
+		c.tokenEndpoint(),
 		strings.NewReader(form.Encode()),
 	)
 	if err != nil {
-		return errors.Wrap(err, "error creating login request")
+		return nil, errors.Wrap(err, "error creating login request")
 	}
 
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req, span := c.startRequestSpan(req, "token")
+	start := time.Now()
 	res, err := c.requester.Do(req)
+	observeRequest(c.keycloak, req.Method, "token", res, err, time.Since(start))
+	endRequestSpan(span, res, err)
 	if err != nil {
 		logClient.Error(err, "error on request ")
-		return errors.Wrap(err, "error performing token request")
+		observeLoginFailure(c.keycloak, form.Get("grant_type"))
+		return nil, errors.Wrap(err, "error performing token request")
 	}
 	defer res.Body.Close()
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
 		logClient.Error(err, "error reading response")
-		return errors.Wrap(err, "error reading token response")
+		observeLoginFailure(c.keycloak, form.Get("grant_type"))
+		return nil, errors.Wrap(err, "error reading token response")
 	}
 
 	tokenRes := &v1alpha1.TokenResponse{}
 	err = json.Unmarshal(body, tokenRes)
 	if err != nil {
-		return errors.Wrap(err, "error parsing token response")
+		observeLoginFailure(c.keycloak, form.Get("grant_type"))
+		return nil, errors.Wrap(err, "error parsing token response")
 	}
 
 	if tokenRes.Error != "" {
 		logClient.Error(errors.New(tokenRes.Error), fmt.Sprintf("error with request: %s", tokenRes.ErrorDescription))
-		return errors.Errorf(tokenRes.ErrorDescription)
+		observeLoginFailure(c.keycloak, form.Get("grant_type"))
+		return nil, errors.Errorf(tokenRes.ErrorDescription)
 	}
 
+	return tokenRes, nil
+}
+
+// setToken stores the tokens of a token response together with their expiry
+func (c *Client) setToken(tokenRes *v1alpha1.TokenResponse, clientID, clientSecret string) {
+	now := time.Now()
 	c.token = tokenRes.AccessToken
-	logClient.Info("login with serviceaccount " + client + " succeeded")
-	return nil
+	c.tokenExpiry = tokenExpiry(now, tokenRes.ExpiresIn)
+	c.refreshToken = tokenRes.RefreshToken
+	c.refreshExpiry = tokenExpiry(now, tokenRes.RefreshExpiresIn)
+	c.tokenClientID = clientID
+	c.tokenClientSecret = clientSecret
+}
+
+// tokenExpiry returns the time at which a token valid for the given number of seconds should be renewed.
+// A zero time is returned if the token does not expire.
+func tokenExpiry(now time.Time, expiresIn int) time.Time {
+	if expiresIn <= 0 {
+		return time.Time{}
+	}
+	validity := time.Duration(expiresIn) * time.Second
+	leeway := tokenExpiryLeeway
+	if leeway > validity/2 {
+		leeway = validity / 2
+	}
+	return now.Add(validity - leeway)
+}
+
+// accessToken returns a valid access token, refreshing it or logging in again if it is about to expire
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.token != "" && (c.tokenExpiry.IsZero() || now.Before(c.tokenExpiry)) {
+		return c.token, nil
+	}
+
+	if c.refreshToken != "" && (c.refreshExpiry.IsZero() || now.Before(c.refreshExpiry)) {
+		err := c.refresh(ctx)
+		if err == nil {
+			return c.token, nil
+		}
+		logClient.Info(fmt.Sprintf("refreshing token failed, logging in again: %s", err))
+	}
+
+	if err := c.authenticate(ctx); err != nil {
+		return "", err
+	}
+	return c.token, nil
+}
+
+// renewToken logs in again after Keycloak rejected the given token, unless another request already did so
+func (c *Client) renewToken(ctx context.Context, rejected string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != rejected {
+		return c.token, nil
+	}
+
+	c.token = ""
+	c.refreshToken = ""
+	if err := c.authenticate(ctx); err != nil {
+		return "", err
+	}
+	return c.token, nil
+}
+
+// do performs an authenticated request against the admin API and records it in the metrics
+func (c *Client) do(req *http.Request, resourceName string) (*http.Response, error) {
+	req, span := c.startRequestSpan(req, resourceName)
+	start := time.Now()
+	res, err := c.doAuthenticated(req)
+	observeRequest(c.keycloak, req.Method, resourceName, res, err, time.Since(start))
+	endRequestSpan(span, res, err)
+	return res, err
+}
+
+// doAuthenticated performs a request with the access token of the client. If Keycloak rejects the access token,
+// e.g. because the session has been revoked, the client logs in again and retries the request once.
+func (c *Client) doAuthenticated(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error authenticating against keycloak")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	res, err := c.requester.Do(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized || c.credentials == nil {
+		return res, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// the body has been consumed and cannot be sent again
+		return res, nil
+	}
+	_ = res.Body.Close()
+
+	logClient.Info(fmt.Sprintf("token rejected by keycloak at %s, logging in again", c.URL))
+	token, err = c.renewToken(ctx, token)
+	if err != nil {
+		return nil, errors.Wrap(err, "error authenticating against keycloak")
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+	return c.requester.Do(retry)
 }
 
 // defaultRequester returns a default client for requesting http endpoints
-func defaultRequester(serverCert []byte) (Requester, error) {
+func defaultRequester(serverCert []byte, timeout time.Duration) (Requester, error) {
 	tlsConfig, err := createTLSConfig(serverCert)
 	if err != nil {
 		return nil, err
@@ -791,7 +1322,7 @@ func defaultRequester(serverCert []byte) (Requester, error) {
 	transport := http.DefaultTransport.(*http.Transport).Clone()
 	transport.TLSClientConfig = tlsConfig
 
-	c := &http.Client{Transport: transport, Timeout: time.Second * 10}
+	c := &http.Client{Transport: transport, Timeout: timeout}
 	return c, nil
 }
 
@@ -813,60 +1344,84 @@ func createTLSConfig(serverCert []byte) (*tls.Config, error) {
 //go:generate moq -out keycloakClient_moq.go . KeycloakInterface
 
 type KeycloakInterface interface {
-	Ping() error
+	Ping(ctx context.Context) error
 
 	Endpoint() string
 
-	CreateRealm(realm *v1alpha1.KeycloakRealm) (string, error)
-	GetRealm(realmName string) (*v1alpha1.KeycloakRealm, error)
-	UpdateRealm(specRealm *v1alpha1.KeycloakRealm) error
-	DeleteRealm(realmName string) error
-	ListRealms() ([]*v1alpha1.KeycloakRealm, error)
-
-	ListRealmRoleClientRoleComposites(realmName, roleID, clientID string) ([]v1alpha1.RoleRepresentation, error)
-	AddRealmRoleComposites(realmName, roleID string, roles *[]v1alpha1.RoleRepresentation) error
-	DeleteRealmRoleComposites(realmName, roleID string, roles *[]v1alpha1.RoleRepresentation) error
-
-	CreateClient(client *v1alpha1.KeycloakAPIClient, realmName string) (string, error)
-	GetClient(clientID, realmName string) (*v1alpha1.KeycloakAPIClient, error)
-	GetClientID(clientID, realmName string) (string, error)
-	GetClientSecret(clientID, realmName string) (string, error)
-	GetClientInstall(clientID, realmName string) ([]byte, error)
-	UpdateClient(specClient *v1alpha1.KeycloakAPIClient, realmName string) error
-	DeleteClient(clientID, realmName string) error
-	ListClients(realmName string) ([]*v1alpha1.KeycloakAPIClient, error)
-	ListClientRoles(clientID, realmName string) ([]v1alpha1.RoleRepresentation, error)
-	ListScopeMappings(clientID, realmName string) (*v1alpha1.MappingsRepresentation, error)
-	ListAvailableClientScopes(realmName string) ([]v1alpha1.KeycloakClientScope, error)
-	ListDefaultClientScopes(clientID, realmName string) ([]v1alpha1.KeycloakClientScope, error)
-	ListOptionalClientScopes(clientID, realmName string) ([]v1alpha1.KeycloakClientScope, error)
-	CreateClientRole(clientID string, role *v1alpha1.RoleRepresentation, realmName string) (string, error)
-	UpdateClientRole(clientID string, role, oldRole *v1alpha1.RoleRepresentation, realmName string) error
-	DeleteClientRole(clientID, role, realmName string) error
-	CreateClientRealmScopeMappings(specClient *v1alpha1.KeycloakAPIClient, mappings *[]v1alpha1.RoleRepresentation, realmName string) error
-	DeleteClientRealmScopeMappings(specClient *v1alpha1.KeycloakAPIClient, mappings *[]v1alpha1.RoleRepresentation, realmName string) error
-	CreateClientClientScopeMappings(specClient *v1alpha1.KeycloakAPIClient, mappings *v1alpha1.ClientMappingsRepresentation, realmName string) error
-	DeleteClientClientScopeMappings(specClient *v1alpha1.KeycloakAPIClient, mappings *v1alpha1.ClientMappingsRepresentation, realmName string) error
-	UpdateClientDefaultClientScope(specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error
-	DeleteClientDefaultClientScope(specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error
-	UpdateClientOptionalClientScope(specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error
-	DeleteClientOptionalClientScope(specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error
-
-	CreateFederatedIdentity(fid v1alpha1.FederatedIdentity, userID string, realmName string) (string, error)
-	RemoveFederatedIdentity(fid v1alpha1.FederatedIdentity, userID string, realmName string) error
-	GetUserFederatedIdentities(userName string, realmName string) ([]v1alpha1.FederatedIdentity, error)
-
-	CreateUserClientRole(role *v1alpha1.KeycloakUserRole, realmName, clientID, userID string) (string, error)
-	ListUserClientRoles(realmName, clientID, userID string) ([]*v1alpha1.KeycloakUserRole, error)
-	ListAvailableUserClientRoles(realmName, clientID, userID string) ([]*v1alpha1.KeycloakUserRole, error)
-	DeleteUserClientRole(role *v1alpha1.KeycloakUserRole, realmName, clientID, userID string) error
-
-	CreateUserRealmRole(role *v1alpha1.KeycloakUserRole, realmName, userID string) (string, error)
-	ListUserRealmRoles(realmName, userID string) ([]*v1alpha1.KeycloakUserRole, error)
-	ListAvailableUserRealmRoles(realmName, userID string) ([]*v1alpha1.KeycloakUserRole, error)
-	DeleteUserRealmRole(role *v1alpha1.KeycloakUserRole, realmName, userID string) error
-
-	GetServiceAccountUser(realmName, clientID string) (*v1alpha1.KeycloakAPIUser, error)
+	GetServerInfo(ctx context.Context) (*v1alpha1.KeycloakServerInfo, error)
+	GetOpenIDConfiguration(ctx context.Context, realmName string) (*v1alpha1.KeycloakOpenIDConfiguration, error)
+
+	CreateRealm(ctx context.Context, realm *v1alpha1.KeycloakRealm) (string, error)
+	GetRealm(ctx context.Context, realmName string) (*v1alpha1.KeycloakRealm, error)
+	UpdateRealm(ctx context.Context, specRealm *v1alpha1.KeycloakRealm) error
+	DeleteRealm(ctx context.Context, realmName string) error
+	ListRealms(ctx context.Context) ([]*v1alpha1.KeycloakRealm, error)
+
+	ListRealmRoleClientRoleComposites(ctx context.Context, realmName, roleID, clientID string) ([]v1alpha1.RoleRepresentation, error)
+	AddRealmRoleComposites(ctx context.Context, realmName, roleID string, roles *[]v1alpha1.RoleRepresentation) error
+	DeleteRealmRoleComposites(ctx context.Context, realmName, roleID string, roles *[]v1alpha1.RoleRepresentation) error
+
+	CreateClient(ctx context.Context, client *v1alpha1.KeycloakAPIClient, realmName string) (string, error)
+	GetClient(ctx context.Context, clientID, realmName string) (*v1alpha1.KeycloakAPIClient, error)
+	GetClientID(ctx context.Context, clientID, realmName string) (string, error)
+	GetClientSecret(ctx context.Context, clientID, realmName string) (string, error)
+	RegenerateClientSecret(ctx context.Context, clientID, realmName string) (string, error)
+	GetClientRotatedSecret(ctx context.Context, clientID, realmName string) (string, error)
+	InvalidateClientRotatedSecret(ctx context.Context, clientID, realmName string) error
+	GetClientInstall(ctx context.Context, clientID, providerID, realmName string) ([]byte, error)
+	UpdateClient(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, realmName string) error
+	DeleteClient(ctx context.Context, clientID, realmName string) error
+	ListClients(ctx context.Context, realmName string) ([]*v1alpha1.KeycloakAPIClient, error)
+	ListClientRoles(ctx context.Context, clientID, realmName string) ([]v1alpha1.RoleRepresentation, error)
+	ListScopeMappings(ctx context.Context, clientID, realmName string) (*v1alpha1.MappingsRepresentation, error)
+	ListAvailableClientScopes(ctx context.Context, realmName string) ([]v1alpha1.KeycloakClientScope, error)
+	ListDefaultClientScopes(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakClientScope, error)
+	ListOptionalClientScopes(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakClientScope, error)
+	CreateClientRole(ctx context.Context, clientID string, role *v1alpha1.RoleRepresentation, realmName string) (string, error)
+	UpdateClientRole(ctx context.Context, clientID string, role, oldRole *v1alpha1.RoleRepresentation, realmName string) error
+	DeleteClientRole(ctx context.Context, clientID, role, realmName string) error
+	ListClientProtocolMappers(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakProtocolMapper, error)
+	CreateClientProtocolMapper(ctx context.Context, clientID string, mapper *v1alpha1.KeycloakProtocolMapper, realmName string) (string, error)
+	UpdateClientProtocolMapper(ctx context.Context, clientID string, mapper *v1alpha1.KeycloakProtocolMapper, realmName string) error
+	DeleteClientProtocolMapper(ctx context.Context, clientID, mapperID, realmName string) error
+	GetAuthorizationSettings(ctx context.Context, clientID, realmName string) (*v1alpha1.KeycloakResourceServer, error)
+	UpdateAuthorizationSettings(ctx context.Context, clientID string, settings *v1alpha1.KeycloakResourceServer, realmName string) error
+	ListAuthorizationScopes(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakScope, error)
+	CreateAuthorizationScope(ctx context.Context, clientID string, scope *v1alpha1.KeycloakScope, realmName string) error
+	UpdateAuthorizationScope(ctx context.Context, clientID string, scope *v1alpha1.KeycloakScope, realmName string) error
+	DeleteAuthorizationScope(ctx context.Context, clientID, scopeID, realmName string) error
+	ListAuthorizationResources(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakResource, error)
+	CreateAuthorizationResource(ctx context.Context, clientID string, resource *v1alpha1.KeycloakResource, realmName string) error
+	UpdateAuthorizationResource(ctx context.Context, clientID string, resource *v1alpha1.KeycloakResource, realmName string) error
+	DeleteAuthorizationResource(ctx context.Context, clientID, resourceID, realmName string) error
+	ListAuthorizationPolicies(ctx context.Context, clientID, realmName string) ([]v1alpha1.KeycloakPolicy, error)
+	CreateAuthorizationPolicy(ctx context.Context, clientID string, policy *v1alpha1.KeycloakPolicy, realmName string) error
+	UpdateAuthorizationPolicy(ctx context.Context, clientID string, policy *v1alpha1.KeycloakPolicy, realmName string) error
+	DeleteAuthorizationPolicy(ctx context.Context, clientID, policyID, realmName string) error
+	CreateClientRealmScopeMappings(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, mappings *[]v1alpha1.RoleRepresentation, realmName string) error
+	DeleteClientRealmScopeMappings(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, mappings *[]v1alpha1.RoleRepresentation, realmName string) error
+	CreateClientClientScopeMappings(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, mappings *v1alpha1.ClientMappingsRepresentation, realmName string) error
+	DeleteClientClientScopeMappings(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, mappings *v1alpha1.ClientMappingsRepresentation, realmName string) error
+	UpdateClientDefaultClientScope(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error
+	DeleteClientDefaultClientScope(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error
+	UpdateClientOptionalClientScope(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error
+	DeleteClientOptionalClientScope(ctx context.Context, specClient *v1alpha1.KeycloakAPIClient, clientScope *v1alpha1.KeycloakClientScope, realmName string) error
+
+	CreateFederatedIdentity(ctx context.Context, fid v1alpha1.FederatedIdentity, userID string, realmName string) (string, error)
+	RemoveFederatedIdentity(ctx context.Context, fid v1alpha1.FederatedIdentity, userID string, realmName string) error
+	GetUserFederatedIdentities(ctx context.Context, userName string, realmName string) ([]v1alpha1.FederatedIdentity, error)
+
+	CreateUserClientRole(ctx context.Context, role *v1alpha1.KeycloakUserRole, realmName, clientID, userID string) (string, error)
+	ListUserClientRoles(ctx context.Context, realmName, clientID, userID string) ([]*v1alpha1.KeycloakUserRole, error)
+	ListAvailableUserClientRoles(ctx context.Context, realmName, clientID, userID string) ([]*v1alpha1.KeycloakUserRole, error)
+	DeleteUserClientRole(ctx context.Context, role *v1alpha1.KeycloakUserRole, realmName, clientID, userID string) error
+
+	CreateUserRealmRole(ctx context.Context, role *v1alpha1.KeycloakUserRole, realmName, userID string) (string, error)
+	ListUserRealmRoles(ctx context.Context, realmName, userID string) ([]*v1alpha1.KeycloakUserRole, error)
+	ListAvailableUserRealmRoles(ctx context.Context, realmName, userID string) ([]*v1alpha1.KeycloakUserRole, error)
+	DeleteUserRealmRole(ctx context.Context, role *v1alpha1.KeycloakUserRole, realmName, userID string) error
+
+	GetServiceAccountUser(ctx context.Context, realmName, clientID string) (*v1alpha1.KeycloakAPIUser, error)
 }
 
 // check if Client implements KeycloakInterface
@@ -876,20 +1431,22 @@ var _ KeycloakInterface = &Client{}
 
 // KeycloakClientFactory interface
 type KeycloakClientFactory interface {
-	AuthenticatedClient(kc v1alpha1.Keycloak) (KeycloakInterface, error)
+	AuthenticatedClient(ctx context.Context, kc v1alpha1.Keycloak, insecureSsl bool) (KeycloakInterface, error)
 }
 
 type LocalConfigKeycloakFactory struct {
+	// Client reads the credential and certificate secrets of a Keycloak. If not set, the secrets are read with a
+	// client created from the local kubeconfig.
+	Client client.Reader
 }
 
-// AuthenticatedClient returns an authenticated client for requesting endpoints from the Keycloak api
-func (i *LocalConfigKeycloakFactory) AuthenticatedClient(kc v1alpha1.Keycloak, insecureSsl bool) (KeycloakInterface, error) {
-	config, err := config2.GetConfig()
-	if err != nil {
-		return nil, err
-	}
+// check if LocalConfigKeycloakFactory implements KeycloakClientFactory
+var _ KeycloakClientFactory = &LocalConfigKeycloakFactory{}
 
-	secretClient, err := kubernetes.NewForConfig(config)
+// AuthenticatedClient returns an authenticated client for requesting endpoints from the Keycloak api.
+// Clients are pooled per Keycloak CR and reused as long as neither the CR nor its secrets change.
+func (i *LocalConfigKeycloakFactory) AuthenticatedClient(ctx context.Context, kc v1alpha1.Keycloak, insecureSsl bool) (KeycloakInterface, error) {
+	secretClient, err := i.secretClient()
 	if err != nil {
 		return nil, err
 	}
@@ -901,58 +1458,98 @@ func (i *LocalConfigKeycloakFactory) AuthenticatedClient(kc v1alpha1.Keycloak, i
 		credentialSecret = kc.Status.CredentialSecret
 	}
 
-	adminCreds, err := secretClient.CoreV1().Secrets(kc.Namespace).Get(context.TODO(), credentialSecret, v12.GetOptions{})
+	adminCreds := &v1.Secret{}
+	err = secretClient.Get(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: credentialSecret}, adminCreds)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get the admin credentials")
 	}
-	user := string(adminCreds.Data[model.AdminUsernameProperty])
-	pass := string(adminCreds.Data[model.AdminPasswordProperty])
-	clientName := string(adminCreds.Data[model.ClientName])
-	clientCredential := string(adminCreds.Data[model.ClientPassword])
 
 	var serverCert []byte = nil
+	var serverCertSecret *v1.Secret
 	if !insecureSsl {
-		serverCert, err = getKCServerCert(secretClient, kc)
+		serverCertSecret, err = getKCServerCert(ctx, secretClient, kc)
 		if err != nil {
 			return nil, err
 		}
+		if serverCertSecret != nil {
+			serverCert = serverCertSecret.Data["tls.crt"]
+		}
+	}
+
+	key := types.NamespacedName{Namespace: kc.Namespace, Name: kc.Name}
+	fingerprint := clientFingerprint(kc, adminCreds, serverCertSecret, insecureSsl)
+	if client := authenticatedClients.get(key, fingerprint); client != nil {
+		return client, nil
 	}
 
-	requester, err := defaultRequester(serverCert)
+	timeout := DefaultRequestTimeout
+	if kc.Spec.AdminAPI.Timeout != nil {
+		timeout = kc.Spec.AdminAPI.Timeout.Duration
+	}
+	httpRequester, err := defaultRequester(serverCert, timeout)
 	if err != nil {
 		return nil, err
 	}
+	requester := newRetryingRequester(httpRequester, kc.Spec.AdminAPI)
 
-	kcURL, err := getKeycloakURL(kc, requester)
+	kcURL, err := getKeycloakURL(ctx, kc, requester)
 	if err != nil {
 		return nil, err
 	}
 
+	adminRealm := kc.Spec.AdminRealm
+	if adminRealm == "" {
+		adminRealm = DefaultAdminRealm
+	}
+
+	contextPath := kc.Spec.External.ContextPath
+	if contextPath == "" {
+		contextPath = detectContextPath(ctx, kcURL, adminRealm, requester)
+	}
+
 	client := &Client{
-		URL:       kcURL,
-		requester: requester,
+		keycloak:    key.String(),
+		URL:         kcURL,
+		contextPath: NormalizeContextPath(contextPath),
+		adminRealm:  adminRealm,
+		tokenURL:    kc.Spec.TokenURL,
+		requester:   requester,
+		credentials: &credentials{
+			user:         string(adminCreds.Data[model.AdminUsernameProperty]),
+			password:     string(adminCreds.Data[model.AdminPasswordProperty]),
+			clientName:   string(adminCreds.Data[model.ClientName]),
+			clientSecret: string(adminCreds.Data[model.ClientPassword]),
+		},
 	}
 
-	if clientName != "" && clientCredential != "" {
-		err := client.login(clientName, clientCredential)
-		if err != nil {
-			if err := client.login_admin(user, pass); err != nil {
-				return nil, err
-			}
-		}
-	} else {
-		if err := client.login_admin(user, pass); err != nil {
-			return nil, err
-		}
+	if err := client.authenticate(ctx); err != nil {
+		return nil, err
 	}
+
+	authenticatedClients.put(key, fingerprint, client)
 	return client, nil
 }
 
-func getKCServerCert(secretClient *kubernetes.Clientset, kc v1alpha1.Keycloak) ([]byte, error) {
-	sslCertsSecret, err := secretClient.CoreV1().Secrets(kc.Namespace).Get(context.TODO(), model.ServingCertSecretName, v12.GetOptions{})
+// secretClient returns the client the secrets of a Keycloak are read with
+func (i *LocalConfigKeycloakFactory) secretClient() (client.Reader, error) {
+	if i.Client != nil {
+		return i.Client, nil
+	}
+
+	config, err := config2.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(config, client.Options{})
+}
+
+func getKCServerCert(ctx context.Context, secretClient client.Reader, kc v1alpha1.Keycloak) (*v1.Secret, error) {
+	sslCertsSecret := &v1.Secret{}
+	err := secretClient.Get(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: model.ServingCertSecretName}, sslCertsSecret)
 	switch {
 	case err == nil:
-		return sslCertsSecret.Data["tls.crt"], nil
+		return sslCertsSecret, nil
 	case k8sErrors.IsNotFound(err):
 		return nil, nil
 	default:
@@ -963,12 +1560,12 @@ func getKCServerCert(secretClient *kubernetes.Clientset, kc v1alpha1.Keycloak) (
 // At normal conditions, Keycloak should be accessible via the internalURL. However, there are some corner cases (like
 // operator running locally during development or services being inaccessible due to network policies) which requires
 // use of externalURL.
-func getKeycloakURL(kc v1alpha1.Keycloak, requester Requester) (string, error) {
+func getKeycloakURL(ctx context.Context, kc v1alpha1.Keycloak, requester Requester) (string, error) {
 	var kcURL string
 	var err error
 
 	if kcURL == "" && kc.Status.ExternalURL != "" {
-		kcURL, err = validateKeycloakURL(kc.Status.ExternalURL, requester)
+		kcURL, err = validateKeycloakURL(ctx, kc.Status.ExternalURL, requester)
 		if err != nil {
 			return "", err
 		}
@@ -983,8 +1580,8 @@ func getKeycloakURL(kc v1alpha1.Keycloak, requester Requester) (string, error) {
 	return kcURL, nil
 }
 
-func validateKeycloakURL(url string, requester Requester) (string, error) {
-	req, err := http.NewRequest(
+func validateKeycloakURL(ctx context.Context, url string, requester Requester) (string, error) {
+	req, err := http.NewRequestWithContext(ctx,
 		"GET",
 		url,
 		nil,
@@ -1001,3 +1598,43 @@ func validateKeycloakURL(url string, requester Requester) (string, error) {
 	_ = res.Body.Close()
 	return url, nil
 }
+
+// NormalizeContextPath turns a configured context path into the form used when building URLs,
+// i.e. with a leading but without a trailing slash. The root context path "/" results in an empty string.
+func NormalizeContextPath(contextPath string) string {
+	contextPath = strings.Trim(contextPath, "/")
+	if contextPath == "" {
+		return ""
+	}
+	return "/" + contextPath
+}
+
+// Keycloak 17+ (Quarkus) serves its endpoints from "/" while older versions use "/auth". The context path is
+// detected by probing the OpenID configuration of the admin realm. If neither candidate responds, the legacy
+// context path is assumed.
+func detectContextPath(ctx context.Context, url, realm string, requester Requester) string {
+	for _, contextPath := range []string{LegacyContextPath, ""} {
+		req, err := http.NewRequestWithContext(ctx,
+			"GET",
+			fmt.Sprintf("%s%s/%s", url, contextPath, fmt.Sprintf(wellKnownPath, realm)),
+			nil,
+		)
+		if err != nil {
+			continue
+		}
+
+		res, err := requester.Do(req)
+		if err != nil {
+			log.Info(fmt.Sprintf("error probing context path '%s' of %s : %s", contextPath, url, err))
+			continue
+		}
+		_ = res.Body.Close()
+		if res.StatusCode == 200 {
+			log.Info(fmt.Sprintf("detected context path '%s' for keycloak url %s", contextPath, url))
+			return contextPath
+		}
+	}
+
+	log.Info(fmt.Sprintf("unable to detect context path for keycloak url %s, assuming '%s'", url, LegacyContextPath))
+	return LegacyContextPath
+}
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// authenticatedClients holds one authenticated client per Keycloak CR. Sharing the clients across reconciles
+// avoids a new transport and a new login against the token endpoint for every reconciled resource.
+var authenticatedClients = newClientPool()
+
+type pooledClient struct {
+	fingerprint string
+	client      *Client
+}
+
+type clientPool struct {
+	mu      sync.Mutex
+	clients map[types.NamespacedName]pooledClient
+}
+
+func newClientPool() *clientPool {
+	return &clientPool{clients: map[types.NamespacedName]pooledClient{}}
+}
+
+// get returns the pooled client of a Keycloak CR if it has been created for the same fingerprint
+func (p *clientPool) get(key types.NamespacedName, fingerprint string) *Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pooled, ok := p.clients[key]
+	if !ok {
+		return nil
+	}
+	if pooled.fingerprint != fingerprint {
+		logClient.Info("keycloak " + key.String() + " or its secrets changed, discarding pooled client")
+		delete(p.clients, key)
+		return nil
+	}
+	return pooled.client
+}
+
+func (p *clientPool) put(key types.NamespacedName, fingerprint string, client *Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clients[key] = pooledClient{fingerprint: fingerprint, client: client}
+}
+
+func (p *clientPool) evict(key types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, key)
+}
+
+// EvictAuthenticatedClient discards the pooled client of a Keycloak CR, e.g. after the CR has been deleted
+func EvictAuthenticatedClient(key types.NamespacedName) {
+	authenticatedClients.evict(key)
+}
+
+// clientFingerprint identifies everything a pooled client has been created from. A client is only reused
+// as long as the Keycloak CR, the credential secret and the server certificate remain unchanged.
+func clientFingerprint(kc v1alpha1.Keycloak, credentialSecret, serverCertSecret *v1.Secret, insecureSsl bool) string {
+	serverCertVersion := ""
+	if serverCertSecret != nil {
+		serverCertVersion = string(serverCertSecret.UID) + ":" + serverCertSecret.ResourceVersion
+	}
+	return strings.Join([]string{
+		string(kc.UID),
+		strconv.FormatInt(kc.Generation, 10),
+		kc.Status.ExternalURL,
+		string(credentialSecret.UID) + ":" + credentialSecret.ResourceVersion,
+		serverCertVersion,
+		strconv.FormatBool(insecureSsl),
+	}, "|")
+}
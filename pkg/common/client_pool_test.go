@@ -0,0 +1,72 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestClientPool_reusesClientForSameFingerprint(t *testing.T) {
+	// given
+	pool := newClientPool()
+	key := types.NamespacedName{Namespace: "ns", Name: "keycloak"}
+	client := &Client{URL: "http://keycloak"}
+	pool.put(key, "fingerprint", client)
+
+	// when
+	pooled := pool.get(key, "fingerprint")
+
+	// then
+	assert.Same(t, client, pooled)
+}
+
+func TestClientPool_discardsClientOnChange(t *testing.T) {
+	// given
+	pool := newClientPool()
+	key := types.NamespacedName{Namespace: "ns", Name: "keycloak"}
+	pool.put(key, "fingerprint", &Client{URL: "http://keycloak"})
+
+	// when
+	changed := pool.get(key, "other")
+	afterChange := pool.get(key, "fingerprint")
+
+	// then
+	assert.Nil(t, changed)
+	assert.Nil(t, afterChange)
+}
+
+func TestClientPool_evict(t *testing.T) {
+	// given
+	pool := newClientPool()
+	key := types.NamespacedName{Namespace: "ns", Name: "keycloak"}
+	pool.put(key, "fingerprint", &Client{URL: "http://keycloak"})
+
+	// when
+	pool.evict(key)
+
+	// then
+	assert.Nil(t, pool.get(key, "fingerprint"))
+}
+
+func TestClientPool_clientFingerprint(t *testing.T) {
+	// given
+	kc := v1alpha1.Keycloak{ObjectMeta: v12.ObjectMeta{UID: "uid", Generation: 1}}
+	secret := &v1.Secret{ObjectMeta: v12.ObjectMeta{UID: "secret", ResourceVersion: "1"}}
+	fingerprint := clientFingerprint(kc, secret, nil, false)
+
+	// when
+	changedSecret := secret.DeepCopy()
+	changedSecret.ResourceVersion = "2"
+	changedKeycloak := kc.DeepCopy()
+	changedKeycloak.Generation = 2
+
+	// then
+	assert.Equal(t, fingerprint, clientFingerprint(kc, secret.DeepCopy(), nil, false))
+	assert.NotEqual(t, fingerprint, clientFingerprint(kc, changedSecret, nil, false))
+	assert.NotEqual(t, fingerprint, clientFingerprint(*changedKeycloak, secret, nil, false))
+	assert.NotEqual(t, fingerprint, clientFingerprint(kc, secret, nil, true))
+}
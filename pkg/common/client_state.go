@@ -2,29 +2,53 @@ package common
 
 import (
 	"context"
+	"crypto/x509"
+	"slices"
 
 	kc "github.com/movewp3/keycloakclient-controller/api/v1alpha1"
 	"github.com/movewp3/keycloakclient-controller/pkg/model"
+	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ClientSecretRotationFeature is the Keycloak feature which keeps the previous secret of a client valid after a
+// rotation
+const ClientSecretRotationFeature = "CLIENT_SECRET_ROTATION"
+
 type ClientState struct {
-	Client                  *kc.KeycloakAPIClient
-	ClientSecret            *v1.Secret // keycloak-client-secret-<custom resource name>
-	Context                 context.Context
-	Realm                   *kc.KeycloakRealm
-	Roles                   []kc.RoleRepresentation
-	DefaultRoleID           string
-	DefaultRoles            []kc.RoleRepresentation
-	ScopeMappings           *kc.MappingsRepresentation
-	AvailableClientScopes   []kc.KeycloakClientScope
-	DefaultClientScopes     []kc.KeycloakClientScope
-	OptionalClientScopes    []kc.KeycloakClientScope
-	DeprecatedClientSecret  *v1.Secret // keycloak-client-secret-<clientID>
-	Keycloak                kc.Keycloak
-	ServiceAccountUserState *UserState
+	Client                    *kc.KeycloakAPIClient
+	ClientSecret              *v1.Secret           // keycloak-client-secret-<custom resource name>[-<target>]
+	RotatedClientSecret       string               // previous secret kept valid by Keycloak after a rotation
+	KeyPair                   *model.ClientKeyPair // key pair of a client with privateKeyJWT authentication
+	ClientCertificate         *x509.Certificate    // certificate of a client with x509 authentication
+	SAMLSigningCertificate    *x509.Certificate    // certificate the signatures of a SAML client are verified with
+	SAMLEncryptionCertificate *x509.Certificate    // certificate the assertions of a SAML client are encrypted for
+	OpenIDConfiguration       *kc.KeycloakOpenIDConfiguration
+	InstallationSecrets       map[string]*v1.Secret    // Secrets the installations are published in by name
+	InstallationConfigMaps    map[string]*v1.ConfigMap // ConfigMaps the installations are published in by name
+	SecretTargets             []v1.Secret              // secret targets of the client, tracked by labels
+	Context                   context.Context
+	Realm                     *kc.KeycloakRealm
+	Roles                     []kc.RoleRepresentation
+	ProtocolMappers           []kc.KeycloakProtocolMapper
+	AuthorizationSettings     *kc.KeycloakResourceServer
+	DefaultRoleID             string
+	DefaultRoles              []kc.RoleRepresentation
+	ScopeMappings             *kc.MappingsRepresentation
+	AvailableClientScopes     []kc.KeycloakClientScope
+	DefaultClientScopes       []kc.KeycloakClientScope
+	OptionalClientScopes      []kc.KeycloakClientScope
+	DeprecatedClientSecret    *v1.Secret // keycloak-client-secret-<clientID>
+	Keycloak                  kc.Keycloak
+	ServiceAccountUserState   *UserState
+	// Target distinguishes the client secrets of a client reconciled with several realms or Keycloaks, empty for a
+	// single realm and Keycloak
+	Target string
+	// SecondaryTarget is true for all but the first realm and Keycloak of the client. The secret targets and
+	// installations are published for the first one only.
+	SecondaryTarget bool
 }
 
 func NewClientState(context context.Context, realm *kc.KeycloakRealm, keycloak kc.Keycloak) *ClientState {
@@ -36,22 +60,76 @@ func NewClientState(context context.Context, realm *kc.KeycloakRealm, keycloak k
 }
 
 func (i *ClientState) Read(context context.Context, cr *kc.KeycloakClient, realmClient KeycloakInterface, controllerClient client.Client) error {
-	if cr.Spec.Client.ID == "" {
-		return nil
+	if cr.Spec.SecretTemplate != nil {
+		// the endpoints of the realm are needed to render the secret of new clients as well
+		config, err := realmClient.GetOpenIDConfiguration(context, i.Realm.Spec.Realm.Realm)
+		if err != nil {
+			return err
+		}
+		i.OpenIDConfiguration = config
+	}
+
+	if cr.Spec.SecretRef != nil {
+		// the referenced secret wins over the secret in the CR and Keycloak, new clients are created with it as well
+		err := i.readSecretRef(context, cr, controllerClient)
+		if err != nil {
+			return err
+		}
 	}
 
-	client, err := realmClient.GetClient(cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+	if model.UsesX509(cr) && cr.DeletionTimestamp == nil {
+		// new clients are created with the certificate as well
+		err := i.readClientCertificate(context, cr, controllerClient)
+		if err != nil {
+			return err
+		}
+	}
 
+	if model.IsSAML(cr) && cr.DeletionTimestamp == nil {
+		err := i.readSAMLCertificates(context, cr, controllerClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the secret targets are read for new and deleted clients as well, so that stale targets are cleaned up
+	err := i.readSecretTargets(context, cr, controllerClient)
+	if err != nil {
+		return err
+	}
+
+	client, err := i.readClient(context, cr, realmClient)
 	if err != nil {
 		return err
 	}
 
+	// the client secrets are read for clients deleted in Keycloak as well, so that they are recreated with the secret
+	// the workloads hold and the existing Secret is updated instead of created
+	err = i.readClientSecret(context, cr, client, controllerClient)
+	if err != nil {
+		return err
+	}
+
+	if cr.Name != cr.Spec.Client.ClientID {
+		// only read when these fields aren't equal to avoid unwanted cyclical create / delete of client secret
+		err = i.readDepcreatedClientSecret(context, cr, client, controllerClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	if client == nil {
+		return nil
+	}
+
 	i.Client = client
 
 	// CR could have updated with new secret, so set saved secret to Spec only when empty
 	// Otherwise let reconcile loop to update secret with desired secret in CR
-	if cr.Spec.Client.Secret == "" {
-		clientSecret, err := realmClient.GetClientSecret(cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+	// With secret rotation the secret in Keycloak always wins, as the CR does not follow the rotations
+	// SAML clients have no secret
+	if !model.IsSAML(cr) && (cr.Spec.Client.Secret == "" || cr.Spec.SecretRotation != nil) {
+		clientSecret, err := realmClient.GetClientSecret(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
 		if err != nil {
 			return err
 		}
@@ -59,51 +137,62 @@ func (i *ClientState) Read(context context.Context, cr *kc.KeycloakClient, realm
 		cr.Spec.Client.Secret = clientSecret
 	}
 
-	err = i.readClientSecret(context, cr, i.Client, controllerClient)
+	if cr.Spec.SecretRotation != nil && slices.Contains(i.Keycloak.Status.Features, ClientSecretRotationFeature) {
+		i.RotatedClientSecret, err = realmClient.GetClientRotatedSecret(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+		if err != nil {
+			return err
+		}
+	}
+
+	i.Roles, err = realmClient.ListClientRoles(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
 	if err != nil {
 		return err
 	}
+	cr.Status.RoleIDs = map[string]string{}
+	for _, role := range i.Roles {
+		cr.Status.RoleIDs[role.Name] = role.ID
+	}
 
-	if cr.Name != cr.Spec.Client.ClientID {
-		// only read when these fields aren't equal to avoid unwanted cyclical create / delete of client secret
-		err = i.readDepcreatedClientSecret(context, cr, i.Client, controllerClient)
+	i.ProtocolMappers, err = realmClient.ListClientProtocolMappers(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+	if err != nil {
+		return err
+	}
+
+	if i.Client.AuthorizationServicesEnabled {
+		err = i.readAuthorizationSettings(context, cr, realmClient)
 		if err != nil {
 			return err
 		}
 	}
 
-	if i.Client == nil {
-		return nil
-	}
-
-	i.Roles, err = realmClient.ListClientRoles(cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+	i.ScopeMappings, err = realmClient.ListScopeMappings(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
 	if err != nil {
 		return err
 	}
 
-	i.ScopeMappings, err = realmClient.ListScopeMappings(cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+	err = i.readClientScopes(context, cr, realmClient)
 	if err != nil {
 		return err
 	}
 
-	err = i.readClientScopes(cr, realmClient)
+	err = i.readDefaultRoles(context, cr, realmClient)
 	if err != nil {
 		return err
 	}
 
-	err = i.readDefaultRoles(cr, realmClient)
+	err = i.readInstallations(context, cr, controllerClient)
 	if err != nil {
 		return err
 	}
 
 	if i.Client.ServiceAccountsEnabled {
-		user, err := realmClient.GetServiceAccountUser(i.Realm.Spec.Realm.Realm, cr.Spec.Client.ID)
+		user, err := realmClient.GetServiceAccountUser(context, i.Realm.Spec.Realm.Realm, cr.Spec.Client.ID)
 		if err != nil {
 			return err
 		}
 
 		i.ServiceAccountUserState = NewUserState(i.Keycloak)
-		err = i.ServiceAccountUserState.ReadWithExistingAPIUser(realmClient, controllerClient, user, *i.Realm)
+		err = i.ServiceAccountUserState.ReadWithExistingAPIUser(context, realmClient, controllerClient, user, *i.Realm)
 		if err != nil {
 			return err
 		}
@@ -112,30 +201,149 @@ func (i *ClientState) Read(context context.Context, cr *kc.KeycloakClient, realm
 	return nil
 }
 
-func (i *ClientState) readClientScopes(cr *kc.KeycloakClient, realmClient KeycloakInterface) (err error) {
+func (i *ClientState) readClientScopes(context context.Context, cr *kc.KeycloakClient, realmClient KeycloakInterface) (err error) {
 	// It is not strictly a property of the client but rather of the realm.
 	// However could not figure out a better way to convey it to populate default and optional
 	// client scopes which requires client scope IDs.
-	i.AvailableClientScopes, err = realmClient.ListAvailableClientScopes(i.Realm.Spec.Realm.Realm)
+	i.AvailableClientScopes, err = realmClient.ListAvailableClientScopes(context, i.Realm.Spec.Realm.Realm)
+	if err != nil {
+		return err
+	}
+
+	i.DefaultClientScopes, err = realmClient.ListDefaultClientScopes(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+	if err != nil {
+		return err
+	}
+
+	i.OptionalClientScopes, err = realmClient.ListOptionalClientScopes(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// readAuthorizationSettings reads the settings of the resource server of the client together with its scopes,
+// resources, policies and permissions
+func (i *ClientState) readAuthorizationSettings(context context.Context, cr *kc.KeycloakClient, realmClient KeycloakInterface) (err error) {
+	settings, err := realmClient.GetAuthorizationSettings(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+	if err != nil || settings == nil {
+		return err
+	}
+
+	settings.Scopes, err = realmClient.ListAuthorizationScopes(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
 	if err != nil {
 		return err
 	}
 
-	i.DefaultClientScopes, err = realmClient.ListDefaultClientScopes(cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+	settings.Resources, err = realmClient.ListAuthorizationResources(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
 	if err != nil {
 		return err
 	}
 
-	i.OptionalClientScopes, err = realmClient.ListOptionalClientScopes(cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
+	settings.Policies, err = realmClient.ListAuthorizationPolicies(context, cr.Spec.Client.ID, i.Realm.Spec.Realm.Realm)
 	if err != nil {
 		return err
 	}
+
+	i.AuthorizationSettings = settings
+	return nil
+}
+
+// readClient reads the client from Keycloak by the ID in the status, if it belongs to the realm, or by the ID in the
+// spec written by earlier versions of the controller, and falls back to a lookup by the clientId if the client with
+// the ID does not exist. The ID is bound to the realm in the status and kept in spec.client.id for the rest of the
+// reconcile, but the spec is never written. Returns nil if the client does not exist.
+func (i *ClientState) readClient(context context.Context, cr *kc.KeycloakClient, realmClient KeycloakInterface) (*kc.KeycloakAPIClient, error) {
+	realm := i.Realm.Spec.Realm.Realm
+	id := cr.Spec.Client.ID
+	if cr.Status.ID != "" && cr.Status.Realm == realm {
+		id = cr.Status.ID
+	}
+
+	var client *kc.KeycloakAPIClient
+	var err error
+	if id != "" {
+		client, err = realmClient.GetClient(context, id, realm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if client == nil {
+		id, err = realmClient.GetClientID(context, cr.Spec.Client.ClientID, realm)
+		if err != nil {
+			return nil, err
+		}
+		if id != "" {
+			client, err = realmClient.GetClient(context, id, realm)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if client == nil {
+		cr.Spec.Client.ID = ""
+		cr.Status.ID = ""
+		cr.Status.Realm = ""
+		cr.Status.RoleIDs = nil
+		return nil, nil
+	}
+	cr.Spec.Client.ID = client.ID
+	cr.Status.ID = client.ID
+	cr.Status.Realm = realm
+	return client, nil
+}
+
+// readInstallations reads the Secrets and ConfigMaps the installations of the client are published in. The documents
+// themselves are fetched when they are published, so that they reflect the changes of the same reconcile.
+func (i *ClientState) readInstallations(context context.Context, cr *kc.KeycloakClient, controllerClient client.Client) error {
+	for _, installation := range model.ClientInstallations(cr) {
+		name := model.ClientInstallationObjectName(cr, installation)
+		key := model.ClientInstallationSelector(cr, name)
+		if model.ClientInstallationKind(cr, installation) == model.ClientInstallationKindConfigMap {
+			if _, ok := i.InstallationConfigMaps[name]; ok {
+				continue
+			}
+			configMap := &v1.ConfigMap{}
+			err := controllerClient.Get(context, key, configMap)
+			if err != nil && !apiErrors.IsNotFound(err) {
+				return err
+			}
+			if i.InstallationConfigMaps == nil {
+				i.InstallationConfigMaps = map[string]*v1.ConfigMap{}
+			}
+			if err == nil {
+				i.InstallationConfigMaps[name] = configMap
+				cr.UpdateStatusSecondaryResources(model.ClientInstallationKindConfigMap, name)
+			} else {
+				i.InstallationConfigMaps[name] = nil
+			}
+		} else {
+			if _, ok := i.InstallationSecrets[name]; ok {
+				continue
+			}
+			secret := &v1.Secret{}
+			err := controllerClient.Get(context, key, secret)
+			if err != nil && !apiErrors.IsNotFound(err) {
+				return err
+			}
+			if i.InstallationSecrets == nil {
+				i.InstallationSecrets = map[string]*v1.Secret{}
+			}
+			if err == nil {
+				i.InstallationSecrets[name] = secret
+				cr.UpdateStatusSecondaryResources(model.ClientInstallationKindSecret, name)
+			} else {
+				i.InstallationSecrets[name] = nil
+			}
+		}
+	}
 	return nil
 }
 
 func (i *ClientState) readClientSecret(context context.Context, cr *kc.KeycloakClient, clientSpec *kc.KeycloakAPIClient, controllerClient client.Client) error {
-	key := model.ClientSecretSelector(cr)
-	secret := model.ClientSecret(cr)
+	key := model.ClientTargetSecretSelector(cr, i.Target)
+	secret := model.ClientTargetSecret(cr, i.Target)
 
 	err := controllerClient.Get(context, key, secret)
 	if err != nil {
@@ -145,19 +353,90 @@ func (i *ClientState) readClientSecret(context context.Context, cr *kc.KeycloakC
 	} else {
 		i.ClientSecret = secret.DeepCopy()
 		cr.UpdateStatusSecondaryResources(i.ClientSecret.Kind, i.ClientSecret.Name)
+		if model.UsesPrivateKeyJWT(cr) {
+			i.KeyPair, err = model.ClientKeyPairFromSecret(i.ClientSecret)
+			return err
+		}
+	}
+	return nil
+}
+
+// readSecretRef sets the secret of the client in the CR to the value of the referenced Secret. Like the other values
+// the controller sets in the spec, it is kept in memory for the reconcile only.
+func (i *ClientState) readSecretRef(context context.Context, cr *kc.KeycloakClient, controllerClient client.Client) error {
+	if cr.Spec.SecretRotation != nil {
+		return errors.Errorf("secretRef cannot be combined with secretRotation")
+	}
+
+	key := model.ClientSecretRefSelector(cr)
+	secret := &v1.Secret{}
+	err := controllerClient.Get(context, key, secret)
+	if err != nil {
+		if apiErrors.IsNotFound(err) {
+			return errors.Errorf("secret %s/%s referenced by client %s not found", key.Namespace, key.Name, cr.Spec.Client.ClientID)
+		}
+		return err
+	}
+
+	cr.Spec.Client.Secret, err = model.ClientSecretFromRef(cr, secret)
+	return err
+}
+
+// readClientCertificate reads the client certificate from the TLS Secret referenced by the CR
+func (i *ClientState) readClientCertificate(context context.Context, cr *kc.KeycloakClient, controllerClient client.Client) error {
+	var err error
+	i.ClientCertificate, err = readCertificate(context, cr, model.ClientCertificateSelector(cr), controllerClient)
+	return err
+}
+
+// readSAMLCertificates reads the signing and encryption certificates of a SAML client from the referenced TLS Secrets
+func (i *ClientState) readSAMLCertificates(context context.Context, cr *kc.KeycloakClient, controllerClient client.Client) error {
+	var err error
+	signing, encryption := model.SAMLCertificateSelectors(cr)
+	if signing.Name != "" {
+		i.SAMLSigningCertificate, err = readCertificate(context, cr, signing, controllerClient)
+		if err != nil {
+			return err
+		}
+	}
+	if encryption.Name != "" {
+		i.SAMLEncryptionCertificate, err = readCertificate(context, cr, encryption, controllerClient)
+	}
+	return err
+}
+
+func readCertificate(context context.Context, cr *kc.KeycloakClient, key client.ObjectKey, controllerClient client.Client) (*x509.Certificate, error) {
+	secret := &v1.Secret{}
+	err := controllerClient.Get(context, key, secret)
+	if err != nil {
+		if apiErrors.IsNotFound(err) {
+			return nil, errors.Errorf("certificate secret %s/%s of client %s not found", key.Namespace, key.Name, cr.Spec.Client.ClientID)
+		}
+		return nil, err
+	}
+	return model.ClientCertificateFromSecret(secret)
+}
+
+// readSecretTargets lists the Secrets in all namespaces labeled as secret target of the client
+func (i *ClientState) readSecretTargets(context context.Context, cr *kc.KeycloakClient, controllerClient client.Client) error {
+	var list v1.SecretList
+	err := controllerClient.List(context, &list, client.MatchingLabels(model.ClientSecretTargetLabels(cr)))
+	if err != nil {
+		return err
 	}
+	i.SecretTargets = list.Items
 	return nil
 }
 
-func (i *ClientState) readDefaultRoles(cr *kc.KeycloakClient, realmClient KeycloakInterface) error {
+func (i *ClientState) readDefaultRoles(context context.Context, cr *kc.KeycloakClient, realmClient KeycloakInterface) error {
 	// we can't use state.Realm as it is the CR, not actual Realm state, and is missing defaultRole
-	realm, err := realmClient.GetRealm(i.Realm.Spec.Realm.Realm)
+	realm, err := realmClient.GetRealm(context, i.Realm.Spec.Realm.Realm)
 	if err != nil {
 		return err
 	}
 
 	i.DefaultRoleID = realm.Spec.Realm.DefaultRole.ID
-	i.DefaultRoles, err = realmClient.ListRealmRoleClientRoleComposites(i.Realm.Spec.Realm.Realm, i.DefaultRoleID, cr.Spec.Client.ID)
+	i.DefaultRoles, err = realmClient.ListRealmRoleClientRoleComposites(context, i.Realm.Spec.Realm.Realm, i.DefaultRoleID, cr.Spec.Client.ID)
 	return err
 }
 
@@ -0,0 +1,301 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClientState_ReadProtocolMappers(t *testing.T) {
+	// given
+	realm := getDummyRealm()
+	mappers := []v1alpha1.KeycloakProtocolMapper{{ID: "mapperID", Name: "audience", ProtocolMapper: "oidc-audience-mapper"}}
+	keycloakClient := &KeycloakInterfaceMock{
+		GetClientFunc: func(ctx context.Context, clientID string, realmName string) (*v1alpha1.KeycloakAPIClient, error) {
+			return &v1alpha1.KeycloakAPIClient{ID: clientID, ClientID: "app"}, nil
+		},
+		ListClientRolesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.RoleRepresentation, error) {
+			return nil, nil
+		},
+		ListClientProtocolMappersFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakProtocolMapper, error) {
+			return mappers, nil
+		},
+		ListScopeMappingsFunc: func(ctx context.Context, clientID string, realmName string) (*v1alpha1.MappingsRepresentation, error) {
+			return &v1alpha1.MappingsRepresentation{}, nil
+		},
+		ListAvailableClientScopesFunc: func(ctx context.Context, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+			return nil, nil
+		},
+		ListDefaultClientScopesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+			return nil, nil
+		},
+		ListOptionalClientScopesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+			return nil, nil
+		},
+		GetRealmFunc: func(ctx context.Context, realmName string) (*v1alpha1.KeycloakRealm, error) {
+			return &v1alpha1.KeycloakRealm{Spec: v1alpha1.KeycloakRealmSpec{Realm: &v1alpha1.KeycloakAPIRealm{DefaultRole: &v1alpha1.RoleRepresentation{ID: "defaultRoleID"}}}}, nil
+		},
+		ListRealmRoleClientRoleCompositesFunc: func(ctx context.Context, realmName string, roleID string, clientID string) ([]v1alpha1.RoleRepresentation, error) {
+			return nil, nil
+		},
+	}
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{ID: "clientID", ClientID: "app", Secret: "secret"},
+		},
+	}
+	state := NewClientState(context.TODO(), realm, v1alpha1.Keycloak{})
+
+	// when
+	err := state.Read(context.TODO(), cr, keycloakClient, fake.NewClientBuilder().Build())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, mappers, state.ProtocolMappers)
+	assert.Len(t, keycloakClient.ListClientProtocolMappersCalls(), 1)
+	assert.Equal(t, "clientID", keycloakClient.ListClientProtocolMappersCalls()[0].ClientID)
+	assert.Equal(t, "dummy", keycloakClient.ListClientProtocolMappersCalls()[0].RealmName)
+}
+
+func TestClientState_ReadAuthorizationSettings(t *testing.T) {
+	// given
+	realm := getDummyRealm()
+	keycloakClient := &KeycloakInterfaceMock{
+		GetClientFunc: func(ctx context.Context, clientID string, realmName string) (*v1alpha1.KeycloakAPIClient, error) {
+			return &v1alpha1.KeycloakAPIClient{ID: clientID, ClientID: "app", AuthorizationServicesEnabled: true}, nil
+		},
+		ListClientRolesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.RoleRepresentation, error) {
+			return nil, nil
+		},
+		ListClientProtocolMappersFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakProtocolMapper, error) {
+			return nil, nil
+		},
+		GetAuthorizationSettingsFunc: func(ctx context.Context, clientID string, realmName string) (*v1alpha1.KeycloakResourceServer, error) {
+			return &v1alpha1.KeycloakResourceServer{ID: "serverID", PolicyEnforcementMode: "ENFORCING"}, nil
+		},
+		ListAuthorizationScopesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakScope, error) {
+			return []v1alpha1.KeycloakScope{{ID: "scopeID", Name: "view"}}, nil
+		},
+		ListAuthorizationResourcesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakResource, error) {
+			return []v1alpha1.KeycloakResource{{ID: "resourceID", Name: "documents"}}, nil
+		},
+		ListAuthorizationPoliciesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakPolicy, error) {
+			return []v1alpha1.KeycloakPolicy{{ID: "policyID", Name: "documents permission", Type: "resource", Resources: []string{"documents"}}}, nil
+		},
+		ListScopeMappingsFunc: func(ctx context.Context, clientID string, realmName string) (*v1alpha1.MappingsRepresentation, error) {
+			return &v1alpha1.MappingsRepresentation{}, nil
+		},
+		ListAvailableClientScopesFunc: func(ctx context.Context, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+			return nil, nil
+		},
+		ListDefaultClientScopesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+			return nil, nil
+		},
+		ListOptionalClientScopesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+			return nil, nil
+		},
+		GetRealmFunc: func(ctx context.Context, realmName string) (*v1alpha1.KeycloakRealm, error) {
+			return &v1alpha1.KeycloakRealm{Spec: v1alpha1.KeycloakRealmSpec{Realm: &v1alpha1.KeycloakAPIRealm{DefaultRole: &v1alpha1.RoleRepresentation{ID: "defaultRoleID"}}}}, nil
+		},
+		ListRealmRoleClientRoleCompositesFunc: func(ctx context.Context, realmName string, roleID string, clientID string) ([]v1alpha1.RoleRepresentation, error) {
+			return nil, nil
+		},
+	}
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{ID: "clientID", ClientID: "app", Secret: "secret"},
+		},
+	}
+	state := NewClientState(context.TODO(), realm, v1alpha1.Keycloak{})
+
+	// when
+	err := state.Read(context.TODO(), cr, keycloakClient, fake.NewClientBuilder().Build())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "serverID", state.AuthorizationSettings.ID)
+	assert.Equal(t, "view", state.AuthorizationSettings.Scopes[0].Name)
+	assert.Equal(t, "documents", state.AuthorizationSettings.Resources[0].Name)
+	assert.Equal(t, []string{"documents"}, state.AuthorizationSettings.Policies[0].Resources)
+	assert.Len(t, keycloakClient.GetAuthorizationSettingsCalls(), 1)
+	assert.Equal(t, "dummy", keycloakClient.GetAuthorizationSettingsCalls()[0].RealmName)
+}
+
+func TestClientState_ReadRotatedClientSecret(t *testing.T) {
+	// given
+	realm := getDummyRealm()
+	keycloakClient := &KeycloakInterfaceMock{
+		GetClientFunc: func(ctx context.Context, clientID string, realmName string) (*v1alpha1.KeycloakAPIClient, error) {
+			return &v1alpha1.KeycloakAPIClient{ID: clientID, ClientID: "app"}, nil
+		},
+		GetClientSecretFunc: func(ctx context.Context, clientID string, realmName string) (string, error) {
+			return "rotated", nil
+		},
+		GetClientRotatedSecretFunc: func(ctx context.Context, clientID string, realmName string) (string, error) {
+			return "previous", nil
+		},
+		ListClientRolesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.RoleRepresentation, error) {
+			return nil, nil
+		},
+		ListClientProtocolMappersFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakProtocolMapper, error) {
+			return nil, nil
+		},
+		ListScopeMappingsFunc: func(ctx context.Context, clientID string, realmName string) (*v1alpha1.MappingsRepresentation, error) {
+			return &v1alpha1.MappingsRepresentation{}, nil
+		},
+		ListAvailableClientScopesFunc: func(ctx context.Context, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+			return nil, nil
+		},
+		ListDefaultClientScopesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+			return nil, nil
+		},
+		ListOptionalClientScopesFunc: func(ctx context.Context, clientID string, realmName string) ([]v1alpha1.KeycloakClientScope, error) {
+			return nil, nil
+		},
+		GetRealmFunc: func(ctx context.Context, realmName string) (*v1alpha1.KeycloakRealm, error) {
+			return &v1alpha1.KeycloakRealm{Spec: v1alpha1.KeycloakRealmSpec{Realm: &v1alpha1.KeycloakAPIRealm{DefaultRole: &v1alpha1.RoleRepresentation{ID: "defaultRoleID"}}}}, nil
+		},
+		ListRealmRoleClientRoleCompositesFunc: func(ctx context.Context, realmName string, roleID string, clientID string) ([]v1alpha1.RoleRepresentation, error) {
+			return nil, nil
+		},
+	}
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client:         &v1alpha1.KeycloakAPIClient{ID: "clientID", ClientID: "app", Secret: "secret"},
+			SecretRotation: &v1alpha1.KeycloakClientSecretRotation{Interval: v13.Duration{Duration: time.Hour}},
+		},
+	}
+	keycloak := v1alpha1.Keycloak{Status: v1alpha1.KeycloakStatus{Features: []string{ClientSecretRotationFeature}}}
+	state := NewClientState(context.TODO(), realm, keycloak)
+
+	// when
+	err := state.Read(context.TODO(), cr, keycloakClient, fake.NewClientBuilder().Build())
+
+	// then
+	assert.NoError(t, err)
+	// the secret in Keycloak wins over the secret in the CR
+	assert.Equal(t, "rotated", cr.Spec.Client.Secret)
+	assert.Equal(t, "previous", state.RotatedClientSecret)
+
+	// when the feature is disabled
+	cr.Spec.Client.Secret = "secret"
+	state = NewClientState(context.TODO(), realm, v1alpha1.Keycloak{})
+	err = state.Read(context.TODO(), cr, keycloakClient, fake.NewClientBuilder().Build())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated", cr.Spec.Client.Secret)
+	assert.Empty(t, state.RotatedClientSecret)
+	assert.Len(t, keycloakClient.GetClientRotatedSecretCalls(), 1)
+}
+
+func TestClientState_ReadSecretRef(t *testing.T) {
+	// given
+	realm := getDummyRealm()
+	keycloakClient := &KeycloakInterfaceMock{
+		GetClientIDFunc: func(ctx context.Context, clientID, realmName string) (string, error) {
+			return "", nil
+		},
+	}
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client:    &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+			SecretRef: &v1alpha1.KeycloakClientSecretRef{Name: "app-credentials"},
+		},
+	}
+	controllerClient := fake.NewClientBuilder().WithObjects(&v1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "app-credentials", Namespace: "test"},
+		Data:       map[string][]byte{"CLIENT_SECRET": []byte("from-ref")},
+	}).Build()
+	state := NewClientState(context.TODO(), realm, v1alpha1.Keycloak{})
+
+	// when
+	err := state.Read(context.TODO(), cr, keycloakClient, controllerClient)
+
+	// then the referenced secret wins over the one in the CR
+	assert.NoError(t, err)
+	assert.Equal(t, "from-ref", cr.Spec.Client.Secret)
+
+	// when
+	cr.Spec.SecretRotation = &v1alpha1.KeycloakClientSecretRotation{Interval: v13.Duration{Duration: time.Hour}}
+	err = state.Read(context.TODO(), cr, keycloakClient, controllerClient)
+
+	// then
+	assert.EqualError(t, err, "secretRef cannot be combined with secretRotation")
+
+	// when
+	cr.Spec.SecretRotation = nil
+	cr.Spec.SecretRef.Name = "missing"
+	err = state.Read(context.TODO(), cr, keycloakClient, controllerClient)
+
+	// then
+	assert.EqualError(t, err, "secret test/missing referenced by client app not found")
+}
+
+func TestClientState_ReadClient(t *testing.T) {
+	// given
+	realm := getDummyRealm()
+	keycloakClient := &KeycloakInterfaceMock{
+		GetClientFunc: func(ctx context.Context, clientID string, realmName string) (*v1alpha1.KeycloakAPIClient, error) {
+			if clientID != "current" {
+				return nil, nil
+			}
+			return &v1alpha1.KeycloakAPIClient{ID: clientID, ClientID: "app"}, nil
+		},
+		GetClientIDFunc: func(ctx context.Context, clientID string, realmName string) (string, error) {
+			return "current", nil
+		},
+	}
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{ClientID: "app"},
+		},
+		Status: v1alpha1.KeycloakClientStatus{ID: "current", Realm: "dummy"},
+	}
+	state := NewClientState(context.TODO(), realm, v1alpha1.Keycloak{})
+
+	// when the ID in the status belongs to the realm
+	client, err := state.readClient(context.TODO(), cr, keycloakClient)
+
+	// then it is used without a lookup
+	assert.NoError(t, err)
+	assert.Equal(t, "current", client.ID)
+	assert.Equal(t, "current", cr.Spec.Client.ID)
+	assert.Empty(t, keycloakClient.GetClientIDCalls())
+
+	// when a CR of an earlier version of the controller carries a stale ID in the spec
+	cr.Spec.Client.ID = "stale"
+	cr.Status = v1alpha1.KeycloakClientStatus{}
+	client, err = state.readClient(context.TODO(), cr, keycloakClient)
+
+	// then the client is looked up by its clientId and the ID moved into the status
+	assert.NoError(t, err)
+	assert.Equal(t, "current", client.ID)
+	assert.Equal(t, "current", cr.Status.ID)
+	assert.Equal(t, "dummy", cr.Status.Realm)
+	assert.Equal(t, "app", keycloakClient.GetClientIDCalls()[0].ClientID)
+
+	// when the client does not exist
+	keycloakClient.GetClientIDFunc = func(ctx context.Context, clientID string, realmName string) (string, error) {
+		return "", nil
+	}
+	cr.Status.ID = "deleted"
+	client, err = state.readClient(context.TODO(), cr, keycloakClient)
+
+	// then it is created again
+	assert.NoError(t, err)
+	assert.Nil(t, client)
+	assert.Empty(t, cr.Spec.Client.ID)
+	assert.Empty(t, cr.Status.ID)
+}
@@ -1,11 +1,15 @@
 package common
 
 import (
+	"context"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 
@@ -41,15 +45,16 @@ func TestClient_CreateRealm(t *testing.T) {
 	defer server.Close()
 
 	client := Client{
-		requester: server.Client(),
-		URL:       server.URL,
-		token:     "dummy",
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "dummy",
 	}
 
 	realm := getDummyRealm()
 
 	// when
-	_, err := client.CreateRealm(realm)
+	_, err := client.CreateRealm(context.TODO(), realm)
 
 	// then
 	// no error expected
@@ -69,13 +74,14 @@ func TestClient_DeleteRealmRealm(t *testing.T) {
 	defer server.Close()
 
 	client := Client{
-		requester: server.Client(),
-		URL:       server.URL,
-		token:     "dummy",
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "dummy",
 	}
 
 	// when
-	err := client.DeleteRealm(realm.Spec.Realm.Realm)
+	err := client.DeleteRealm(context.TODO(), realm.Spec.Realm.Realm)
 
 	// then
 	// correct path expected on httptest server
@@ -105,13 +111,14 @@ func TestClient_login(t *testing.T) {
 	defer server.Close()
 
 	client := Client{
-		requester: server.Client(),
-		URL:       server.URL,
-		token:     "not set",
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "not set",
 	}
 
 	// when
-	err := client.login("dummy", "dummy")
+	err := client.login(context.TODO(), "dummy", "dummy")
 
 	// then
 	// token must be set on the client now
@@ -119,6 +126,124 @@ func TestClient_login(t *testing.T) {
 	assert.Equal(t, client.token, "dummy")
 }
 
+func TestClient_CreateRealmWithRootContextPath(t *testing.T) {
+	// given
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/admin/realms", req.URL.Path)
+		w.WriteHeader(201)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: NormalizeContextPath("/"),
+		token:       "dummy",
+	}
+
+	// when
+	_, err := client.CreateRealm(context.TODO(), getDummyRealm())
+
+	// then
+	assert.NoError(t, err)
+}
+
+func TestClient_NormalizeContextPath(t *testing.T) {
+	assert.Equal(t, "", NormalizeContextPath(""))
+	assert.Equal(t, "", NormalizeContextPath("/"))
+	assert.Equal(t, "/auth", NormalizeContextPath("auth"))
+	assert.Equal(t, "/auth", NormalizeContextPath("/auth/"))
+	assert.Equal(t, "/sso/keycloak", NormalizeContextPath("/sso/keycloak"))
+}
+
+func TestClient_detectContextPath(t *testing.T) {
+	// given
+	legacy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/auth/realms/master/.well-known/openid-configuration" {
+			w.WriteHeader(200)
+			return
+		}
+		w.WriteHeader(404)
+	}))
+	defer legacy.Close()
+	quarkus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/realms/master/.well-known/openid-configuration" {
+			w.WriteHeader(200)
+			return
+		}
+		w.WriteHeader(404)
+	}))
+	defer quarkus.Close()
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(503)
+	}))
+	defer unavailable.Close()
+
+	// when / then
+	assert.Equal(t, LegacyContextPath, detectContextPath(context.TODO(), legacy.URL, DefaultAdminRealm, legacy.Client()))
+	assert.Equal(t, "", detectContextPath(context.TODO(), quarkus.URL, DefaultAdminRealm, quarkus.Client()))
+	assert.Equal(t, LegacyContextPath, detectContextPath(context.TODO(), unavailable.URL, DefaultAdminRealm, unavailable.Client()))
+}
+
+func TestClient_loginWithAdminRealm(t *testing.T) {
+	// given
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/realms/dummy/protocol/openid-connect/token", req.URL.Path)
+
+		json, err := jsoniter.Marshal(v1alpha1.TokenResponse{AccessToken: "dummy"})
+		assert.NoError(t, err)
+		_, err = w.Write(json)
+		assert.NoError(t, err)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:  server.Client(),
+		URL:        server.URL,
+		adminRealm: "dummy",
+		token:      "not set",
+	}
+
+	// when
+	err := client.login(context.TODO(), "dummy", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "dummy", client.token)
+}
+
+func TestClient_loginWithTokenURL(t *testing.T) {
+	// given
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/custom/token", req.URL.Path)
+
+		json, err := jsoniter.Marshal(v1alpha1.TokenResponse{AccessToken: "dummy"})
+		assert.NoError(t, err)
+		_, err = w.Write(json)
+		assert.NoError(t, err)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		adminRealm:  "dummy",
+		tokenURL:    server.URL + "/custom/token",
+		token:       "not set",
+	}
+
+	// when
+	err := client.login_admin(context.TODO(), "dummy", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "dummy", client.token)
+}
+
 func TestClient_useKeycloakServerCertificate(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		_, err := w.Write([]byte("dummy"))
@@ -131,7 +256,7 @@ func TestClient_useKeycloakServerCertificate(t *testing.T) {
 
 	pemCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
 
-	requester, err := defaultRequester(pemCert)
+	requester, err := defaultRequester(pemCert, DefaultRequestTimeout)
 	assert.NoError(t, err)
 	httpClient, ok := requester.(*http.Client)
 	assert.True(t, ok)
@@ -144,3 +269,337 @@ func TestClient_useKeycloakServerCertificate(t *testing.T) {
 	defer resp.Body.Close()
 	assert.Equal(t, resp.StatusCode, 200)
 }
+
+func TestClient_refreshesExpiredToken(t *testing.T) {
+	// given
+	refreshed := false
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case TokenPath:
+			assert.NoError(t, req.ParseForm())
+			assert.Equal(t, "refresh_token", req.Form.Get("grant_type"))
+			assert.Equal(t, "old-refresh", req.Form.Get("refresh_token"))
+			refreshed = true
+
+			json, err := jsoniter.Marshal(v1alpha1.TokenResponse{AccessToken: "new", ExpiresIn: 60})
+			assert.NoError(t, err)
+			_, err = w.Write(json)
+			assert.NoError(t, err)
+		case fmt.Sprintf(RealmsDeletePath, "dummy"):
+			assert.Equal(t, "Bearer new", req.Header.Get("Authorization"))
+			w.WriteHeader(204)
+		default:
+			t.Errorf("unexpected request to %s", req.URL.Path)
+		}
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:     server.Client(),
+		URL:           server.URL,
+		contextPath:   LegacyContextPath,
+		token:         "old",
+		tokenExpiry:   time.Now().Add(-time.Second),
+		refreshToken:  "old-refresh",
+		tokenClientID: "admin-cli",
+	}
+
+	// when
+	err := client.DeleteRealm(context.TODO(), "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, refreshed)
+	assert.Equal(t, "new", client.token)
+	assert.True(t, client.tokenExpiry.After(time.Now()))
+}
+
+func TestClient_loginAgainOnUnauthorized(t *testing.T) {
+	// given
+	logins := 0
+	created := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case TokenPath:
+			assert.NoError(t, req.ParseForm())
+			assert.Equal(t, "password", req.Form.Get("grant_type"))
+			logins++
+
+			json, err := jsoniter.Marshal(v1alpha1.TokenResponse{AccessToken: "valid"})
+			assert.NoError(t, err)
+			_, err = w.Write(json)
+			assert.NoError(t, err)
+		case RealmsCreatePath:
+			if req.Header.Get("Authorization") != "Bearer valid" {
+				w.WriteHeader(401)
+				return
+			}
+			body, err := io.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(body), "dummy")
+			created++
+			w.WriteHeader(201)
+		default:
+			t.Errorf("unexpected request to %s", req.URL.Path)
+		}
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "revoked",
+		credentials: &credentials{user: "admin", password: "admin"},
+	}
+
+	// when
+	_, err := client.CreateRealm(context.TODO(), getDummyRealm())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, logins)
+	assert.Equal(t, 1, created)
+	assert.Equal(t, "valid", client.token)
+}
+
+func TestClient_tokenExpiry(t *testing.T) {
+	now := time.Now()
+	assert.True(t, tokenExpiry(now, 0).IsZero())
+	assert.Equal(t, now.Add(50*time.Second), tokenExpiry(now, 60))
+	assert.Equal(t, now.Add(5*time.Second), tokenExpiry(now, 10))
+}
+
+func TestClient_cancelledContext(t *testing.T) {
+	// given
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request to %s", req.URL.Path)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "dummy",
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	_, err := client.CreateRealm(ctx, getDummyRealm())
+
+	// then
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClient_ProtocolMappers(t *testing.T) {
+	// given
+	const mappersPath = "/auth/admin/realms/dummy/clients/clientID/protocol-mappers/models"
+	var requests []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch req.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"id":"mapperID","name":"audience","protocol":"openid-connect","protocolMapper":"oidc-audience-mapper","config":{"included.client.audience":"app"}}]`))
+		case http.MethodPost:
+			w.Header().Set("Location", "http://"+req.Host+mappersPath+"/newID")
+			w.WriteHeader(201)
+		default:
+			w.WriteHeader(204)
+		}
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "dummy",
+	}
+
+	// when
+	mappers, err := client.ListClientProtocolMappers(context.TODO(), "clientID", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, mappers, 1)
+	assert.Equal(t, "mapperID", mappers[0].ID)
+	assert.Equal(t, "app", mappers[0].Config["included.client.audience"])
+
+	// when
+	id, err := client.CreateClientProtocolMapper(context.TODO(), "clientID", &v1alpha1.KeycloakProtocolMapper{Name: "new"}, "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "newID", id)
+
+	// when
+	err = client.UpdateClientProtocolMapper(context.TODO(), "clientID", &mappers[0], "dummy")
+
+	// then
+	assert.NoError(t, err)
+
+	// when
+	err = client.DeleteClientProtocolMapper(context.TODO(), "clientID", "mapperID", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET " + mappersPath,
+		"POST " + mappersPath,
+		"PUT " + mappersPath + "/mapperID",
+		"DELETE " + mappersPath + "/mapperID",
+	}, requests)
+}
+
+func TestClient_ClientSecretRotation(t *testing.T) {
+	// given
+	const secretPath = "/auth/admin/realms/dummy/clients/clientID/client-secret"
+	var requests []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch {
+		case req.Method == http.MethodPost:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"type":"secret","value":"new"}`))
+		case req.Method == http.MethodGet && req.URL.Path == secretPath+"/rotated":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"type":"secret","value":"old"}`))
+		default:
+			w.WriteHeader(204)
+		}
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "dummy",
+	}
+
+	// when
+	secret, err := client.RegenerateClientSecret(context.TODO(), "clientID", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "new", secret)
+
+	// when
+	rotated, err := client.GetClientRotatedSecret(context.TODO(), "clientID", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "old", rotated)
+
+	// when
+	err = client.InvalidateClientRotatedSecret(context.TODO(), "clientID", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"POST " + secretPath,
+		"GET " + secretPath + "/rotated",
+		"DELETE " + secretPath + "/rotated",
+	}, requests)
+}
+
+func TestClient_GetClientRotatedSecret_None(t *testing.T) {
+	// given
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(404)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "dummy",
+	}
+
+	// when
+	rotated, err := client.GetClientRotatedSecret(context.TODO(), "clientID", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Empty(t, rotated)
+}
+
+func TestClient_GetOpenIDConfiguration(t *testing.T) {
+	// given
+	var requests []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issuer":"https://sso/realms/dummy","token_endpoint":"https://sso/realms/dummy/protocol/openid-connect/token","jwks_uri":"https://sso/realms/dummy/protocol/openid-connect/certs"}`))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "dummy",
+	}
+
+	// when
+	config, err := client.GetOpenIDConfiguration(context.TODO(), "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "https://sso/realms/dummy", config.Issuer)
+	assert.Equal(t, "https://sso/realms/dummy/protocol/openid-connect/token", config.TokenEndpoint)
+	assert.Equal(t, "https://sso/realms/dummy/protocol/openid-connect/certs", config.JwksURI)
+	assert.Equal(t, []string{"GET /auth/realms/dummy/.well-known/openid-configuration"}, requests)
+}
+
+func TestClient_GetClientInstall(t *testing.T) {
+	// given
+	var requests []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		if strings.HasSuffix(req.URL.Path, "/unknown") {
+			w.WriteHeader(404)
+			return
+		}
+		_, _ = w.Write([]byte(`<EntityDescriptor/>`))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "dummy",
+	}
+
+	// when
+	document, err := client.GetClientInstall(context.TODO(), "clientID", "saml-idp-descriptor", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`<EntityDescriptor/>`), document)
+
+	// when
+	document, err = client.GetClientInstall(context.TODO(), "clientID", "unknown", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, document)
+	assert.Equal(t, []string{
+		"GET /auth/admin/realms/dummy/clients/clientID/installation/providers/saml-idp-descriptor",
+		"GET /auth/admin/realms/dummy/clients/clientID/installation/providers/unknown",
+	}, requests)
+}
@@ -8,8 +8,9 @@ import (
 	"strings"
 
 	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
-	"github.com/movewp3/keycloakclient-controller/pkg/util"
+	"github.com/movewp3/keycloakclient-controller/pkg/model"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -35,6 +36,23 @@ type ActionRunner interface {
 	CreateClientRole(keycloakClient *v1alpha1.KeycloakClient, role *v1alpha1.RoleRepresentation, realm string) error
 	UpdateClientRole(keycloakClient *v1alpha1.KeycloakClient, role, oldRole *v1alpha1.RoleRepresentation, realm string) error
 	DeleteClientRole(keycloakClient *v1alpha1.KeycloakClient, role, Realm string) error
+	CreateClientProtocolMapper(keycloakClient *v1alpha1.KeycloakClient, mapper *v1alpha1.KeycloakProtocolMapper, realm string) error
+	UpdateClientProtocolMapper(keycloakClient *v1alpha1.KeycloakClient, mapper *v1alpha1.KeycloakProtocolMapper, realm string) error
+	DeleteClientProtocolMapper(keycloakClient *v1alpha1.KeycloakClient, mapper *v1alpha1.KeycloakProtocolMapper, realm string) error
+	RotateClientSecret(keycloakClient *v1alpha1.KeycloakClient, secret *corev1.Secret, data model.ClientSecretTemplateData, keepPrevious bool, realm string) error
+	InvalidateClientRotatedSecret(keycloakClient *v1alpha1.KeycloakClient, realm string) error
+	PublishClientInstallations(keycloakClient *v1alpha1.KeycloakClient, target client.Object, installations []v1alpha1.KeycloakClientInstallation, realm string) error
+	ApplyClientSecretTarget(keycloakClient *v1alpha1.KeycloakClient, secret *corev1.Secret, template *v1alpha1.KeycloakClientSecretTemplate, data model.ClientSecretTemplateData, followRotation bool) error
+	UpdateAuthorizationSettings(keycloakClient *v1alpha1.KeycloakClient, settings *v1alpha1.KeycloakResourceServer, realm string) error
+	CreateAuthorizationScope(keycloakClient *v1alpha1.KeycloakClient, scope *v1alpha1.KeycloakScope, realm string) error
+	UpdateAuthorizationScope(keycloakClient *v1alpha1.KeycloakClient, scope *v1alpha1.KeycloakScope, realm string) error
+	DeleteAuthorizationScope(keycloakClient *v1alpha1.KeycloakClient, scope *v1alpha1.KeycloakScope, realm string) error
+	CreateAuthorizationResource(keycloakClient *v1alpha1.KeycloakClient, resource *v1alpha1.KeycloakResource, realm string) error
+	UpdateAuthorizationResource(keycloakClient *v1alpha1.KeycloakClient, resource *v1alpha1.KeycloakResource, realm string) error
+	DeleteAuthorizationResource(keycloakClient *v1alpha1.KeycloakClient, resource *v1alpha1.KeycloakResource, realm string) error
+	CreateAuthorizationPolicy(keycloakClient *v1alpha1.KeycloakClient, policy *v1alpha1.KeycloakPolicy, realm string) error
+	UpdateAuthorizationPolicy(keycloakClient *v1alpha1.KeycloakClient, policy *v1alpha1.KeycloakPolicy, realm string) error
+	DeleteAuthorizationPolicy(keycloakClient *v1alpha1.KeycloakClient, policy *v1alpha1.KeycloakPolicy, realm string) error
 	CreateClientRealmScopeMappings(keycloakClient *v1alpha1.KeycloakClient, mappings *[]v1alpha1.RoleRepresentation, realm string) error
 	DeleteClientRealmScopeMappings(keycloakClient *v1alpha1.KeycloakClient, mappings *[]v1alpha1.RoleRepresentation, realm string) error
 	CreateClientClientScopeMappings(keycloakClient *v1alpha1.KeycloakClient, mappings *v1alpha1.ClientMappingsRepresentation, realm string) error
@@ -88,7 +106,7 @@ func NewClusterAndKeycloakActionRunner(context context.Context, client client.Cl
 
 func (i *ClusterActionRunner) RunAll(desiredState DesiredClusterState) error {
 	for index, action := range desiredState {
-		msg, err := action.Run(i)
+		msg, err := i.run(action)
 		if err != nil {
 			log.Info(fmt.Sprintf("(%5d) %10s %s : %s", index, "FAILED", msg, err))
 			return err
@@ -99,6 +117,18 @@ func (i *ClusterActionRunner) RunAll(desiredState DesiredClusterState) error {
 	return nil
 }
 
+// run runs a single action in its own span, so that the requests of the action are traced as its children
+func (i *ClusterActionRunner) run(action ClusterAction) (string, error) {
+	parent := i.context
+	ctx, span := StartSpan(parent, actionName(action))
+	i.context = ctx
+	defer func() { i.context = parent }()
+
+	msg, err := action.Run(i)
+	EndSpan(span, err)
+	return msg, err
+}
+
 func (i *ClusterActionRunner) Create(obj client.Object) error {
 	err := controllerutil.SetControllerReference(i.cr.(v1.Object), obj.(v1.Object), i.scheme)
 	if err != nil {
@@ -132,7 +162,7 @@ func (i *ClusterActionRunner) CreateRealm(obj *v1alpha1.KeycloakRealm) error {
 		return errors.Errorf("cannot perform realm create when client is nil")
 	}
 
-	_, err := i.keycloakClient.CreateRealm(obj)
+	_, err := i.keycloakClient.CreateRealm(i.context, obj)
 	return err
 }
 
@@ -152,13 +182,12 @@ func (i *ClusterActionRunner) CreateClient(obj *v1alpha1.KeycloakClient, realm s
 	}
 
 	oldClientScopes := obj.Spec.Client.DefaultClientScopes
-	addedDefaultClientScope := false
 
 	additionalDefaultClientScopes := obj.Spec.Client.DefaultClientScopes
-	if obj.Spec.Client.PublicClient {
+	// the additional default client scopes are OIDC client scopes
+	if obj.Spec.Client.PublicClient && !model.IsSAML(obj) {
 		for _, scope := range getAdditionalDefaultClientScopes() {
 			if !slices.Contains(obj.Spec.Client.DefaultClientScopes, scope) {
-				addedDefaultClientScope = true
 				log.Info(fmt.Sprintf("Add default client scope %v",
 					getAdditionalDefaultClientScopes()))
 				additionalDefaultClientScopes = append(additionalDefaultClientScopes, scope)
@@ -171,68 +200,34 @@ func (i *ClusterActionRunner) CreateClient(obj *v1alpha1.KeycloakClient, realm s
 	}
 	obj.Spec.Client.DefaultClientScopes = additionalDefaultClientScopes
 
-	uid, err := i.keycloakClient.CreateClient(obj.Spec.Client, realm)
+	uid, err := i.keycloakClient.CreateClient(i.context, obj.Spec.Client, realm)
 
 	if err == nil {
-		obj.Spec.Client.ID = uid
-		//  keycloak CR is updated here with uid
-		log.Info(fmt.Sprintf("Update K8S Keycloakclient %v",
-			obj.Name))
-
-		// if secret was generated via seed secret, then dont store it
-		sha, errsha := util.GetClientShaCode(obj.Spec.Client.ClientID)
-		if errsha == nil && sha == obj.Spec.Client.Secret {
-			obj.Spec.Client.Secret = ""
-			log.Info(fmt.Sprintf("Removed secret (generated from secretSeed) from keycloak client %v",
-				obj.Name))
-		}
-		if addedDefaultClientScope {
-			obj.Spec.Client.DefaultClientScopes = oldClientScopes
-			log.Info(fmt.Sprintf("Removed additional client scope from keycloak client %v",
-				obj.Name))
-		}
-
-		return i.client.Update(i.context, obj)
+		bindClientID(obj, uid, realm)
+		return nil
 	}
 
 	log.Info(fmt.Sprintf("FAILED: create client failed for client %s with error %s", obj.Spec.Client.Name, err.Error()))
 
-	if err.Error() == "failed to create client: (409) 409 Conflict" {
+	if IsConflict(err) {
 		log.Info(" retry create client after 409 Conflict")
 
-		uid, err2 := i.keycloakClient.GetClientID(obj.Spec.Client.ClientID, realm)
+		uid, err2 := i.keycloakClient.GetClientID(i.context, obj.Spec.Client.ClientID, realm)
 
 		if err2 != nil {
 			return errors.Errorf(fmt.Sprintf("cannot perform client create because of %s followed by %s", err.Error(), err2.Error()))
 		}
-		err3 := i.keycloakClient.DeleteClient(uid, realm)
+		err3 := i.keycloakClient.DeleteClient(i.context, uid, realm)
 		if err3 != nil {
 			return errors.Errorf(fmt.Sprintf("cannot perform client create because of %s followed by %s", err.Error(), err3.Error()))
 		}
 		log.Info(fmt.Sprintf(" client %s deleted", obj.Spec.Client.Name))
 
-		uid, err := i.keycloakClient.CreateClient(obj.Spec.Client, realm)
+		uid, err := i.keycloakClient.CreateClient(i.context, obj.Spec.Client, realm)
 
 		if err == nil {
-			obj.Spec.Client.ID = uid
-			//  keycloak CR is updated here with uid
-			log.Info(fmt.Sprintf("Update K8S Keycloakclient %v",
-				obj.Name))
-
-			// if secret was generated via seed secret, then dont store it
-			sha, errsha := util.GetClientShaCode(obj.Spec.Client.ClientID)
-			if errsha == nil && sha == obj.Spec.Client.Secret {
-				obj.Spec.Client.Secret = ""
-				log.Info(fmt.Sprintf("Removed secret (generated from secretSeed) from keycloak client %v",
-					obj.Name))
-			}
-			if addedDefaultClientScope {
-				obj.Spec.Client.DefaultClientScopes = oldClientScopes
-				log.Info(fmt.Sprintf("Removed additional client scope from keycloak client %v",
-					obj.Name))
-			}
-
-			return i.client.Update(i.context, obj)
+			bindClientID(obj, uid, realm)
+			return nil
 		}
 	}
 
@@ -240,89 +235,286 @@ func (i *ClusterActionRunner) CreateClient(obj *v1alpha1.KeycloakClient, realm s
 
 }
 
+// bindClientID keeps the ID of a created client in the status of the CR, which is written at the end of the reconcile,
+// and in spec.client.id for the following actions of the reconcile
+func bindClientID(obj *v1alpha1.KeycloakClient, uid, realm string) {
+	log.Info(fmt.Sprintf("created client %v with id %v in realm %v", obj.Name, uid, realm))
+	obj.Spec.Client.ID = uid
+	obj.Status.ID = uid
+	obj.Status.Realm = realm
+	obj.Status.RoleIDs = nil
+}
+
 func (i *ClusterActionRunner) UpdateClient(obj *v1alpha1.KeycloakClient, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client update when client is nil")
 	}
-	return i.keycloakClient.UpdateClient(obj.Spec.Client, realm)
+	return i.keycloakClient.UpdateClient(i.context, obj.Spec.Client, realm)
 }
 
 func (i *ClusterActionRunner) CreateClientRole(obj *v1alpha1.KeycloakClient, role *v1alpha1.RoleRepresentation, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client role create when client is nil")
 	}
-	_, err := i.keycloakClient.CreateClientRole(obj.Spec.Client.ID, role, realm)
-	return err
+	_, err := i.keycloakClient.CreateClientRole(i.context, obj.Spec.Client.ID, role, realm)
+	if err != nil {
+		return err
+	}
+	// the location of a created role contains its name, the ID is only known from the roles of the client
+	roles, err := i.keycloakClient.ListClientRoles(i.context, obj.Spec.Client.ID, realm)
+	if err != nil {
+		return err
+	}
+	obj.Status.RoleIDs = map[string]string{}
+	for _, role := range roles {
+		obj.Status.RoleIDs[role.Name] = role.ID
+	}
+	return nil
 }
 
 func (i *ClusterActionRunner) UpdateClientRole(obj *v1alpha1.KeycloakClient, role, oldRole *v1alpha1.RoleRepresentation, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client role update when client is nil")
 	}
-	return i.keycloakClient.UpdateClientRole(obj.Spec.Client.ID, role, oldRole, realm)
+	return i.keycloakClient.UpdateClientRole(i.context, obj.Spec.Client.ID, role, oldRole, realm)
 }
 
 func (i *ClusterActionRunner) DeleteClientRole(obj *v1alpha1.KeycloakClient, role, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client role delete when client is nil")
 	}
-	return i.keycloakClient.DeleteClientRole(obj.Spec.Client.ID, role, realm)
+	err := i.keycloakClient.DeleteClientRole(i.context, obj.Spec.Client.ID, role, realm)
+	if err == nil {
+		delete(obj.Status.RoleIDs, role)
+	}
+	return err
+}
+
+func (i *ClusterActionRunner) CreateClientProtocolMapper(obj *v1alpha1.KeycloakClient, mapper *v1alpha1.KeycloakProtocolMapper, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client protocol mapper create when client is nil")
+	}
+	_, err := i.keycloakClient.CreateClientProtocolMapper(i.context, obj.Spec.Client.ID, mapper, realm)
+	return err
+}
+
+func (i *ClusterActionRunner) UpdateClientProtocolMapper(obj *v1alpha1.KeycloakClient, mapper *v1alpha1.KeycloakProtocolMapper, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client protocol mapper update when client is nil")
+	}
+	return i.keycloakClient.UpdateClientProtocolMapper(i.context, obj.Spec.Client.ID, mapper, realm)
+}
+
+func (i *ClusterActionRunner) DeleteClientProtocolMapper(obj *v1alpha1.KeycloakClient, mapper *v1alpha1.KeycloakProtocolMapper, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client protocol mapper delete when client is nil")
+	}
+	return i.keycloakClient.DeleteClientProtocolMapper(i.context, obj.Spec.Client.ID, mapper.ID, realm)
+}
+
+// RotateClientSecret regenerates the secret of the client and renders it into the given k8s secret, together with
+// the previous secret if Keycloak keeps it valid. The time of the rotation is recorded in the status of the CR.
+func (i *ClusterActionRunner) RotateClientSecret(obj *v1alpha1.KeycloakClient, secret *corev1.Secret, data model.ClientSecretTemplateData, keepPrevious bool, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client secret rotation when client is nil")
+	}
+	clientSecret, err := i.keycloakClient.RegenerateClientSecret(i.context, obj.Spec.Client.ID, realm)
+	if err != nil {
+		return err
+	}
+	now := v1.Now()
+	obj.Spec.Client.Secret = clientSecret
+	obj.Status.LastSecretRotation = &now
+
+	data.ClientSecret = clientSecret
+	data.PreviousClientSecret = ""
+	if keepPrevious {
+		data.PreviousClientSecret, err = i.keycloakClient.GetClientRotatedSecret(i.context, obj.Spec.Client.ID, realm)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := model.RenderClientSecret(secret, obj.Spec.SecretTemplate, data); err != nil {
+		return err
+	}
+	return i.Update(secret)
+}
+
+// ApplyClientSecretTarget renders and creates or updates a secret target of the client. Secret targets have no owner
+// reference, as they may be in another namespace. With followRotation the secret of the client is taken from the CR
+// when the action runs, i.e. after the rotation of the secret in the same reconcile.
+func (i *ClusterActionRunner) ApplyClientSecretTarget(obj *v1alpha1.KeycloakClient, secret *corev1.Secret, template *v1alpha1.KeycloakClientSecretTemplate, data model.ClientSecretTemplateData, followRotation bool) error {
+	if followRotation {
+		data.ClientSecret = obj.Spec.Client.Secret
+	}
+	err := model.RenderClientSecret(secret, template, data)
+	if err != nil {
+		return err
+	}
+
+	if secret.GetResourceVersion() == "" {
+		return i.client.Create(i.context, secret)
+	}
+	return i.client.Update(i.context, secret)
+}
+
+// PublishClientInstallations fetches the documents of the installation providers and creates or updates the Secret
+// or ConfigMap they are published in, depending on whether it exists already
+func (i *ClusterActionRunner) PublishClientInstallations(obj *v1alpha1.KeycloakClient, target client.Object, installations []v1alpha1.KeycloakClientInstallation, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client installations publish when client is nil")
+	}
+	documents := map[string][]byte{}
+	for _, installation := range installations {
+		document, err := i.keycloakClient.GetClientInstall(i.context, obj.Spec.Client.ID, installation.ProviderID, realm)
+		if err != nil {
+			return err
+		}
+		if document == nil {
+			return errors.Errorf("installation provider %s is not available for client %s", installation.ProviderID, obj.Spec.Client.ClientID)
+		}
+		documents[installation.Key] = document
+	}
+
+	model.SetClientInstallationDocuments(target, documents)
+	if target.GetResourceVersion() == "" {
+		return i.Create(target)
+	}
+	return i.Update(target)
+}
+
+func (i *ClusterActionRunner) InvalidateClientRotatedSecret(obj *v1alpha1.KeycloakClient, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client rotated secret invalidation when client is nil")
+	}
+	return i.keycloakClient.InvalidateClientRotatedSecret(i.context, obj.Spec.Client.ID, realm)
+}
+
+func (i *ClusterActionRunner) UpdateAuthorizationSettings(obj *v1alpha1.KeycloakClient, settings *v1alpha1.KeycloakResourceServer, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client authorization settings update when client is nil")
+	}
+	return i.keycloakClient.UpdateAuthorizationSettings(i.context, obj.Spec.Client.ID, settings, realm)
+}
+
+func (i *ClusterActionRunner) CreateAuthorizationScope(obj *v1alpha1.KeycloakClient, scope *v1alpha1.KeycloakScope, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client authorization scope create when client is nil")
+	}
+	return i.keycloakClient.CreateAuthorizationScope(i.context, obj.Spec.Client.ID, scope, realm)
+}
+
+func (i *ClusterActionRunner) UpdateAuthorizationScope(obj *v1alpha1.KeycloakClient, scope *v1alpha1.KeycloakScope, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client authorization scope update when client is nil")
+	}
+	return i.keycloakClient.UpdateAuthorizationScope(i.context, obj.Spec.Client.ID, scope, realm)
+}
+
+func (i *ClusterActionRunner) DeleteAuthorizationScope(obj *v1alpha1.KeycloakClient, scope *v1alpha1.KeycloakScope, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client authorization scope delete when client is nil")
+	}
+	return i.keycloakClient.DeleteAuthorizationScope(i.context, obj.Spec.Client.ID, scope.ID, realm)
+}
+
+func (i *ClusterActionRunner) CreateAuthorizationResource(obj *v1alpha1.KeycloakClient, resource *v1alpha1.KeycloakResource, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client authorization resource create when client is nil")
+	}
+	return i.keycloakClient.CreateAuthorizationResource(i.context, obj.Spec.Client.ID, resource, realm)
+}
+
+func (i *ClusterActionRunner) UpdateAuthorizationResource(obj *v1alpha1.KeycloakClient, resource *v1alpha1.KeycloakResource, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client authorization resource update when client is nil")
+	}
+	return i.keycloakClient.UpdateAuthorizationResource(i.context, obj.Spec.Client.ID, resource, realm)
+}
+
+func (i *ClusterActionRunner) DeleteAuthorizationResource(obj *v1alpha1.KeycloakClient, resource *v1alpha1.KeycloakResource, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client authorization resource delete when client is nil")
+	}
+	return i.keycloakClient.DeleteAuthorizationResource(i.context, obj.Spec.Client.ID, resource.ID, realm)
+}
+
+func (i *ClusterActionRunner) CreateAuthorizationPolicy(obj *v1alpha1.KeycloakClient, policy *v1alpha1.KeycloakPolicy, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client authorization policy create when client is nil")
+	}
+	return i.keycloakClient.CreateAuthorizationPolicy(i.context, obj.Spec.Client.ID, policy, realm)
+}
+
+func (i *ClusterActionRunner) UpdateAuthorizationPolicy(obj *v1alpha1.KeycloakClient, policy *v1alpha1.KeycloakPolicy, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client authorization policy update when client is nil")
+	}
+	return i.keycloakClient.UpdateAuthorizationPolicy(i.context, obj.Spec.Client.ID, policy, realm)
+}
+
+func (i *ClusterActionRunner) DeleteAuthorizationPolicy(obj *v1alpha1.KeycloakClient, policy *v1alpha1.KeycloakPolicy, realm string) error {
+	if i.keycloakClient == nil {
+		return errors.Errorf("cannot perform client authorization policy delete when client is nil")
+	}
+	return i.keycloakClient.DeleteAuthorizationPolicy(i.context, obj.Spec.Client.ID, policy.ID, realm)
 }
 
 func (i *ClusterActionRunner) CreateClientRealmScopeMappings(keycloakClient *v1alpha1.KeycloakClient, mappings *[]v1alpha1.RoleRepresentation, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client realm scope create when client is nil")
 	}
-	return i.keycloakClient.CreateClientRealmScopeMappings(keycloakClient.Spec.Client, mappings, realm)
+	return i.keycloakClient.CreateClientRealmScopeMappings(i.context, keycloakClient.Spec.Client, mappings, realm)
 }
 
 func (i *ClusterActionRunner) DeleteClientRealmScopeMappings(keycloakClient *v1alpha1.KeycloakClient, mappings *[]v1alpha1.RoleRepresentation, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client realm scope delete when client is nil")
 	}
-	return i.keycloakClient.DeleteClientRealmScopeMappings(keycloakClient.Spec.Client, mappings, realm)
+	return i.keycloakClient.DeleteClientRealmScopeMappings(i.context, keycloakClient.Spec.Client, mappings, realm)
 }
 
 func (i *ClusterActionRunner) CreateClientClientScopeMappings(keycloakClient *v1alpha1.KeycloakClient, mappings *v1alpha1.ClientMappingsRepresentation, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client client scope create when client is nil")
 	}
-	return i.keycloakClient.CreateClientClientScopeMappings(keycloakClient.Spec.Client, mappings, realm)
+	return i.keycloakClient.CreateClientClientScopeMappings(i.context, keycloakClient.Spec.Client, mappings, realm)
 }
 
 func (i *ClusterActionRunner) DeleteClientDefaultClientScope(keycloakClient *v1alpha1.KeycloakClient, clientScope *v1alpha1.KeycloakClientScope, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client default client scope delete when client is nil")
 	}
-	return i.keycloakClient.DeleteClientDefaultClientScope(keycloakClient.Spec.Client, clientScope, realm)
+	return i.keycloakClient.DeleteClientDefaultClientScope(i.context, keycloakClient.Spec.Client, clientScope, realm)
 }
 
 func (i *ClusterActionRunner) UpdateClientDefaultClientScope(keycloakClient *v1alpha1.KeycloakClient, clientScope *v1alpha1.KeycloakClientScope, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client default client scope create when client is nil")
 	}
-	return i.keycloakClient.UpdateClientDefaultClientScope(keycloakClient.Spec.Client, clientScope, realm)
+	return i.keycloakClient.UpdateClientDefaultClientScope(i.context, keycloakClient.Spec.Client, clientScope, realm)
 }
 
 func (i *ClusterActionRunner) DeleteClientOptionalClientScope(keycloakClient *v1alpha1.KeycloakClient, clientScope *v1alpha1.KeycloakClientScope, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client optional client scope delete when client is nil")
 	}
-	return i.keycloakClient.DeleteClientOptionalClientScope(keycloakClient.Spec.Client, clientScope, realm)
+	return i.keycloakClient.DeleteClientOptionalClientScope(i.context, keycloakClient.Spec.Client, clientScope, realm)
 }
 
 func (i *ClusterActionRunner) UpdateClientOptionalClientScope(keycloakClient *v1alpha1.KeycloakClient, clientScope *v1alpha1.KeycloakClientScope, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client optional client scope create when client is nil")
 	}
-	return i.keycloakClient.UpdateClientOptionalClientScope(keycloakClient.Spec.Client, clientScope, realm)
+	return i.keycloakClient.UpdateClientOptionalClientScope(i.context, keycloakClient.Spec.Client, clientScope, realm)
 }
 
 func (i *ClusterActionRunner) DeleteClientClientScopeMappings(keycloakClient *v1alpha1.KeycloakClient, mappings *v1alpha1.ClientMappingsRepresentation, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client client scope delete when client is nil")
 	}
-	return i.keycloakClient.DeleteClientClientScopeMappings(keycloakClient.Spec.Client, mappings, realm)
+	return i.keycloakClient.DeleteClientClientScopeMappings(i.context, keycloakClient.Spec.Client, mappings, realm)
 }
 
 // Delete a realm using the keycloak api
@@ -330,14 +522,14 @@ func (i *ClusterActionRunner) DeleteRealm(obj *v1alpha1.KeycloakRealm) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform realm delete when client is nil")
 	}
-	return i.keycloakClient.DeleteRealm(obj.Spec.Realm.Realm)
+	return i.keycloakClient.DeleteRealm(i.context, obj.Spec.Realm.Realm)
 }
 
 func (i *ClusterActionRunner) DeleteClient(obj *v1alpha1.KeycloakClient, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform client delete when client is nil")
 	}
-	return i.keycloakClient.DeleteClient(obj.Spec.Client.ID, realm)
+	return i.keycloakClient.DeleteClient(i.context, obj.Spec.Client.ID, realm)
 }
 
 // Check if Keycloak is available
@@ -345,7 +537,7 @@ func (i *ClusterActionRunner) Ping() error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform keycloak ping when client is nil")
 	}
-	return i.keycloakClient.Ping()
+	return i.keycloakClient.Ping(i.context)
 }
 
 func (i *ClusterActionRunner) AssignRealmRole(obj *v1alpha1.KeycloakUserRole, userID, realm string) error {
@@ -353,7 +545,7 @@ func (i *ClusterActionRunner) AssignRealmRole(obj *v1alpha1.KeycloakUserRole, us
 		return errors.Errorf("cannot perform role assign when client is nil")
 	}
 
-	_, err := i.keycloakClient.CreateUserRealmRole(obj, realm, userID)
+	_, err := i.keycloakClient.CreateUserRealmRole(i.context, obj, realm, userID)
 	return err
 }
 
@@ -361,7 +553,7 @@ func (i *ClusterActionRunner) RemoveRealmRole(obj *v1alpha1.KeycloakUserRole, us
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform role remove when client is nil")
 	}
-	return i.keycloakClient.DeleteUserRealmRole(obj, realm, userID)
+	return i.keycloakClient.DeleteUserRealmRole(i.context, obj, realm, userID)
 }
 
 func (i *ClusterActionRunner) AssignClientRole(obj *v1alpha1.KeycloakUserRole, clientID, userID, realm string) error {
@@ -369,7 +561,7 @@ func (i *ClusterActionRunner) AssignClientRole(obj *v1alpha1.KeycloakUserRole, c
 		return errors.Errorf("cannot perform role assign when client is nil")
 	}
 
-	_, err := i.keycloakClient.CreateUserClientRole(obj, realm, clientID, userID)
+	_, err := i.keycloakClient.CreateUserClientRole(i.context, obj, realm, clientID, userID)
 	return err
 }
 
@@ -377,21 +569,21 @@ func (i *ClusterActionRunner) RemoveClientRole(obj *v1alpha1.KeycloakUserRole, c
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform role remove when client is nil")
 	}
-	return i.keycloakClient.DeleteUserClientRole(obj, realm, clientID, userID)
+	return i.keycloakClient.DeleteUserClientRole(i.context, obj, realm, clientID, userID)
 }
 
 func (i *ClusterActionRunner) AddDefaultRoles(obj *[]v1alpha1.RoleRepresentation, defaultRealmRoleID, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform default role add when client is nil")
 	}
-	return i.keycloakClient.AddRealmRoleComposites(realm, defaultRealmRoleID, obj)
+	return i.keycloakClient.AddRealmRoleComposites(i.context, realm, defaultRealmRoleID, obj)
 }
 
 func (i *ClusterActionRunner) DeleteDefaultRoles(obj *[]v1alpha1.RoleRepresentation, defaultRealmRoleID, realm string) error {
 	if i.keycloakClient == nil {
 		return errors.Errorf("cannot perform default role delete when client is nil")
 	}
-	return i.keycloakClient.DeleteRealmRoleComposites(realm, defaultRealmRoleID, obj)
+	return i.keycloakClient.DeleteRealmRoleComposites(i.context, realm, defaultRealmRoleID, obj)
 }
 
 // An action to create generic kubernetes resources
@@ -455,6 +647,129 @@ type DeleteClientRoleAction struct {
 	Realm string
 }
 
+type CreateClientProtocolMapperAction struct {
+	Mapper *v1alpha1.KeycloakProtocolMapper
+	Ref    *v1alpha1.KeycloakClient
+	Msg    string
+	Realm  string
+}
+
+type UpdateClientProtocolMapperAction struct {
+	Mapper *v1alpha1.KeycloakProtocolMapper
+	Ref    *v1alpha1.KeycloakClient
+	Msg    string
+	Realm  string
+}
+
+type DeleteClientProtocolMapperAction struct {
+	Mapper *v1alpha1.KeycloakProtocolMapper
+	Ref    *v1alpha1.KeycloakClient
+	Msg    string
+	Realm  string
+}
+
+type RotateClientSecretAction struct {
+	Secret       *corev1.Secret
+	Data         model.ClientSecretTemplateData
+	KeepPrevious bool
+	Ref          *v1alpha1.KeycloakClient
+	Msg          string
+	Realm        string
+}
+
+type ApplyClientSecretTargetAction struct {
+	Secret         *corev1.Secret
+	Template       *v1alpha1.KeycloakClientSecretTemplate
+	Data           model.ClientSecretTemplateData
+	FollowRotation bool
+	Ref            *v1alpha1.KeycloakClient
+	Msg            string
+}
+
+type PublishClientInstallationsAction struct {
+	Target        client.Object
+	Installations []v1alpha1.KeycloakClientInstallation
+	Ref           *v1alpha1.KeycloakClient
+	Msg           string
+	Realm         string
+}
+
+type InvalidateClientRotatedSecretAction struct {
+	Ref   *v1alpha1.KeycloakClient
+	Msg   string
+	Realm string
+}
+
+type UpdateAuthorizationSettingsAction struct {
+	Settings *v1alpha1.KeycloakResourceServer
+	Ref      *v1alpha1.KeycloakClient
+	Msg      string
+	Realm    string
+}
+
+type CreateAuthorizationScopeAction struct {
+	Scope *v1alpha1.KeycloakScope
+	Ref   *v1alpha1.KeycloakClient
+	Msg   string
+	Realm string
+}
+
+type UpdateAuthorizationScopeAction struct {
+	Scope *v1alpha1.KeycloakScope
+	Ref   *v1alpha1.KeycloakClient
+	Msg   string
+	Realm string
+}
+
+type DeleteAuthorizationScopeAction struct {
+	Scope *v1alpha1.KeycloakScope
+	Ref   *v1alpha1.KeycloakClient
+	Msg   string
+	Realm string
+}
+
+type CreateAuthorizationResourceAction struct {
+	Resource *v1alpha1.KeycloakResource
+	Ref      *v1alpha1.KeycloakClient
+	Msg      string
+	Realm    string
+}
+
+type UpdateAuthorizationResourceAction struct {
+	Resource *v1alpha1.KeycloakResource
+	Ref      *v1alpha1.KeycloakClient
+	Msg      string
+	Realm    string
+}
+
+type DeleteAuthorizationResourceAction struct {
+	Resource *v1alpha1.KeycloakResource
+	Ref      *v1alpha1.KeycloakClient
+	Msg      string
+	Realm    string
+}
+
+type CreateAuthorizationPolicyAction struct {
+	Policy *v1alpha1.KeycloakPolicy
+	Ref    *v1alpha1.KeycloakClient
+	Msg    string
+	Realm  string
+}
+
+type UpdateAuthorizationPolicyAction struct {
+	Policy *v1alpha1.KeycloakPolicy
+	Ref    *v1alpha1.KeycloakClient
+	Msg    string
+	Realm  string
+}
+
+type DeleteAuthorizationPolicyAction struct {
+	Policy *v1alpha1.KeycloakPolicy
+	Ref    *v1alpha1.KeycloakClient
+	Msg    string
+	Realm  string
+}
+
 type AddDefaultRolesAction struct {
 	Roles              *[]v1alpha1.RoleRepresentation
 	DefaultRealmRoleID string
@@ -593,6 +908,74 @@ func (i DeleteClientRoleAction) Run(runner ActionRunner) (string, error) {
 	return i.Msg, runner.DeleteClientRole(i.Ref, i.Role.Name, i.Realm)
 }
 
+func (i CreateClientProtocolMapperAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.CreateClientProtocolMapper(i.Ref, i.Mapper, i.Realm)
+}
+
+func (i UpdateClientProtocolMapperAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.UpdateClientProtocolMapper(i.Ref, i.Mapper, i.Realm)
+}
+
+func (i DeleteClientProtocolMapperAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.DeleteClientProtocolMapper(i.Ref, i.Mapper, i.Realm)
+}
+
+func (i RotateClientSecretAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.RotateClientSecret(i.Ref, i.Secret, i.Data, i.KeepPrevious, i.Realm)
+}
+
+func (i ApplyClientSecretTargetAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.ApplyClientSecretTarget(i.Ref, i.Secret, i.Template, i.Data, i.FollowRotation)
+}
+
+func (i PublishClientInstallationsAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.PublishClientInstallations(i.Ref, i.Target, i.Installations, i.Realm)
+}
+
+func (i InvalidateClientRotatedSecretAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.InvalidateClientRotatedSecret(i.Ref, i.Realm)
+}
+
+func (i UpdateAuthorizationSettingsAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.UpdateAuthorizationSettings(i.Ref, i.Settings, i.Realm)
+}
+
+func (i CreateAuthorizationScopeAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.CreateAuthorizationScope(i.Ref, i.Scope, i.Realm)
+}
+
+func (i UpdateAuthorizationScopeAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.UpdateAuthorizationScope(i.Ref, i.Scope, i.Realm)
+}
+
+func (i DeleteAuthorizationScopeAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.DeleteAuthorizationScope(i.Ref, i.Scope, i.Realm)
+}
+
+func (i CreateAuthorizationResourceAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.CreateAuthorizationResource(i.Ref, i.Resource, i.Realm)
+}
+
+func (i UpdateAuthorizationResourceAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.UpdateAuthorizationResource(i.Ref, i.Resource, i.Realm)
+}
+
+func (i DeleteAuthorizationResourceAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.DeleteAuthorizationResource(i.Ref, i.Resource, i.Realm)
+}
+
+func (i CreateAuthorizationPolicyAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.CreateAuthorizationPolicy(i.Ref, i.Policy, i.Realm)
+}
+
+func (i UpdateAuthorizationPolicyAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.UpdateAuthorizationPolicy(i.Ref, i.Policy, i.Realm)
+}
+
+func (i DeleteAuthorizationPolicyAction) Run(runner ActionRunner) (string, error) {
+	return i.Msg, runner.DeleteAuthorizationPolicy(i.Ref, i.Policy, i.Realm)
+}
+
 func (i AddDefaultRolesAction) Run(runner ActionRunner) (string, error) {
 	return i.Msg, runner.AddDefaultRoles(i.Roles, i.DefaultRealmRoleID, i.Realm)
 }
@@ -0,0 +1,199 @@
+package common
+
+import (
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/movewp3/keycloakclient-controller/pkg/model"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PlanActions returns the descriptions of the actions of the desired state without running them, e.g. for a dry run.
+// The availability check of Keycloak changes nothing and is left out.
+func PlanActions(desiredState DesiredClusterState) []string {
+	runner := &PlanActionRunner{}
+	for _, action := range desiredState {
+		if _, ping := action.(PingAction); ping {
+			continue
+		}
+		// the runner changes nothing, so the actions cannot fail
+		msg, _ := action.Run(runner)
+		runner.Plan = append(runner.Plan, msg)
+	}
+	return runner.Plan
+}
+
+// PlanActionRunner runs no action at all, it only collects the descriptions of the actions in RunAll
+type PlanActionRunner struct {
+	Plan []string
+}
+
+var _ ActionRunner = &PlanActionRunner{}
+
+func (i *PlanActionRunner) RunAll(desiredState DesiredClusterState) error {
+	i.Plan = append(i.Plan, PlanActions(desiredState)...)
+	return nil
+}
+
+func (i *PlanActionRunner) Create(client.Object) error {
+	return nil
+}
+
+func (i *PlanActionRunner) Update(client.Object) error {
+	return nil
+}
+
+func (i *PlanActionRunner) Delete(client.Object) error {
+	return nil
+}
+
+func (i *PlanActionRunner) CreateClient(*v1alpha1.KeycloakClient, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteClient(*v1alpha1.KeycloakClient, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) UpdateClient(*v1alpha1.KeycloakClient, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) CreateClientRole(*v1alpha1.KeycloakClient, *v1alpha1.RoleRepresentation, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) UpdateClientRole(*v1alpha1.KeycloakClient, *v1alpha1.RoleRepresentation, *v1alpha1.RoleRepresentation, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteClientRole(*v1alpha1.KeycloakClient, string, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) CreateClientProtocolMapper(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakProtocolMapper, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) UpdateClientProtocolMapper(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakProtocolMapper, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteClientProtocolMapper(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakProtocolMapper, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) RotateClientSecret(*v1alpha1.KeycloakClient, *corev1.Secret, model.ClientSecretTemplateData, bool, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) InvalidateClientRotatedSecret(*v1alpha1.KeycloakClient, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) PublishClientInstallations(*v1alpha1.KeycloakClient, client.Object, []v1alpha1.KeycloakClientInstallation, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) ApplyClientSecretTarget(*v1alpha1.KeycloakClient, *corev1.Secret, *v1alpha1.KeycloakClientSecretTemplate, model.ClientSecretTemplateData, bool) error {
+	return nil
+}
+
+func (i *PlanActionRunner) UpdateAuthorizationSettings(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakResourceServer, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) CreateAuthorizationScope(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakScope, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) UpdateAuthorizationScope(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakScope, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteAuthorizationScope(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakScope, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) CreateAuthorizationResource(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakResource, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) UpdateAuthorizationResource(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakResource, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteAuthorizationResource(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakResource, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) CreateAuthorizationPolicy(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakPolicy, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) UpdateAuthorizationPolicy(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakPolicy, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteAuthorizationPolicy(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakPolicy, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) CreateClientRealmScopeMappings(*v1alpha1.KeycloakClient, *[]v1alpha1.RoleRepresentation, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteClientRealmScopeMappings(*v1alpha1.KeycloakClient, *[]v1alpha1.RoleRepresentation, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) CreateClientClientScopeMappings(*v1alpha1.KeycloakClient, *v1alpha1.ClientMappingsRepresentation, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteClientClientScopeMappings(*v1alpha1.KeycloakClient, *v1alpha1.ClientMappingsRepresentation, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) UpdateClientDefaultClientScope(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakClientScope, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteClientDefaultClientScope(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakClientScope, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) UpdateClientOptionalClientScope(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakClientScope, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteClientOptionalClientScope(*v1alpha1.KeycloakClient, *v1alpha1.KeycloakClientScope, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) AssignRealmRole(*v1alpha1.KeycloakUserRole, string, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) RemoveRealmRole(*v1alpha1.KeycloakUserRole, string, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) AssignClientRole(*v1alpha1.KeycloakUserRole, string, string, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) RemoveClientRole(*v1alpha1.KeycloakUserRole, string, string, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) AddDefaultRoles(*[]v1alpha1.RoleRepresentation, string, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) DeleteDefaultRoles(*[]v1alpha1.RoleRepresentation, string, string) error {
+	return nil
+}
+
+func (i *PlanActionRunner) Ping() error {
+	return nil
+}
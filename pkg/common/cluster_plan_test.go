@@ -0,0 +1,24 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanActions(t *testing.T) {
+	// given
+	cr := &v1alpha1.KeycloakClient{}
+	desiredState := DesiredClusterState{
+		PingAction{Msg: "check if keycloak is available"},
+		&CreateClientAction{Ref: cr, Realm: "test", Msg: "create client test/app"},
+		&CreateClientRoleAction{Ref: cr, Role: &v1alpha1.RoleRepresentation{Name: "read"}, Realm: "test", Msg: "create client role read"},
+	}
+
+	// when
+	plan := PlanActions(desiredState)
+
+	// then
+	assert.Equal(t, []string{"create client test/app", "create client role read"}, plan)
+}
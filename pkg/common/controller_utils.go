@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -9,6 +10,7 @@ import (
 	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -80,3 +82,19 @@ func GetMatchingRealms(ctx context.Context, c client.Client, labelSelector *v1.L
 	err := c.List(ctx, &list, opts...)
 	return list, err
 }
+
+// UpdateKeycloakClientFinalizers writes the finalizers of the CR. The controller never writes the spec of a
+// KeycloakClient, which is owned by its users or GitOps tools and changed in memory during a reconcile, e.g. with the
+// secret of the client.
+func UpdateKeycloakClientFinalizers(ctx context.Context, c client.Client, cr *v1alpha1.KeycloakClient) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"finalizers":      cr.Finalizers,
+			"resourceVersion": cr.ResourceVersion,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return c.Patch(ctx, cr.DeepCopy(), client.RawPatch(types.MergePatchType, patch))
+}
@@ -0,0 +1,127 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxErrorMessageLength limits how much of a response body which is not a Keycloak error representation
+// ends up in error messages
+const maxErrorMessageLength = 256
+
+// KeycloakAPIError is returned if the Keycloak admin API answers a request with an unexpected status
+type KeycloakAPIError struct {
+	// Operation which failed, e.g. "create" or "GET"
+	Operation string
+	// Name of the resource the operation has been performed on
+	ResourceName string
+	// HTTP method and path of the request
+	Method string
+	Path   string
+	// HTTP status of the response
+	StatusCode int
+	Status     string
+	// Error message returned by Keycloak, if any
+	ErrorMessage string
+}
+
+func (e *KeycloakAPIError) Error() string {
+	msg := fmt.Sprintf("failed to %s %s: (%d) %s", e.Operation, e.ResourceName, e.StatusCode, e.Status)
+	if e.ErrorMessage != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.ErrorMessage)
+	}
+	return msg
+}
+
+// keycloakErrorBody covers the error representations of the admin API and the OpenID Connect endpoints
+type keycloakErrorBody struct {
+	ErrorMessage     string `json:"errorMessage"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// newKeycloakAPIError builds a KeycloakAPIError from an unexpected response and decodes its body
+func newKeycloakAPIError(res *http.Response, operation, resourceName string) *KeycloakAPIError {
+	apiErr := &KeycloakAPIError{
+		Operation:    operation,
+		ResourceName: resourceName,
+		StatusCode:   res.StatusCode,
+		Status:       res.Status,
+	}
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.Path = res.Request.URL.Path
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil || len(body) == 0 {
+		return apiErr
+	}
+	apiErr.ErrorMessage = decodeErrorMessage(body)
+	return apiErr
+}
+
+func decodeErrorMessage(body []byte) string {
+	errorBody := &keycloakErrorBody{}
+	if err := json.Unmarshal(body, errorBody); err == nil {
+		switch {
+		case errorBody.ErrorMessage != "":
+			return errorBody.ErrorMessage
+		case errorBody.ErrorDescription != "":
+			return errorBody.ErrorDescription
+		case errorBody.Error != "":
+			return errorBody.Error
+		}
+	}
+
+	msg := strings.TrimSpace(string(body))
+	if len(msg) > maxErrorMessageLength {
+		msg = msg[:maxErrorMessageLength] + "..."
+	}
+	return msg
+}
+
+// keycloakAPIErrorStatus returns the HTTP status code of a KeycloakAPIError in the chain of err, or 0
+func keycloakAPIErrorStatus(err error) int {
+	var apiErr *KeycloakAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsConflict returns true if Keycloak rejected a request because the resource already exists
+func IsConflict(err error) bool {
+	return keycloakAPIErrorStatus(err) == http.StatusConflict
+}
+
+// IsNotFound returns true if Keycloak reported the requested resource as not existing
+func IsNotFound(err error) bool {
+	return keycloakAPIErrorStatus(err) == http.StatusNotFound
+}
+
+// IsUnauthorized returns true if Keycloak rejected the credentials or the token of the controller
+func IsUnauthorized(err error) bool {
+	return keycloakAPIErrorStatus(err) == http.StatusUnauthorized
+}
+
+// IsForbidden returns true if the controller lacks the permissions for a request
+func IsForbidden(err error) bool {
+	return keycloakAPIErrorStatus(err) == http.StatusForbidden
+}
+
+// IsBadRequest returns true if Keycloak rejected the content of a request, e.g. an invalid client representation
+func IsBadRequest(err error) bool {
+	return keycloakAPIErrorStatus(err) == http.StatusBadRequest
+}
+
+// IsPermanentError returns true for errors that will not go away by retrying the same request, but require
+// a change of the resource or of the permissions of the controller
+func IsPermanentError(err error) bool {
+	return IsBadRequest(err) || IsForbidden(err)
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeycloakAPIError_createConflict(t *testing.T) {
+	// given
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(409)
+		_, err := w.Write([]byte(`{"errorMessage":"Client dummy already exists"}`))
+		assert.NoError(t, err)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "dummy",
+	}
+
+	// when
+	_, err := client.CreateRealm(context.TODO(), getDummyRealm())
+
+	// then
+	assert.Error(t, err)
+	assert.True(t, IsConflict(err))
+	assert.False(t, IsNotFound(err))
+
+	apiErr := &KeycloakAPIError{}
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "POST", apiErr.Method)
+	assert.Equal(t, RealmsCreatePath, apiErr.Path)
+	assert.Equal(t, "Client dummy already exists", apiErr.ErrorMessage)
+	assert.Equal(t, "failed to create realm: (409) 409 Conflict: Client dummy already exists", err.Error())
+}
+
+func TestKeycloakAPIError_categories(t *testing.T) {
+	forbidden := errors.Wrap(&KeycloakAPIError{StatusCode: 403}, "wrapped")
+	notFound := &KeycloakAPIError{StatusCode: 404}
+	badRequest := &KeycloakAPIError{StatusCode: 400}
+	unavailable := &KeycloakAPIError{StatusCode: 503}
+	unauthorized := &KeycloakAPIError{StatusCode: 401}
+
+	assert.True(t, IsForbidden(forbidden))
+	assert.True(t, IsPermanentError(forbidden))
+	assert.True(t, IsNotFound(notFound))
+	assert.False(t, IsPermanentError(notFound))
+	assert.True(t, IsBadRequest(badRequest))
+	assert.True(t, IsPermanentError(badRequest))
+	assert.False(t, IsPermanentError(unavailable))
+	assert.True(t, IsUnauthorized(unauthorized))
+	assert.False(t, IsUnauthorized(forbidden))
+	assert.False(t, IsConflict(errors.New("failed to create client: (409) 409 Conflict")))
+}
+
+func TestKeycloakAPIError_decodeErrorMessage(t *testing.T) {
+	assert.Equal(t, "invalid redirect uri", decodeErrorMessage([]byte(`{"errorMessage":"invalid redirect uri"}`)))
+	assert.Equal(t, "Invalid client credentials", decodeErrorMessage([]byte(`{"error":"unauthorized_client","error_description":"Invalid client credentials"}`)))
+	assert.Equal(t, "unknown_error", decodeErrorMessage([]byte(`{"error":"unknown_error"}`)))
+	assert.Equal(t, "Internal Server Error", decodeErrorMessage([]byte("Internal Server Error\n")))
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsNamespace = "keycloakclient_controller"
+
+var (
+	keycloakRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "keycloak_requests_total",
+		Help:      "Number of requests sent to the Keycloak admin API and token endpoint.",
+	}, []string{"keycloak", "method", "resource", "code"})
+
+	keycloakRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "keycloak_request_duration_seconds",
+		Help:      "Latency of requests sent to the Keycloak admin API and token endpoint, including retries.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"keycloak", "method", "resource", "code"})
+
+	keycloakLoginFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "keycloak_login_failures_total",
+		Help:      "Number of failed logins of the controller against Keycloak.",
+	}, []string{"keycloak", "grant_type"})
+
+	clientDriftTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "client_drift_total",
+		Help:      "Number of reconciles which found a client in Keycloak differing from its KeycloakClient.",
+	}, []string{"keycloak", "realm", "policy"})
+)
+
+func init() {
+	// register with the registry of controller-runtime, which is served on the metrics endpoint of the manager
+	metrics.Registry.MustRegister(keycloakRequestsTotal, keycloakRequestDuration, keycloakLoginFailuresTotal, clientDriftTotal)
+}
+
+// observeRequest records a request against Keycloak. Requests which did not result in a response are
+// recorded with the code "error".
+func observeRequest(keycloak, method, resourceName string, res *http.Response, err error, duration time.Duration) {
+	code := "error"
+	if err == nil && res != nil {
+		code = strconv.Itoa(res.StatusCode)
+	}
+	resource := strings.ReplaceAll(resourceName, " ", "-")
+
+	keycloakRequestsTotal.WithLabelValues(keycloak, method, resource, code).Inc()
+	keycloakRequestDuration.WithLabelValues(keycloak, method, resource, code).Observe(duration.Seconds())
+}
+
+func observeLoginFailure(keycloak, grantType string) {
+	keycloakLoginFailuresTotal.WithLabelValues(keycloak, grantType).Inc()
+}
+
+// ObserveClientDrift records a client in a realm of a Keycloak which differed from its KeycloakClient
+func ObserveClientDrift(keycloak, realm, policy string) {
+	clientDriftTotal.WithLabelValues(keycloak, realm, policy).Inc()
+}
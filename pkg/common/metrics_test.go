@@ -0,0 +1,78 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	metric := &dto.Metric{}
+	assert.NoError(t, counter.Write(metric))
+	return metric.GetCounter().GetValue()
+}
+
+func TestMetrics_requests(t *testing.T) {
+	// given
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(409)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		keycloak:    "ns/metrics-requests",
+		token:       "dummy",
+	}
+	conflicts := keycloakRequestsTotal.WithLabelValues("ns/metrics-requests", "POST", "realm", "409")
+	before := counterValue(t, conflicts)
+
+	// when
+	_, err := client.CreateRealm(context.TODO(), getDummyRealm())
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, before+1, counterValue(t, conflicts))
+}
+
+func TestMetrics_loginFailures(t *testing.T) {
+	// given
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(401)
+		_, err := w.Write([]byte(`{"error":"unauthorized_client","error_description":"Invalid client secret"}`))
+		assert.NoError(t, err)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		keycloak:    "ns/metrics-login",
+	}
+	failures := keycloakLoginFailuresTotal.WithLabelValues("ns/metrics-login", "client_credentials")
+	tokenRequests := keycloakRequestsTotal.WithLabelValues("ns/metrics-login", "POST", "token", "401")
+
+	// when
+	err := client.login(context.TODO(), "dummy", "dummy")
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, 1.0, counterValue(t, failures))
+	assert.Equal(t, 1.0, counterValue(t, tokenRequests))
+}
+
+func TestMetrics_resourceLabel(t *testing.T) {
+	observeRequest("ns/metrics-label", "GET", "client default client scope", nil, context.Canceled, 0)
+
+	assert.Equal(t, 1.0, counterValue(t, keycloakRequestsTotal.WithLabelValues("ns/metrics-label", "GET", "client-default-client-scope", "error")))
+}
@@ -23,7 +23,7 @@ func NewRealmState(context context.Context, keycloak kc.Keycloak) *RealmState {
 }
 
 func (i *RealmState) Read(cr *kc.KeycloakRealm, realmClient KeycloakInterface, controllerClient client.Client) error {
-	realm, err := realmClient.GetRealm(cr.Spec.Realm.Realm)
+	realm, err := realmClient.GetRealm(i.Context, cr.Spec.Realm.Realm)
 	if err != nil {
 		i.Realm = nil
 		return err
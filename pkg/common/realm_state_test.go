@@ -0,0 +1,33 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey string
+
+func TestRealmState_ReadPassesContext(t *testing.T) {
+	// given
+	ctx := context.WithValue(context.Background(), contextKey("reconcile"), "dummy")
+	realm := getDummyRealm()
+	keycloakClient := &KeycloakInterfaceMock{
+		GetRealmFunc: func(ctx context.Context, realmName string) (*v1alpha1.KeycloakRealm, error) {
+			return realm, nil
+		},
+	}
+	state := NewRealmState(ctx, v1alpha1.Keycloak{})
+
+	// when
+	err := state.Read(realm, keycloakClient, nil)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, realm, state.Realm)
+	assert.Len(t, keycloakClient.GetRealmCalls(), 1)
+	assert.Equal(t, "dummy", keycloakClient.GetRealmCalls()[0].Ctx.Value(contextKey("reconcile")))
+	assert.Equal(t, "dummy", keycloakClient.GetRealmCalls()[0].RealmName)
+}
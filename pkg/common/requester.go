@@ -0,0 +1,180 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultRequestTimeout is the timeout of a single request against Keycloak if none is configured
+	DefaultRequestTimeout = 10 * time.Second
+	// DefaultMaxRetries is the number of retries of a failed request if none is configured
+	DefaultMaxRetries = 3
+
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// retryingRequester retries requests which failed because Keycloak is temporarily unavailable, e.g. during a
+// rolling restart, and limits the rate of requests sent to a Keycloak instance.
+type retryingRequester struct {
+	requester  Requester
+	maxRetries int
+	// limiter is nil if the rate of requests is not limited
+	limiter *rate.Limiter
+	// sleep waits for the given delay unless the request is cancelled before
+	sleep func(req *http.Request, delay time.Duration) error
+}
+
+// newRetryingRequester wraps a requester according to the admin API settings of a Keycloak CR
+func newRetryingRequester(requester Requester, settings v1alpha1.KeycloakAdminAPI) *retryingRequester {
+	maxRetries := DefaultMaxRetries
+	if settings.MaxRetries != nil {
+		maxRetries = int(*settings.MaxRetries)
+	}
+
+	var limiter *rate.Limiter
+	if settings.RequestsPerSecond > 0 {
+		burst := int(settings.Burst)
+		if burst <= 0 {
+			burst = int(settings.RequestsPerSecond)
+		}
+		limiter = rate.NewLimiter(rate.Limit(settings.RequestsPerSecond), burst)
+	}
+
+	return &retryingRequester{
+		requester:  requester,
+		maxRetries: maxRetries,
+		limiter:    limiter,
+		sleep:      sleepWithContext,
+	}
+}
+
+func (r *retryingRequester) Do(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		if r.limiter != nil {
+			if err := r.limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := r.requester.Do(attemptReq)
+		delay, retry := r.retryDelay(req, res, err, attempt)
+		if !retry {
+			return res, err
+		}
+
+		if err != nil {
+			log.Info(fmt.Sprintf("%s %s failed, retrying in %v: %s", req.Method, req.URL.Path, delay, err))
+		} else {
+			log.Info(fmt.Sprintf("%s %s failed with %s, retrying in %v", req.Method, req.URL.Path, res.Status, delay))
+			// drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		if err := r.sleep(req, delay); err != nil {
+			return nil, err
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			attemptReq.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// retryDelay decides whether a failed attempt is retried and how long to wait before
+func (r *retryingRequester) retryDelay(req *http.Request, res *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= r.maxRetries || req.Context().Err() != nil {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// the body has been consumed and cannot be sent again
+		return 0, false
+	}
+
+	if err != nil {
+		return backoff(attempt), isIdempotent(req.Method)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		// the request has not been processed, so even non-idempotent requests can be retried
+		return retryAfter(res, attempt), true
+	case http.StatusServiceUnavailable:
+		return retryAfter(res, attempt), isIdempotent(req.Method)
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return backoff(attempt), isIdempotent(req.Method)
+	default:
+		return 0, false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns an exponentially growing delay with jitter, so that concurrent reconciles do not retry in lockstep
+func backoff(attempt int) time.Duration {
+	delay := retryMaxDelay
+	if attempt < 16 {
+		delay = retryBaseDelay << uint(attempt)
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1)) // nolint
+}
+
+// retryAfter returns the delay requested by the Retry-After header of a response, if present
+func retryAfter(res *http.Response, attempt int) time.Duration {
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return backoff(attempt)
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		delay = time.Until(date)
+	} else {
+		return backoff(attempt)
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+func sleepWithContext(req *http.Request, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
@@ -0,0 +1,157 @@
+package common
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedRequester answers the requests in order with the given responses
+type scriptedRequester struct {
+	responses []func() (*http.Response, error)
+	requests  []*http.Request
+	bodies    []string
+}
+
+func (s *scriptedRequester) Do(req *http.Request) (*http.Response, error) {
+	s.requests = append(s.requests, req)
+	body := ""
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		body = string(b)
+	}
+	s.bodies = append(s.bodies, body)
+	return s.responses[len(s.requests)-1]()
+}
+
+func respond(status int, header http.Header) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	}
+}
+
+func newTestRequester(requester Requester, maxRetries int32) (*retryingRequester, *[]time.Duration) {
+	delays := &[]time.Duration{}
+	r := newRetryingRequester(requester, v1alpha1.KeycloakAdminAPI{MaxRetries: &maxRetries})
+	r.sleep = func(req *http.Request, delay time.Duration) error {
+		*delays = append(*delays, delay)
+		return nil
+	}
+	return r, delays
+}
+
+func TestRequester_retriesIdempotentRequests(t *testing.T) {
+	// given
+	scripted := &scriptedRequester{responses: []func() (*http.Response, error){
+		respond(502, nil),
+		func() (*http.Response, error) { return nil, errors.New("connection refused") },
+		respond(200, nil),
+	}}
+	requester, delays := newTestRequester(scripted, 3)
+	req, err := http.NewRequest("PUT", "http://keycloak/admin/realms/dummy", strings.NewReader("dummy"))
+	assert.NoError(t, err)
+
+	// when
+	res, err := requester.Do(req)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+	assert.Len(t, scripted.requests, 3)
+	assert.Equal(t, []string{"dummy", "dummy", "dummy"}, scripted.bodies)
+	assert.Len(t, *delays, 2)
+	assert.True(t, (*delays)[0] >= retryBaseDelay/2 && (*delays)[0] <= retryBaseDelay)
+	assert.True(t, (*delays)[1] >= retryBaseDelay && (*delays)[1] <= 2*retryBaseDelay)
+}
+
+func TestRequester_doesNotRetryPostOnServerError(t *testing.T) {
+	// given
+	scripted := &scriptedRequester{responses: []func() (*http.Response, error){
+		respond(503, nil),
+	}}
+	requester, _ := newTestRequester(scripted, 3)
+	req, err := http.NewRequest("POST", "http://keycloak/admin/realms", strings.NewReader("dummy"))
+	assert.NoError(t, err)
+
+	// when
+	res, err := requester.Do(req)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 503, res.StatusCode)
+	assert.Len(t, scripted.requests, 1)
+}
+
+func TestRequester_honoursRetryAfter(t *testing.T) {
+	// given
+	scripted := &scriptedRequester{responses: []func() (*http.Response, error){
+		respond(429, http.Header{"Retry-After": []string{"2"}}),
+		respond(201, nil),
+	}}
+	requester, delays := newTestRequester(scripted, 3)
+	req, err := http.NewRequest("POST", "http://keycloak/admin/realms", strings.NewReader("dummy"))
+	assert.NoError(t, err)
+
+	// when
+	res, err := requester.Do(req)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 201, res.StatusCode)
+	assert.Equal(t, []time.Duration{2 * time.Second}, *delays)
+}
+
+func TestRequester_givesUpAfterMaxRetries(t *testing.T) {
+	// given
+	scripted := &scriptedRequester{responses: []func() (*http.Response, error){
+		respond(504, nil),
+		respond(504, nil),
+		respond(504, nil),
+	}}
+	requester, delays := newTestRequester(scripted, 2)
+	req, err := http.NewRequest("GET", "http://keycloak/admin/realms", nil)
+	assert.NoError(t, err)
+
+	// when
+	res, err := requester.Do(req)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 504, res.StatusCode)
+	assert.Len(t, scripted.requests, 3)
+	assert.Len(t, *delays, 2)
+}
+
+func TestRequester_rateLimit(t *testing.T) {
+	// given
+	requester := newRetryingRequester(&scriptedRequester{}, v1alpha1.KeycloakAdminAPI{RequestsPerSecond: 5, Burst: 2})
+
+	// then
+	assert.NotNil(t, requester.limiter)
+	assert.Equal(t, 5.0, float64(requester.limiter.Limit()))
+	assert.Equal(t, 2, requester.limiter.Burst())
+	assert.Equal(t, DefaultMaxRetries, requester.maxRetries)
+	assert.Nil(t, newRetryingRequester(&scriptedRequester{}, v1alpha1.KeycloakAdminAPI{}).limiter)
+}
+
+func TestRequester_retryAfter(t *testing.T) {
+	date := &http.Response{Header: http.Header{"Retry-After": []string{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}}}
+	seconds := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+
+	assert.Equal(t, retryMaxDelay, retryAfter(date, 0))
+	assert.Equal(t, 3*time.Second, retryAfter(seconds, 0))
+}
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+)
+
+// ApplyServerInfo stores the version, the enabled features and the available protocol mapper types of a Keycloak
+// server in the status of its Keycloak CR
+func ApplyServerInfo(status *v1alpha1.KeycloakStatus, serverInfo *v1alpha1.KeycloakServerInfo) {
+	status.Version = serverInfo.SystemInfo.Version
+	status.Features = enabledFeatures(serverInfo)
+
+	status.ProtocolMapperTypes = nil
+	for protocol, mapperTypes := range serverInfo.ProtocolMapperTypes {
+		if status.ProtocolMapperTypes == nil {
+			status.ProtocolMapperTypes = map[string][]string{}
+		}
+		ids := []string{}
+		for _, mapperType := range mapperTypes {
+			ids = append(ids, mapperType.ID)
+		}
+		sort.Strings(ids)
+		status.ProtocolMapperTypes[protocol] = ids
+	}
+}
+
+// enabledFeatures returns the names of the enabled features. Keycloak 24 and newer report all features with their
+// state, older versions only report the disabled ones besides the preview and experimental features.
+func enabledFeatures(serverInfo *v1alpha1.KeycloakServerInfo) []string {
+	var features []string
+	if len(serverInfo.Features) > 0 {
+		for _, feature := range serverInfo.Features {
+			if feature.Enabled {
+				features = append(features, feature.Name)
+			}
+		}
+	} else {
+		disabled := map[string]bool{}
+		for _, feature := range serverInfo.ProfileInfo.DisabledFeatures {
+			disabled[feature] = true
+		}
+		for _, feature := range append(append([]string{}, serverInfo.ProfileInfo.PreviewFeatures...), serverInfo.ProfileInfo.ExperimentalFeatures...) {
+			if !disabled[feature] {
+				features = append(features, feature)
+			}
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// KeycloakMajorVersion returns the major version of the Keycloak server detected by the Keycloak controller,
+// e.g. 25 for "25.0.6". Returns false if the version is unknown, e.g. before the server info has been read.
+func KeycloakMajorVersion(kc v1alpha1.Keycloak) (int, bool) {
+	major, _, _ := strings.Cut(kc.Status.Version, ".")
+	version, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, false
+	}
+	return version, true
+}
+
+// KeycloakVersionAtLeast returns true if the detected version of the Keycloak server is at least the given major
+// version. Servers of unknown version are treated like older versions.
+func KeycloakVersionAtLeast(kc v1alpha1.Keycloak, major int) bool {
+	version, ok := KeycloakMajorVersion(kc)
+	return ok && version >= major
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyServerInfo(t *testing.T) {
+	// given
+	status := &v1alpha1.KeycloakStatus{Version: "main"}
+	serverInfo := &v1alpha1.KeycloakServerInfo{
+		SystemInfo: v1alpha1.KeycloakSystemInfo{Version: "25.0.6"},
+		Features: []v1alpha1.KeycloakFeature{
+			{Name: "TOKEN_EXCHANGE", Enabled: true},
+			{Name: "ADMIN_FINE_GRAINED_AUTHZ", Enabled: false},
+			{Name: "AUTHORIZATION", Enabled: true},
+		},
+		ProtocolMapperTypes: map[string][]v1alpha1.KeycloakProtocolMapperType{
+			"openid-connect": {{ID: "oidc-usermodel-attribute-mapper"}, {ID: "oidc-audience-mapper"}},
+		},
+	}
+
+	// when
+	ApplyServerInfo(status, serverInfo)
+
+	// then
+	assert.Equal(t, "25.0.6", status.Version)
+	assert.Equal(t, []string{"AUTHORIZATION", "TOKEN_EXCHANGE"}, status.Features)
+	assert.Equal(t, map[string][]string{"openid-connect": {"oidc-audience-mapper", "oidc-usermodel-attribute-mapper"}}, status.ProtocolMapperTypes)
+}
+
+func TestApplyServerInfo_ProfileInfo(t *testing.T) {
+	// given
+	status := &v1alpha1.KeycloakStatus{}
+	serverInfo := &v1alpha1.KeycloakServerInfo{
+		SystemInfo: v1alpha1.KeycloakSystemInfo{Version: "21.1.2"},
+		ProfileInfo: v1alpha1.KeycloakProfileInfo{
+			DisabledFeatures:     []string{"ADMIN_FINE_GRAINED_AUTHZ", "DECLARATIVE_USER_PROFILE"},
+			PreviewFeatures:      []string{"ADMIN_FINE_GRAINED_AUTHZ", "TOKEN_EXCHANGE"},
+			ExperimentalFeatures: []string{"DECLARATIVE_USER_PROFILE"},
+		},
+	}
+
+	// when
+	ApplyServerInfo(status, serverInfo)
+
+	// then
+	assert.Equal(t, "21.1.2", status.Version)
+	assert.Equal(t, []string{"TOKEN_EXCHANGE"}, status.Features)
+	assert.Nil(t, status.ProtocolMapperTypes)
+}
+
+func TestKeycloakVersionAtLeast(t *testing.T) {
+	keycloak := func(version string) v1alpha1.Keycloak {
+		return v1alpha1.Keycloak{Status: v1alpha1.KeycloakStatus{Version: version}}
+	}
+
+	assert.True(t, KeycloakVersionAtLeast(keycloak("25.0.6"), 25))
+	assert.True(t, KeycloakVersionAtLeast(keycloak("26.0.0"), 25))
+	assert.True(t, KeycloakVersionAtLeast(keycloak("25"), 25))
+	assert.False(t, KeycloakVersionAtLeast(keycloak("24.0.5.redhat-00001"), 25))
+	assert.False(t, KeycloakVersionAtLeast(keycloak("main"), 25))
+	assert.False(t, KeycloakVersionAtLeast(keycloak(""), 25))
+}
@@ -0,0 +1,122 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/movewp3/keycloakclient-controller/version"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName  = "github.com/movewp3/keycloakclient-controller"
+	serviceName = "keycloakclient-controller"
+
+	// Attributes of the spans created by the operator
+	AttributeNamespace = attribute.Key("keycloak.cr.namespace")
+	AttributeName      = attribute.Key("keycloak.cr.name")
+	AttributeKind      = attribute.Key("keycloak.cr.kind")
+	AttributeRealm     = attribute.Key("keycloak.realm")
+	AttributeEndpoint  = attribute.Key("keycloak.endpoint")
+	AttributeResource  = attribute.Key("keycloak.resource")
+)
+
+// SetupTracing exports the spans of the operator via OTLP/HTTP to the given endpoint, e.g. http://otel-collector:4318.
+// If no endpoint is given, the standard OTEL_EXPORTER_OTLP_ENDPOINT and OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+// environment variables are used. Tracing is disabled if none of them is set.
+// The returned function flushes the remaining spans and must be called on shutdown.
+func SetupTracing(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	var options []otlptracehttp.Option
+	if endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpointURL(endpoint))
+	} else if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating trace exporter")
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.Version),
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating trace resource")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span as child of the span in the context, if any. Spans are only recorded if tracing has
+// been set up, otherwise the global no-op tracer provider is used.
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan ends a span and records the error it ended with
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// startRequestSpan starts a client span for a request against the admin API and returns the request with the
+// context of the span, so that the requests of a token refresh triggered by the request become its children
+func (c *Client) startRequestSpan(req *http.Request, resourceName string) (*http.Request, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(req.Context(), fmt.Sprintf("%s %s", req.Method, resourceName),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLPath(req.URL.Path),
+			AttributeEndpoint.String(c.URL),
+			AttributeResource.String(resourceName),
+		))
+	return req.WithContext(ctx), span
+}
+
+func endRequestSpan(span trace.Span, res *http.Response, err error) {
+	if err == nil && res != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+		if res.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, res.Status)
+		}
+	}
+	EndSpan(span, err)
+}
+
+// actionName returns the name of the span of an action, e.g. GenericCreateAction
+func actionName(action ClusterAction) string {
+	name := fmt.Sprintf("%T", action)
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// RecordSpanError marks the span in the context as failed, e.g. the span of a reconcile which ends without error
+// because the failure is reported in the status of the CR
+func RecordSpanError(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
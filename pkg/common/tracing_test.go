@@ -0,0 +1,174 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans records the spans of a test in memory
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+type tracedAction struct {
+	err error
+}
+
+func (a tracedAction) Run(runner ActionRunner) (string, error) {
+	_, span := StartSpan(runner.(*ClusterActionRunner).context, "request")
+	span.End()
+	return "traced action", a.err
+}
+
+func TestTracing_requests(t *testing.T) {
+	// given
+	recorder := recordSpans(t)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(409)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+		token:       "dummy",
+	}
+	ctx, parent := StartSpan(context.TODO(), "reconcile")
+
+	// when
+	_, err := client.CreateRealm(ctx, getDummyRealm())
+	parent.End()
+
+	// then
+	assert.Error(t, err)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "POST realm", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, server.URL, spanAttribute(span, AttributeEndpoint).AsString())
+	assert.Equal(t, "POST", spanAttribute(span, "http.request.method").AsString())
+	assert.Equal(t, "/auth/admin/realms", spanAttribute(span, "url.path").AsString())
+	assert.Equal(t, int64(409), spanAttribute(span, "http.response.status_code").AsInt64())
+	assert.Equal(t, codes.Error, span.Status().Code)
+}
+
+func TestTracing_tokenRequests(t *testing.T) {
+	// given
+	recorder := recordSpans(t)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, err := w.Write([]byte(`{"access_token":"dummy","expires_in":300}`))
+		assert.NoError(t, err)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := Client{
+		requester:   server.Client(),
+		URL:         server.URL,
+		contextPath: LegacyContextPath,
+	}
+
+	// when
+	err := client.login_admin(context.TODO(), "dummy", "dummy")
+
+	// then
+	assert.NoError(t, err)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "POST token", spans[0].Name())
+	assert.Equal(t, int64(200), spanAttribute(spans[0], "http.response.status_code").AsInt64())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+}
+
+func TestTracing_actions(t *testing.T) {
+	// given
+	recorder := recordSpans(t)
+	ctx, parent := StartSpan(context.TODO(), "reconcile")
+	runner := NewClusterActionRunner(ctx, nil, nil, nil)
+	desiredState := DesiredClusterState{
+		tracedAction{},
+		tracedAction{err: errors.New("failed")},
+	}
+
+	// when
+	err := runner.RunAll(desiredState)
+	parent.End()
+
+	// then
+	assert.Error(t, err)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 5)
+
+	// the spans of the requests of an action are children of the span of the action
+	assert.Equal(t, "request", spans[0].Name())
+	assert.Equal(t, "tracedAction", spans[1].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[1].Parent().SpanID())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+
+	assert.Equal(t, "tracedAction", spans[3].Name())
+	assert.Equal(t, spans[3].SpanContext().SpanID(), spans[2].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[3].Status().Code)
+
+	// the context of the runner is restored after an action
+	assert.Equal(t, ctx, runner.(*ClusterActionRunner).context)
+}
+
+func TestTracing_userState(t *testing.T) {
+	// given
+	recorder := recordSpans(t)
+	keycloakClient := &KeycloakInterfaceMock{
+		ListUserRealmRolesFunc: func(ctx context.Context, realmName string, userID string) ([]*v1alpha1.KeycloakUserRole, error) {
+			return nil, nil
+		},
+		ListAvailableUserRealmRolesFunc: func(ctx context.Context, realmName string, userID string) ([]*v1alpha1.KeycloakUserRole, error) {
+			return nil, nil
+		},
+		ListClientsFunc: func(ctx context.Context, realmName string) ([]*v1alpha1.KeycloakAPIClient, error) {
+			return nil, errors.New("unavailable")
+		},
+	}
+	realm := getDummyRealm()
+	state := NewUserState(v1alpha1.Keycloak{})
+
+	// when
+	err := state.ReadWithExistingAPIUser(context.TODO(), keycloakClient, nil, &v1alpha1.KeycloakAPIUser{ID: "user"}, *realm)
+
+	// then
+	assert.Error(t, err)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "UserState.Read", spans[0].Name())
+	assert.Equal(t, realm.Spec.Realm.Realm, spanAttribute(spans[0], AttributeRealm).AsString())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, spans[0].SpanContext(), trace.SpanContextFromContext(state.Context))
+}
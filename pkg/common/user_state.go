@@ -30,15 +30,20 @@ func NewUserState(keycloak v1alpha1.Keycloak) *UserState {
 	}
 }
 
-func (i *UserState) ReadWithExistingAPIUser(keycloakClient KeycloakInterface, userClient client.Client, user *v1alpha1.KeycloakAPIUser, realm v1alpha1.KeycloakRealm) error {
+func (i *UserState) ReadWithExistingAPIUser(context context.Context, keycloakClient KeycloakInterface, userClient client.Client, user *v1alpha1.KeycloakAPIUser, realm v1alpha1.KeycloakRealm) (err error) {
 	// Don't continue if the user could not be found
 	if user == nil {
 		return nil
 	}
 
+	// the role scans issue two requests per client of the realm
+	context, span := StartSpan(context, "UserState.Read", AttributeRealm.String(realm.Spec.Realm.Realm))
+	defer func() { EndSpan(span, err) }()
+
 	i.User = user
+	i.Context = context
 
-	var err = i.readRealmRoles(keycloakClient, realm.Spec.Realm.Realm)
+	err = i.readRealmRoles(keycloakClient, realm.Spec.Realm.Realm)
 	if err != nil {
 		return err
 	}
@@ -53,14 +58,14 @@ func (i *UserState) ReadWithExistingAPIUser(keycloakClient KeycloakInterface, us
 
 func (i *UserState) readRealmRoles(client KeycloakInterface, realm string) error {
 	// Get all the realm roles of this user
-	roles, err := client.ListUserRealmRoles(realm, i.User.ID)
+	roles, err := client.ListUserRealmRoles(i.Context, realm, i.User.ID)
 	if err != nil {
 		return err
 	}
 	i.RealmRoles = roles
 
 	// Get the roles that are still available to this user
-	availableRoles, err := client.ListAvailableUserRealmRoles(realm, i.User.ID)
+	availableRoles, err := client.ListAvailableUserRealmRoles(i.Context, realm, i.User.ID)
 	if err != nil {
 		return err
 	}
@@ -70,7 +75,7 @@ func (i *UserState) readRealmRoles(client KeycloakInterface, realm string) error
 }
 
 func (i *UserState) readClientRoles(client KeycloakInterface, realm string) error {
-	clients, err := client.ListClients(realm)
+	clients, err := client.ListClients(i.Context, realm)
 	if err != nil {
 		return err
 	}
@@ -78,14 +83,14 @@ func (i *UserState) readClientRoles(client KeycloakInterface, realm string) erro
 
 	for _, c := range clients {
 		// Get all client roles of this user
-		roles, err := client.ListUserClientRoles(realm, c.ID, i.User.ID)
+		roles, err := client.ListUserClientRoles(i.Context, realm, c.ID, i.User.ID)
 		if err != nil {
 			return err
 		}
 		i.ClientRoles[c.ClientID] = roles
 
 		// Get the roles that are still available to this user
-		availableRoles, err := client.ListAvailableUserClientRoles(realm, c.ID, i.User.ID)
+		availableRoles, err := client.ListAvailableUserClientRoles(i.Context, realm, c.ID, i.User.ID)
 		if err != nil {
 			return err
 		}
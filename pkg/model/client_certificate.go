@@ -0,0 +1,62 @@
+package model
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"strconv"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ClientAuthenticationX509 = "x509"
+	// ClientX509Authenticator is the client authenticator of Keycloak for client certificates
+	ClientX509Authenticator = "client-x509"
+)
+
+// UsesX509 returns true if the confidential client authenticates with a client certificate
+func UsesX509(cr *v1alpha1.KeycloakClient) bool {
+	return cr.Spec.ClientAuthentication == ClientAuthenticationX509 && !cr.Spec.Client.PublicClient
+}
+
+// ClientCertificateSelector returns the key of the TLS Secret holding the client certificate of the CR
+func ClientCertificateSelector(cr *v1alpha1.KeycloakClient) client.ObjectKey {
+	return client.ObjectKey{
+		Name:      cr.Spec.X509.SecretName,
+		Namespace: cr.Namespace,
+	}
+}
+
+// ReferencesClientCertificate returns true if the client certificate of the CR is taken from the Secret
+func ReferencesClientCertificate(cr *v1alpha1.KeycloakClient, secret client.ObjectKey) bool {
+	return UsesX509(cr) && cr.Spec.X509 != nil && ClientCertificateSelector(cr) == secret
+}
+
+// ClientCertificateFromSecret returns the first certificate in tls.crt of the TLS Secret, i.e. the leaf certificate
+func ClientCertificateFromSecret(secret *v1.Secret) (*x509.Certificate, error) {
+	block, _ := pem.Decode(secret.Data[v1.TLSCertKey])
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.Errorf("no PEM encoded certificate in %s of secret %s/%s", v1.TLSCertKey, secret.Namespace, secret.Name)
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid certificate in secret %s/%s", secret.Namespace, secret.Name)
+	}
+	return certificate, nil
+}
+
+// SetClientCertificate configures the client to authenticate with certificates with the subject DN of the
+// certificate
+func SetClientCertificate(client *v1alpha1.KeycloakAPIClient, certificate *x509.Certificate, x509Spec *v1alpha1.KeycloakClientX509) {
+	boundAccessTokens := x509Spec.CertificateBoundAccessTokens == nil || *x509Spec.CertificateBoundAccessTokens
+	client.ClientAuthenticatorType = ClientX509Authenticator
+	if client.Attributes == nil {
+		client.Attributes = map[string]string{}
+	}
+	client.Attributes["x509.subjectdn"] = certificate.Subject.String()
+	client.Attributes["x509.allow.regex.pattern.comparison"] = "false"
+	client.Attributes["tls.client.certificate.bound.access.tokens"] = strconv.FormatBool(boundAccessTokens)
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestCertificatePEM(t *testing.T, commonName string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Example"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestClientCertificate_Secret(t *testing.T) {
+	// given
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	secret := &v1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "app-tls", Namespace: "test"},
+		Type:       v1.SecretTypeTLS,
+		Data:       map[string][]byte{v1.TLSCertKey: newTestCertificatePEM(t, "app", notAfter)},
+	}
+
+	// when
+	certificate, err := ClientCertificateFromSecret(secret)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "CN=app,O=Example", certificate.Subject.String())
+	assert.True(t, notAfter.Equal(certificate.NotAfter))
+
+	// when
+	_, err = ClientCertificateFromSecret(&v1.Secret{ObjectMeta: v13.ObjectMeta{Name: "empty", Namespace: "test"}})
+
+	// then
+	assert.EqualError(t, err, "no PEM encoded certificate in tls.crt of secret test/empty")
+}
+
+func TestClientCertificate_SetClientCertificate(t *testing.T) {
+	// given
+	certificate, err := ClientCertificateFromSecret(&v1.Secret{
+		Data: map[string][]byte{v1.TLSCertKey: newTestCertificatePEM(t, "app", time.Now().Add(time.Hour))},
+	})
+	assert.NoError(t, err)
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client:               &v1alpha1.KeycloakAPIClient{ClientID: "app"},
+			ClientAuthentication: ClientAuthenticationX509,
+			X509:                 &v1alpha1.KeycloakClientX509{SecretName: "app-tls"},
+		},
+	}
+
+	// when
+	SetClientCertificate(cr.Spec.Client, certificate, cr.Spec.X509)
+
+	// then
+	assert.Equal(t, ClientX509Authenticator, cr.Spec.Client.ClientAuthenticatorType)
+	assert.Equal(t, "CN=app,O=Example", cr.Spec.Client.Attributes["x509.subjectdn"])
+	assert.Equal(t, "true", cr.Spec.Client.Attributes["tls.client.certificate.bound.access.tokens"])
+	assert.True(t, ReferencesClientCertificate(cr, client.ObjectKey{Namespace: "test", Name: "app-tls"}))
+	assert.NoError(t, ValidateClientAuthentication(cr))
+
+	// when
+	cr.Spec.X509.CertificateBoundAccessTokens = new(bool)
+	SetClientCertificate(cr.Spec.Client, certificate, cr.Spec.X509)
+
+	// then
+	assert.Equal(t, "false", cr.Spec.Client.Attributes["tls.client.certificate.bound.access.tokens"])
+
+	// when
+	cr.Spec.X509 = nil
+
+	// then
+	assert.EqualError(t, ValidateClientAuthentication(cr), "clientAuthentication x509 requires x509.secretName")
+}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+)
+
+const (
+	// DriftPolicyCorrect overwrites changes made to the client in Keycloak on the next reconcile
+	DriftPolicyCorrect = "correct"
+	// DriftPolicyReportOnly reports changes made to the client in Keycloak without overwriting them
+	DriftPolicyReportOnly = "reportOnly"
+)
+
+// clientDriftIgnoredFields are compared separately or not at all, e.g. the secret, which Keycloak does not return to
+// every caller, and the protocol mappers and client scopes, which Keycloak manages apart from the client
+var clientDriftIgnoredFields = []string{
+	"id", "secret", "attributes", "protocolMappers", "authorizationSettings", "defaultClientScopes",
+	"optionalClientScopes", "defaultRoles", "access",
+}
+
+// clientDriftIgnoredAttributes are set by the controller from Secrets and key pairs, which change without a change of
+// the CR
+var clientDriftIgnoredAttributes = []string{
+	"x509.subjectdn", "jwks.string", "saml.signing.certificate", "saml.encryption.certificate",
+}
+
+// ReportsDriftOnly returns true if changes made to the client in Keycloak are reported but not overwritten
+func ReportsDriftOnly(cr *v1alpha1.KeycloakClient) bool {
+	return cr.Spec.DriftPolicy == DriftPolicyReportOnly
+}
+
+// ClientDrift returns the fields of the client in Keycloak which differ from the desired client, e.g. client.enabled
+// or client.attributes.pkce.code.challenge.method. Only the fields set in the desired client are compared, values
+// Keycloak defaults are no drift. The order of lists like the redirect URIs is ignored.
+func ClientDrift(desired, actual *v1alpha1.KeycloakAPIClient) []string {
+	desiredFields, err := clientFields(desired)
+	if err != nil {
+		return nil
+	}
+	actualFields, err := clientFields(actual)
+	if err != nil {
+		return nil
+	}
+
+	var drift []string
+	for name, value := range desiredFields {
+		if slices.Contains(clientDriftIgnoredFields, name) {
+			continue
+		}
+		if !reflect.DeepEqual(normalizeField(value), normalizeField(actualFields[name])) {
+			drift = append(drift, "client."+name)
+		}
+	}
+	for name, value := range desired.Attributes {
+		if slices.Contains(clientDriftIgnoredAttributes, name) {
+			continue
+		}
+		if actual.Attributes[name] != value {
+			drift = append(drift, "client.attributes."+name)
+		}
+	}
+	sort.Strings(drift)
+	return drift
+}
+
+// ClientChanges returns the fields an update of the client in Keycloak would change. Unlike ClientDrift, it includes
+// the secret, if Keycloak returned it, and the attributes set from Secrets and key pairs.
+func ClientChanges(desired, actual *v1alpha1.KeycloakAPIClient) []string {
+	changes := ClientDrift(desired, actual)
+	if actual.Secret != "" && desired.Secret != actual.Secret {
+		changes = append(changes, "client.secret")
+	}
+	for _, name := range clientDriftIgnoredAttributes {
+		if value, found := desired.Attributes[name]; found && actual.Attributes[name] != value {
+			changes = append(changes, "client.attributes."+name)
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+func clientFields(client *v1alpha1.KeycloakAPIClient) (map[string]interface{}, error) {
+	data, err := json.Marshal(client)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+// normalizeField sorts lists, so that lists with the same elements in another order are equal
+func normalizeField(value interface{}) interface{} {
+	list, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	elements := make([]string, 0, len(list))
+	for _, element := range list {
+		data, _ := json.Marshal(element)
+		elements = append(elements, string(data))
+	}
+	sort.Strings(elements)
+	return elements
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientDrift(t *testing.T) {
+	// given
+	desired := &v1alpha1.KeycloakAPIClient{
+		ClientID:            "app",
+		Secret:              "secret",
+		Enabled:             true,
+		StandardFlowEnabled: true,
+		RedirectUris:        []string{"https://app.example.com/a", "https://app.example.com/b"},
+		Attributes:          map[string]string{"pkce.code.challenge.method": "S256", "jwks.string": "{}"},
+	}
+	actual := &v1alpha1.KeycloakAPIClient{
+		ID:                  "4711",
+		ClientID:            "app",
+		Enabled:             true,
+		StandardFlowEnabled: true,
+		RedirectUris:        []string{"https://app.example.com/b", "https://app.example.com/a"},
+		Attributes:          map[string]string{"pkce.code.challenge.method": "S256", "jwks.string": "{\"keys\":[]}", "login_theme": "keycloak"},
+		Description:         "set in Keycloak only",
+	}
+
+	// then values set in Keycloak only and the order of lists are no drift
+	assert.Empty(t, ClientDrift(desired, actual))
+
+	// when
+	actual.Enabled = false
+	actual.StandardFlowEnabled = false
+	actual.RedirectUris = []string{"https://app.example.com/a"}
+	actual.Attributes["pkce.code.challenge.method"] = "plain"
+
+	// then
+	assert.Equal(t, []string{
+		"client.attributes.pkce.code.challenge.method",
+		"client.enabled",
+		"client.redirectUris",
+		"client.standardFlowEnabled",
+	}, ClientDrift(desired, actual))
+}
+
+func TestClientChanges(t *testing.T) {
+	// given
+	desired := &v1alpha1.KeycloakAPIClient{
+		ClientID:   "app",
+		Secret:     "secret",
+		Enabled:    true,
+		Attributes: map[string]string{"jwks.string": "{}"},
+	}
+	actual := &v1alpha1.KeycloakAPIClient{
+		ID:         "4711",
+		ClientID:   "app",
+		Secret:     "secret",
+		Enabled:    true,
+		Attributes: map[string]string{"jwks.string": "{}"},
+	}
+
+	// then
+	assert.Empty(t, ClientChanges(desired, actual))
+
+	// when
+	actual.Secret = "rotated"
+	actual.Attributes["jwks.string"] = "{\"keys\":[]}"
+
+	// then
+	assert.Equal(t, []string{"client.attributes.jwks.string", "client.secret"}, ClientChanges(desired, actual))
+}
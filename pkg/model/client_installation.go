@@ -0,0 +1,93 @@
+package model
+
+import (
+	"unicode/utf8"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ClientInstallationName          = ApplicationName + "-client-installation"
+	ClientInstallationKindSecret    = "Secret"
+	ClientInstallationKindConfigMap = "ConfigMap"
+)
+
+// ClientInstallationObjectName returns the name of the Secret or ConfigMap the installation is published in
+func ClientInstallationObjectName(cr *v1alpha1.KeycloakClient, installation v1alpha1.KeycloakClientInstallation) string {
+	if installation.Name != "" {
+		return installation.Name
+	}
+	return SanitizeResourceNameWithAlphaNum(ClientInstallationName + "-" + cr.Name)
+}
+
+// ClientInstallationKind returns the kind of the object the installation is published in. The documents of public
+// clients contain no secret and go into ConfigMaps unless configured otherwise.
+func ClientInstallationKind(cr *v1alpha1.KeycloakClient, installation v1alpha1.KeycloakClientInstallation) string {
+	if installation.Kind != "" {
+		return installation.Kind
+	}
+	if cr.Spec.Client.PublicClient {
+		return ClientInstallationKindConfigMap
+	}
+	return ClientInstallationKindSecret
+}
+
+func ClientInstallationSelector(cr *v1alpha1.KeycloakClient, name string) client.ObjectKey {
+	return client.ObjectKey{
+		Name:      name,
+		Namespace: cr.Namespace,
+	}
+}
+
+func ClientInstallationSecret(cr *v1alpha1.KeycloakClient, name string) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: v12.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels: map[string]string{
+				"app": ApplicationName,
+			},
+		},
+	}
+}
+
+func ClientInstallationConfigMap(cr *v1alpha1.KeycloakClient, name string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: v12.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels: map[string]string{
+				"app": ApplicationName,
+			},
+		},
+	}
+}
+
+// SetClientInstallationDocuments replaces the data of the Secret or ConfigMap with the documents, so the keys of
+// removed installations are dropped. Documents of a ConfigMap which are no valid UTF-8, e.g. the zip of
+// mod-auth-mellon, are stored as binary data.
+func SetClientInstallationDocuments(obj client.Object, documents map[string][]byte) {
+	switch o := obj.(type) {
+	case *v1.Secret:
+		o.Data = documents
+	case *v1.ConfigMap:
+		o.Data = nil
+		o.BinaryData = nil
+		for key, document := range documents {
+			if utf8.Valid(document) {
+				if o.Data == nil {
+					o.Data = map[string]string{}
+				}
+				o.Data[key] = string(document)
+			} else {
+				if o.BinaryData == nil {
+					o.BinaryData = map[string][]byte{}
+				}
+				o.BinaryData[key] = document
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClientInstallation_Defaults(t *testing.T) {
+	// given
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{ClientID: "app"},
+		},
+	}
+	installation := v1alpha1.KeycloakClientInstallation{ProviderID: "keycloak-oidc-keycloak-json", Key: "keycloak.json"}
+
+	// then
+	assert.Equal(t, "keycloak-client-installation-app", ClientInstallationObjectName(cr, installation))
+	assert.Equal(t, ClientInstallationKindSecret, ClientInstallationKind(cr, installation))
+
+	// when
+	cr.Spec.Client.PublicClient = true
+
+	// then
+	assert.Equal(t, ClientInstallationKindConfigMap, ClientInstallationKind(cr, installation))
+
+	// when
+	installation.Name = "keycloak-json"
+	installation.Kind = ClientInstallationKindSecret
+
+	// then
+	assert.Equal(t, "keycloak-json", ClientInstallationObjectName(cr, installation))
+	assert.Equal(t, ClientInstallationKindSecret, ClientInstallationKind(cr, installation))
+}
+
+func TestClientInstallation_Documents(t *testing.T) {
+	// given
+	cr := &v1alpha1.KeycloakClient{ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"}}
+	documents := map[string][]byte{
+		"keycloak.json": []byte(`{"realm":"test"}`),
+		"mellon.zip":    {'P', 'K', 0xff},
+	}
+	secret := ClientInstallationSecret(cr, "installation")
+	configMap := ClientInstallationConfigMap(cr, "installation")
+
+	// when
+	SetClientInstallationDocuments(secret, documents)
+	SetClientInstallationDocuments(configMap, documents)
+
+	// then
+	assert.Equal(t, documents, secret.Data)
+	assert.Equal(t, map[string]string{"keycloak.json": `{"realm":"test"}`}, configMap.Data)
+	assert.Equal(t, map[string][]byte{"mellon.zip": {'P', 'K', 0xff}}, configMap.BinaryData)
+
+	// when
+	SetClientInstallationDocuments(configMap, map[string][]byte{"keycloak.json": []byte(`{}`)})
+
+	// then
+	assert.Equal(t, map[string]string{"keycloak.json": `{}`}, configMap.Data)
+	assert.Nil(t, configMap.BinaryData)
+	assert.Equal(t, "test", configMap.Namespace)
+}
@@ -0,0 +1,222 @@
+package model
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	ClientAuthenticationClientSecret  = "clientSecret"
+	ClientAuthenticationPrivateKeyJWT = "privateKeyJWT"
+	// ClientJWTAuthenticator is the client authenticator of Keycloak for signed JWTs
+	ClientJWTAuthenticator = "client-jwt"
+	// Keys of the key pair in the client secret, which are always set for clients with privateKeyJWT authentication
+	ClientSecretPrivateKeyProperty = "PRIVATE_KEY"
+	ClientSecretKeyIDProperty      = "KEY_ID"
+
+	KeyPairAlgorithmRS256 = "RS256"
+	KeyPairAlgorithmES256 = "ES256"
+	keyPairRSABits        = 2048
+)
+
+// ClientKeyPair is the key pair a client with privateKeyJWT authentication signs its client assertions with
+type ClientKeyPair struct {
+	KeyID      string
+	Algorithm  string
+	PrivateKey crypto.Signer
+}
+
+// UsesPrivateKeyJWT returns true if the confidential client authenticates with a key pair
+func UsesPrivateKeyJWT(cr *v1alpha1.KeycloakClient) bool {
+	return cr.Spec.ClientAuthentication == ClientAuthenticationPrivateKeyJWT && !cr.Spec.Client.PublicClient
+}
+
+// ClientKeyPairAlgorithm returns the algorithm of the key pair of the CR, RS256 by default
+func ClientKeyPairAlgorithm(cr *v1alpha1.KeycloakClient) string {
+	if cr.Spec.PrivateKeyJWT != nil && cr.Spec.PrivateKeyJWT.Algorithm != "" {
+		return cr.Spec.PrivateKeyJWT.Algorithm
+	}
+	return KeyPairAlgorithmRS256
+}
+
+// ValidateClientAuthentication returns an error if the authentication of the client cannot be combined with the
+// rest of the CR
+func ValidateClientAuthentication(cr *v1alpha1.KeycloakClient) error {
+	if UsesX509(cr) && (cr.Spec.X509 == nil || cr.Spec.X509.SecretName == "") {
+		return errors.Errorf("clientAuthentication %s requires x509.secretName", ClientAuthenticationX509)
+	}
+	if !UsesPrivateKeyJWT(cr) && !UsesX509(cr) {
+		return nil
+	}
+	if cr.Spec.SecretRotation != nil || cr.Spec.SecretRef != nil {
+		return errors.Errorf("clientAuthentication %s cannot be combined with secretRotation or secretRef", cr.Spec.ClientAuthentication)
+	}
+	return nil
+}
+
+// GenerateClientKeyPair generates a key pair for the algorithm, with the JWK thumbprint of the public key as key ID
+func GenerateClientKeyPair(algorithm string) (*ClientKeyPair, error) {
+	var key crypto.Signer
+	var err error
+	switch algorithm {
+	case KeyPairAlgorithmRS256:
+		key, err = rsa.GenerateKey(rand.Reader, keyPairRSABits)
+	case KeyPairAlgorithmES256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, errors.Errorf("unsupported key pair algorithm %s", algorithm)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate key pair")
+	}
+	return newClientKeyPair(key)
+}
+
+// ClientKeyPairFromSecret reads the key pair from the client secret. Returns nil if the secret contains no key pair.
+func ClientKeyPairFromSecret(secret *v1.Secret) (*ClientKeyPair, error) {
+	if secret == nil || len(secret.Data[ClientSecretPrivateKeyProperty]) == 0 {
+		return nil, nil
+	}
+	block, _ := pem.Decode(secret.Data[ClientSecretPrivateKeyProperty])
+	if block == nil {
+		return nil, errors.Errorf("no PEM encoded private key in secret %s/%s", secret.Namespace, secret.Name)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid private key in secret %s/%s", secret.Namespace, secret.Name)
+	}
+	key, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported private key in secret %s/%s", secret.Namespace, secret.Name)
+	}
+	return newClientKeyPair(key)
+}
+
+// PrivateKeyPEM returns the PKCS #8 PEM encoding of the private key
+func (k *ClientKeyPair) PrivateKeyPEM() (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot encode private key")
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// JWK returns the JSON web key of the public key
+func (k *ClientKeyPair) JWK() map[string]string {
+	jwk := publicJWK(k.PrivateKey.Public())
+	jwk["kid"] = k.KeyID
+	jwk["use"] = "sig"
+	jwk["alg"] = k.Algorithm
+	return jwk
+}
+
+// JWKS returns the JSON web key set with the public key, followed by the previous keys
+func (k *ClientKeyPair) JWKS(previous ...map[string]string) (string, error) {
+	keys := append([]map[string]string{k.JWK()}, previous...)
+	jwks, err := json.Marshal(map[string]interface{}{"keys": keys})
+	if err != nil {
+		return "", errors.Wrap(err, "cannot encode JWKS")
+	}
+	return string(jwks), nil
+}
+
+// PreviousClientJWK returns the first key of the JSON web key set of the client which is not the key with the ID,
+// i.e. the key registered before the last rotation. Returns nil if there is none.
+func PreviousClientJWK(client *v1alpha1.KeycloakAPIClient, keyID string) map[string]string {
+	if client == nil || client.Attributes["jwks.string"] == "" {
+		return nil
+	}
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(client.Attributes["jwks.string"]), &jwks); err != nil {
+		return nil
+	}
+	for _, jwk := range jwks.Keys {
+		if jwk["kid"] != keyID {
+			return jwk
+		}
+	}
+	return nil
+}
+
+// SetClientKeyPair configures the client to authenticate with signed JWTs verified with the public key of the pair.
+// The previous key, if any, stays registered as well.
+func SetClientKeyPair(client *v1alpha1.KeycloakAPIClient, keyPair *ClientKeyPair, previous map[string]string) error {
+	var previousKeys []map[string]string
+	if previous != nil {
+		previousKeys = append(previousKeys, previous)
+	}
+	jwks, err := keyPair.JWKS(previousKeys...)
+	if err != nil {
+		return err
+	}
+	client.ClientAuthenticatorType = ClientJWTAuthenticator
+	if client.Attributes == nil {
+		client.Attributes = map[string]string{}
+	}
+	client.Attributes["use.jwks.url"] = "false"
+	client.Attributes["use.jwks.string"] = "true"
+	client.Attributes["jwks.string"] = jwks
+	client.Attributes["token.endpoint.auth.signing.alg"] = keyPair.Algorithm
+	return nil
+}
+
+func newClientKeyPair(key crypto.Signer) (*ClientKeyPair, error) {
+	keyPair := &ClientKeyPair{PrivateKey: key}
+	switch public := key.Public().(type) {
+	case *rsa.PublicKey:
+		keyPair.Algorithm = KeyPairAlgorithmRS256
+	case *ecdsa.PublicKey:
+		if public.Curve != elliptic.P256() {
+			return nil, errors.Errorf("unsupported curve %s", public.Curve.Params().Name)
+		}
+		keyPair.Algorithm = KeyPairAlgorithmES256
+	default:
+		return nil, errors.Errorf("unsupported key type %T", public)
+	}
+	keyPair.KeyID = jwkThumbprint(publicJWK(key.Public()))
+	return keyPair, nil
+}
+
+// publicJWK returns the required members of the JWK of the public key
+func publicJWK(key crypto.PublicKey) map[string]string {
+	switch public := key.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		size := (public.Curve.Params().BitSize + 7) / 8
+		return map[string]string{
+			"kty": "EC",
+			"crv": public.Curve.Params().Name,
+			"x":   base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, size))),
+			"y":   base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, size))),
+		}
+	}
+	return map[string]string{}
+}
+
+// jwkThumbprint returns the RFC 7638 thumbprint of the JWK. encoding/json sorts the members lexicographically, as
+// required for the thumbprint.
+func jwkThumbprint(jwk map[string]string) string {
+	canonical, _ := json.Marshal(jwk)
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
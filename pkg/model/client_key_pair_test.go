@@ -0,0 +1,117 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestClientKeyPair_Secret(t *testing.T) {
+	for _, algorithm := range []string{KeyPairAlgorithmRS256, KeyPairAlgorithmES256} {
+		// given
+		keyPair, err := GenerateClientKeyPair(algorithm)
+		assert.NoError(t, err)
+		privateKey, err := keyPair.PrivateKeyPEM()
+		assert.NoError(t, err)
+		secret := &v1.Secret{}
+		data := ClientSecretTemplateData{ClientID: "app", ClientSecret: "secret", PrivateKey: privateKey, KeyID: keyPair.KeyID}
+
+		// when
+		err = RenderClientSecret(secret, nil, data)
+
+		// then the key pair is stored in the secret instead of the client secret
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]byte{
+			ClientSecretClientIDProperty:   []byte("app"),
+			ClientSecretPrivateKeyProperty: []byte(privateKey),
+			ClientSecretKeyIDProperty:      []byte(keyPair.KeyID),
+		}, secret.Data)
+
+		// when
+		read, err := ClientKeyPairFromSecret(secret)
+
+		// then the same key pair is read back
+		assert.NoError(t, err)
+		assert.Equal(t, algorithm, read.Algorithm)
+		assert.Equal(t, keyPair.KeyID, read.KeyID)
+	}
+}
+
+func TestClientKeyPair_NoKeyPair(t *testing.T) {
+	keyPair, err := ClientKeyPairFromSecret(&v1.Secret{Data: map[string][]byte{ClientSecretClientSecretProperty: []byte("secret")}})
+	assert.NoError(t, err)
+	assert.Nil(t, keyPair)
+
+	_, err = ClientKeyPairFromSecret(&v1.Secret{Data: map[string][]byte{ClientSecretPrivateKeyProperty: []byte("invalid")}})
+	assert.Error(t, err)
+
+	_, err = GenerateClientKeyPair("HS256")
+	assert.EqualError(t, err, "unsupported key pair algorithm HS256")
+}
+
+func TestClientKeyPair_SetClientKeyPair(t *testing.T) {
+	// given
+	keyPair, err := GenerateClientKeyPair(KeyPairAlgorithmES256)
+	assert.NoError(t, err)
+	client := &v1alpha1.KeycloakAPIClient{ClientID: "app"}
+
+	// when
+	err = SetClientKeyPair(client, keyPair, nil)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, ClientJWTAuthenticator, client.ClientAuthenticatorType)
+	assert.Equal(t, "true", client.Attributes["use.jwks.string"])
+	assert.Equal(t, "ES256", client.Attributes["token.endpoint.auth.signing.alg"])
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(client.Attributes["jwks.string"]), &jwks))
+	assert.Len(t, jwks.Keys, 1)
+	assert.Equal(t, keyPair.KeyID, jwks.Keys[0]["kid"])
+	assert.Equal(t, "EC", jwks.Keys[0]["kty"])
+	assert.Equal(t, "P-256", jwks.Keys[0]["crv"])
+	assert.Equal(t, "sig", jwks.Keys[0]["use"])
+}
+
+func TestClientKeyPair_PreviousClientJWK(t *testing.T) {
+	// given
+	previous, err := GenerateClientKeyPair(KeyPairAlgorithmRS256)
+	assert.NoError(t, err)
+	keyPair, err := GenerateClientKeyPair(KeyPairAlgorithmES256)
+	assert.NoError(t, err)
+	client := &v1alpha1.KeycloakAPIClient{ClientID: "app"}
+	assert.NoError(t, SetClientKeyPair(client, previous, nil))
+
+	// then
+	assert.Nil(t, PreviousClientJWK(client, previous.KeyID))
+	assert.Equal(t, previous.JWK(), PreviousClientJWK(client, keyPair.KeyID))
+
+	// when
+	err = SetClientKeyPair(client, keyPair, previous.JWK())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, previous.JWK(), PreviousClientJWK(client, keyPair.KeyID))
+	assert.Contains(t, client.Attributes["jwks.string"], keyPair.KeyID)
+	assert.Equal(t, "ES256", client.Attributes["token.endpoint.auth.signing.alg"])
+	assert.Nil(t, PreviousClientJWK(&v1alpha1.KeycloakAPIClient{}, keyPair.KeyID))
+}
+
+func TestClientKeyPair_ValidateClientAuthentication(t *testing.T) {
+	cr := &v1alpha1.KeycloakClient{Spec: v1alpha1.KeycloakClientSpec{
+		Client:               &v1alpha1.KeycloakAPIClient{ClientID: "app"},
+		ClientAuthentication: ClientAuthenticationPrivateKeyJWT,
+	}}
+	assert.NoError(t, ValidateClientAuthentication(cr))
+
+	cr.Spec.SecretRef = &v1alpha1.KeycloakClientSecretRef{Name: "app"}
+	assert.EqualError(t, ValidateClientAuthentication(cr), "clientAuthentication privateKeyJWT cannot be combined with secretRotation or secretRef")
+
+	// public clients do not authenticate
+	cr.Spec.Client.PublicClient = true
+	assert.NoError(t, ValidateClientAuthentication(cr))
+}
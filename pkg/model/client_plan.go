@@ -0,0 +1,11 @@
+package model
+
+import "github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+
+// ClientDryRunAnnotation set to "true" makes the controller plan the changes of a KeycloakClient without applying them
+const ClientDryRunAnnotation = "keycloak.org/dry-run"
+
+// IsDryRun returns true if the changes of the client are planned only
+func IsDryRun(cr *v1alpha1.KeycloakClient) bool {
+	return cr.Annotations[ClientDryRunAnnotation] == "true"
+}
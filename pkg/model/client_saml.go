@@ -0,0 +1,120 @@
+package model
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"strconv"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SAMLProtocol = "saml"
+	// SAMLIdPDescriptorProvider is the installation provider of the IdP metadata descriptor of the realm
+	SAMLIdPDescriptorProvider = "saml-idp-descriptor"
+	SAMLIdPDescriptorKey      = "idp-metadata.xml"
+)
+
+// IsSAML returns true if the client uses the SAML protocol. SAML clients have no client secret.
+func IsSAML(cr *v1alpha1.KeycloakClient) bool {
+	return cr.Spec.Client.Protocol == SAMLProtocol
+}
+
+// ValidateSAML returns an error if the SAML settings are incomplete or the CR configures settings of OIDC clients for
+// a SAML client
+func ValidateSAML(cr *v1alpha1.KeycloakClient) error {
+	if !IsSAML(cr) {
+		if cr.Spec.SAML != nil {
+			return errors.Errorf("saml settings require protocol %s", SAMLProtocol)
+		}
+		return nil
+	}
+	if (cr.Spec.ClientAuthentication != "" && cr.Spec.ClientAuthentication != ClientAuthenticationClientSecret) ||
+		cr.Spec.SecretRotation != nil || cr.Spec.SecretRef != nil || len(cr.Spec.SecretTargets) > 0 {
+		return errors.Errorf("protocol %s cannot be combined with clientAuthentication, secretRotation, secretRef or secretTargets", SAMLProtocol)
+	}
+	saml := cr.Spec.SAML
+	if saml == nil {
+		return nil
+	}
+	if saml.ClientSignatureRequired && saml.SigningCertificateSecretName == "" {
+		return errors.Errorf("saml clientSignatureRequired requires signingCertificateSecretName")
+	}
+	if saml.EncryptAssertions && saml.EncryptionCertificateSecretName == "" {
+		return errors.Errorf("saml encryptAssertions requires encryptionCertificateSecretName")
+	}
+	return nil
+}
+
+// SAMLCertificateSelectors returns the keys of the TLS Secrets with the signing and encryption certificate of the
+// client, an empty key if the certificate is not configured
+func SAMLCertificateSelectors(cr *v1alpha1.KeycloakClient) (signing client.ObjectKey, encryption client.ObjectKey) {
+	if !IsSAML(cr) || cr.Spec.SAML == nil {
+		return
+	}
+	if cr.Spec.SAML.SigningCertificateSecretName != "" {
+		signing = client.ObjectKey{Name: cr.Spec.SAML.SigningCertificateSecretName, Namespace: cr.Namespace}
+	}
+	if cr.Spec.SAML.EncryptionCertificateSecretName != "" {
+		encryption = client.ObjectKey{Name: cr.Spec.SAML.EncryptionCertificateSecretName, Namespace: cr.Namespace}
+	}
+	return
+}
+
+// ReferencesSAMLCertificate returns true if the signing or encryption certificate of the CR is taken from the Secret
+func ReferencesSAMLCertificate(cr *v1alpha1.KeycloakClient, secret client.ObjectKey) bool {
+	signing, encryption := SAMLCertificateSelectors(cr)
+	return secret.Name != "" && (signing == secret || encryption == secret)
+}
+
+// ClientInstallations returns the installations of the CR, including the IdP metadata descriptor of a SAML client
+func ClientInstallations(cr *v1alpha1.KeycloakClient) []v1alpha1.KeycloakClientInstallation {
+	if !IsSAML(cr) || cr.Spec.SAML == nil || cr.Spec.SAML.IdPDescriptorConfigMapName == "" {
+		return cr.Spec.Installations
+	}
+	return append(append([]v1alpha1.KeycloakClientInstallation{}, cr.Spec.Installations...), v1alpha1.KeycloakClientInstallation{
+		ProviderID: SAMLIdPDescriptorProvider,
+		Key:        SAMLIdPDescriptorKey,
+		Kind:       ClientInstallationKindConfigMap,
+		Name:       cr.Spec.SAML.IdPDescriptorConfigMapName,
+	})
+}
+
+// SetClientSAML sets the SAML attributes of the client. The certificates are nil if not configured.
+func SetClientSAML(client *v1alpha1.KeycloakAPIClient, saml *v1alpha1.KeycloakClientSAML, signing, encryption *x509.Certificate) {
+	if client.Attributes == nil {
+		client.Attributes = map[string]string{}
+	}
+	attributes := client.Attributes
+	attributes["saml.server.signature"] = strconv.FormatBool(saml.SignDocuments == nil || *saml.SignDocuments)
+	attributes["saml.assertion.signature"] = strconv.FormatBool(saml.SignAssertions)
+	attributes["saml.client.signature"] = strconv.FormatBool(saml.ClientSignatureRequired)
+	attributes["saml.encrypt"] = strconv.FormatBool(saml.EncryptAssertions)
+	attributes["saml_force_name_id_format"] = strconv.FormatBool(saml.ForceNameIDFormat)
+	setAttribute(attributes, "saml.signature.algorithm", saml.SignatureAlgorithm, "RSA_SHA256")
+	setAttribute(attributes, "saml_name_id_format", saml.NameIDFormat, "username")
+	setAttribute(attributes, "saml_assertion_consumer_url_post", saml.AssertionConsumerURLPost, "")
+	setAttribute(attributes, "saml_assertion_consumer_url_redirect", saml.AssertionConsumerURLRedirect, "")
+	setAttribute(attributes, "saml_single_logout_service_url_post", saml.LogoutURLPost, "")
+	setAttribute(attributes, "saml_single_logout_service_url_redirect", saml.LogoutURLRedirect, "")
+	if signing != nil {
+		attributes["saml.signing.certificate"] = base64.StdEncoding.EncodeToString(signing.Raw)
+	}
+	if encryption != nil {
+		attributes["saml.encryption.certificate"] = base64.StdEncoding.EncodeToString(encryption.Raw)
+	}
+}
+
+// setAttribute sets the attribute to the value or the default, and removes it if both are empty
+func setAttribute(attributes map[string]string, name, value, defaultValue string) {
+	if value == "" {
+		value = defaultValue
+	}
+	if value == "" {
+		delete(attributes, name)
+		return
+	}
+	attributes[name] = value
+}
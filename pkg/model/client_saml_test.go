@@ -0,0 +1,95 @@
+package model
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestClientSAML_SetClientSAML(t *testing.T) {
+	// given
+	certificate, err := ClientCertificateFromSecret(&v1.Secret{
+		Data: map[string][]byte{v1.TLSCertKey: newTestCertificatePEM(t, "sp", time.Now().Add(time.Hour))},
+	})
+	assert.NoError(t, err)
+	apiClient := &v1alpha1.KeycloakAPIClient{
+		ClientID:   "sp",
+		Protocol:   SAMLProtocol,
+		Attributes: map[string]string{"saml_single_logout_service_url_post": "https://sp.example.com/old"},
+	}
+	saml := &v1alpha1.KeycloakClientSAML{
+		SignAssertions:           true,
+		ClientSignatureRequired:  true,
+		NameIDFormat:             "email",
+		AssertionConsumerURLPost: "https://sp.example.com/acs",
+	}
+
+	// when
+	SetClientSAML(apiClient, saml, certificate, nil)
+
+	// then
+	assert.Equal(t, map[string]string{
+		"saml.server.signature":            "true",
+		"saml.assertion.signature":         "true",
+		"saml.client.signature":            "true",
+		"saml.encrypt":                     "false",
+		"saml_force_name_id_format":        "false",
+		"saml.signature.algorithm":         "RSA_SHA256",
+		"saml_name_id_format":              "email",
+		"saml_assertion_consumer_url_post": "https://sp.example.com/acs",
+		"saml.signing.certificate":         base64.StdEncoding.EncodeToString(certificate.Raw),
+	}, apiClient.Attributes)
+}
+
+func TestClientSAML_Validate(t *testing.T) {
+	// given
+	cr := &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "sp", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client: &v1alpha1.KeycloakAPIClient{ClientID: "sp", Protocol: SAMLProtocol},
+			SAML: &v1alpha1.KeycloakClientSAML{
+				EncryptAssertions:               true,
+				EncryptionCertificateSecretName: "sp-encryption",
+				IdPDescriptorConfigMapName:      "sp-idp",
+			},
+		},
+	}
+
+	// then
+	assert.NoError(t, ValidateSAML(cr))
+	assert.True(t, ReferencesSAMLCertificate(cr, client.ObjectKey{Namespace: "test", Name: "sp-encryption"}))
+	assert.False(t, ReferencesSAMLCertificate(cr, client.ObjectKey{Namespace: "other", Name: "sp-encryption"}))
+	assert.Equal(t, []v1alpha1.KeycloakClientInstallation{{
+		ProviderID: SAMLIdPDescriptorProvider,
+		Key:        SAMLIdPDescriptorKey,
+		Kind:       ClientInstallationKindConfigMap,
+		Name:       "sp-idp",
+	}}, ClientInstallations(cr))
+
+	// when
+	cr.Spec.SAML.ClientSignatureRequired = true
+
+	// then
+	assert.EqualError(t, ValidateSAML(cr), "saml clientSignatureRequired requires signingCertificateSecretName")
+
+	// when
+	cr.Spec.SAML.ClientSignatureRequired = false
+	cr.Spec.SecretRotation = &v1alpha1.KeycloakClientSecretRotation{}
+
+	// then
+	assert.EqualError(t, ValidateSAML(cr), "protocol saml cannot be combined with clientAuthentication, secretRotation, secretRef or secretTargets")
+
+	// when
+	cr.Spec.SecretRotation = nil
+	cr.Spec.Client.Protocol = "openid-connect"
+
+	// then
+	assert.EqualError(t, ValidateSAML(cr), "saml settings require protocol saml")
+	assert.Empty(t, ClientInstallations(cr))
+}
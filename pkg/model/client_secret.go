@@ -9,10 +9,20 @@ import (
 )
 
 func ClientSecret(cr *v1alpha1.KeycloakClient) *v1.Secret {
-	escapedSecretName := SanitizeResourceNameWithAlphaNum(ClientSecretName + "-" + cr.Name)
+	return ClientTargetSecret(cr, "")
+}
+
+func ClientSecretSelector(cr *v1alpha1.KeycloakClient) client.ObjectKey {
+	return ClientTargetSecretSelector(cr, "")
+}
+
+// ClientTargetSecret returns the secret of the client for one of several realms and Keycloaks it is reconciled with,
+// i.e. keycloak-client-secret-<custom resource name>-<target>. Without a target it is the secret of a client with a
+// single realm and Keycloak.
+func ClientTargetSecret(cr *v1alpha1.KeycloakClient, target string) *v1.Secret {
 	return &v1.Secret{
 		ObjectMeta: v12.ObjectMeta{
-			Name:      escapedSecretName,
+			Name:      clientSecretName(cr, target),
 			Namespace: cr.Namespace,
 			Labels: map[string]string{
 				"app": ApplicationName,
@@ -25,14 +35,21 @@ func ClientSecret(cr *v1alpha1.KeycloakClient) *v1.Secret {
 	}
 }
 
-func ClientSecretSelector(cr *v1alpha1.KeycloakClient) client.ObjectKey {
-	escapedSelectorName := SanitizeResourceNameWithAlphaNum(ClientSecretName + "-" + cr.Name)
+func ClientTargetSecretSelector(cr *v1alpha1.KeycloakClient, target string) client.ObjectKey {
 	return client.ObjectKey{
-		Name:      escapedSelectorName,
+		Name:      clientSecretName(cr, target),
 		Namespace: cr.Namespace,
 	}
 }
 
+func clientSecretName(cr *v1alpha1.KeycloakClient, target string) string {
+	name := ClientSecretName + "-" + cr.Name
+	if target != "" {
+		name += "-" + target
+	}
+	return SanitizeResourceNameWithAlphaNum(name)
+}
+
 func ClientSecretReconciled(cr *v1alpha1.KeycloakClient, currentState *v1.Secret) *v1.Secret {
 	reconciled := currentState.DeepCopy()
 	// Since the client is synced upon update, we always override what's there...
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClientSecretRefAllowedNamespacesAnnotation lists the namespaces of the KeycloakClients which may reference a Secret
+// in another namespace, comma separated or "*" for all namespaces
+const ClientSecretRefAllowedNamespacesAnnotation = "keycloak.org/allowed-client-namespaces"
+
+// ClientSecretRefSelector returns the key of the Secret referenced by the secretRef of the CR
+func ClientSecretRefSelector(cr *v1alpha1.KeycloakClient) client.ObjectKey {
+	namespace := cr.Spec.SecretRef.Namespace
+	if namespace == "" {
+		namespace = cr.Namespace
+	}
+	return client.ObjectKey{
+		Name:      cr.Spec.SecretRef.Name,
+		Namespace: namespace,
+	}
+}
+
+// ClientSecretFromRef returns the client secret from the Secret referenced by the secretRef of the CR. A Secret in
+// another namespace must allow the namespace of the CR.
+func ClientSecretFromRef(cr *v1alpha1.KeycloakClient, secret *v1.Secret) (string, error) {
+	if secret.Namespace != cr.Namespace && !clientSecretRefAllowed(cr, secret) {
+		return "", errors.Errorf("secret %s/%s does not allow clients of namespace %s", secret.Namespace, secret.Name, cr.Namespace)
+	}
+
+	key := cr.Spec.SecretRef.Key
+	if key == "" {
+		key = ClientSecretClientSecretProperty
+	}
+	value, ok := secret.Data[key]
+	if !ok || len(value) == 0 {
+		return "", errors.Errorf("secret %s/%s has no key %s", secret.Namespace, secret.Name, key)
+	}
+	return string(value), nil
+}
+
+// ReferencesClientSecret returns true if the secretRef of the CR refers to the Secret
+func ReferencesClientSecret(cr *v1alpha1.KeycloakClient, secret client.ObjectKey) bool {
+	return cr.Spec.SecretRef != nil && ClientSecretRefSelector(cr) == secret
+}
+
+func clientSecretRefAllowed(cr *v1alpha1.KeycloakClient, secret *v1.Secret) bool {
+	for _, namespace := range strings.Split(secret.Annotations[ClientSecretRefAllowedNamespacesAnnotation], ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "*" || namespace == cr.Namespace {
+			return true
+		}
+	}
+	return false
+}
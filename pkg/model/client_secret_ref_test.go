@@ -0,0 +1,96 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newSecretRefTestClient(secretRef *v1alpha1.KeycloakClientSecretRef) *v1alpha1.KeycloakClient {
+	return &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client:    &v1alpha1.KeycloakAPIClient{ClientID: "app"},
+			SecretRef: secretRef,
+		},
+	}
+}
+
+func TestClientSecretRef_SameNamespace(t *testing.T) {
+	// given
+	cr := newSecretRefTestClient(&v1alpha1.KeycloakClientSecretRef{Name: "app-credentials"})
+	secret := &v1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "app-credentials", Namespace: "test"},
+		Data:       map[string][]byte{ClientSecretClientSecretProperty: []byte("secret")},
+	}
+
+	// when
+	value, err := ClientSecretFromRef(cr, secret)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", value)
+	assert.Equal(t, client.ObjectKey{Namespace: "test", Name: "app-credentials"}, ClientSecretRefSelector(cr))
+	assert.True(t, ReferencesClientSecret(cr, client.ObjectKey{Namespace: "test", Name: "app-credentials"}))
+	assert.False(t, ReferencesClientSecret(cr, client.ObjectKey{Namespace: "other", Name: "app-credentials"}))
+}
+
+func TestClientSecretRef_Key(t *testing.T) {
+	// given
+	cr := newSecretRefTestClient(&v1alpha1.KeycloakClientSecretRef{Name: "app-credentials", Key: "password"})
+	secret := &v1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "app-credentials", Namespace: "test"},
+		Data:       map[string][]byte{ClientSecretClientSecretProperty: []byte("secret")},
+	}
+
+	// when
+	_, err := ClientSecretFromRef(cr, secret)
+
+	// then
+	assert.EqualError(t, err, "secret test/app-credentials has no key password")
+
+	// when
+	secret.Data["password"] = []byte("password")
+	value, err := ClientSecretFromRef(cr, secret)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "password", value)
+}
+
+func TestClientSecretRef_OtherNamespace(t *testing.T) {
+	// given
+	cr := newSecretRefTestClient(&v1alpha1.KeycloakClientSecretRef{Name: "shared", Namespace: "secrets"})
+	secret := &v1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "shared", Namespace: "secrets"},
+		Data:       map[string][]byte{ClientSecretClientSecretProperty: []byte("secret")},
+	}
+
+	// when
+	_, err := ClientSecretFromRef(cr, secret)
+
+	// then
+	assert.EqualError(t, err, "secret secrets/shared does not allow clients of namespace test")
+	assert.Equal(t, client.ObjectKey{Namespace: "secrets", Name: "shared"}, ClientSecretRefSelector(cr))
+
+	for _, allowed := range []string{"test", "other, test", "*"} {
+		// when
+		secret.Annotations = map[string]string{ClientSecretRefAllowedNamespacesAnnotation: allowed}
+		value, err := ClientSecretFromRef(cr, secret)
+
+		// then
+		assert.NoError(t, err, allowed)
+		assert.Equal(t, "secret", value, allowed)
+	}
+
+	// when
+	secret.Annotations = map[string]string{ClientSecretRefAllowedNamespacesAnnotation: "other,test2"}
+	_, err = ClientSecretFromRef(cr, secret)
+
+	// then
+	assert.Error(t, err)
+}
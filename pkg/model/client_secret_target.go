@@ -0,0 +1,74 @@
+package model
+
+import (
+	"slices"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// The secret targets of a client are tracked by labels, as owner references cannot cross namespaces
+const (
+	ClientSecretTargetNamespaceLabel = "keycloak.org/client-namespace"
+	ClientSecretTargetNameLabel      = "keycloak.org/client-name"
+)
+
+// ClientSecretTargetLabels returns the labels the secret targets of the CR are tracked with
+func ClientSecretTargetLabels(cr *v1alpha1.KeycloakClient) map[string]string {
+	return map[string]string{
+		ClientSecretTargetNamespaceLabel: cr.Namespace,
+		ClientSecretTargetNameLabel:      cr.Name,
+	}
+}
+
+// ClientSecretTargetNamespace returns the namespace of the secret target, by default the namespace of the CR
+func ClientSecretTargetNamespace(cr *v1alpha1.KeycloakClient, target v1alpha1.KeycloakClientSecretTarget) string {
+	if target.Namespace != "" {
+		return target.Namespace
+	}
+	return cr.Namespace
+}
+
+// ClientSecretTargetTemplate returns the template of the secret target, by default the secret template of the CR
+func ClientSecretTargetTemplate(cr *v1alpha1.KeycloakClient, target v1alpha1.KeycloakClientSecretTarget) *v1alpha1.KeycloakClientSecretTemplate {
+	if target.Template != nil {
+		return target.Template
+	}
+	return cr.Spec.SecretTemplate
+}
+
+func ClientSecretTarget(cr *v1alpha1.KeycloakClient, target v1alpha1.KeycloakClientSecretTarget) *v1.Secret {
+	labels := ClientSecretTargetLabels(cr)
+	labels["app"] = ApplicationName
+	return &v1.Secret{
+		ObjectMeta: v12.ObjectMeta{
+			Name:      target.Name,
+			Namespace: ClientSecretTargetNamespace(cr, target),
+			Labels:    labels,
+		},
+	}
+}
+
+// ValidateClientSecretTargets returns an error if a secret target is in a namespace other than the one of the CR
+// which is not in the allowed namespaces, or if its template is invalid. "*" allows all namespaces.
+func ValidateClientSecretTargets(cr *v1alpha1.KeycloakClient, allowedNamespaces []string) error {
+	if len(cr.Spec.SecretTargets) == 0 {
+		return nil
+	}
+	if len(validation.IsValidLabelValue(cr.Name)) > 0 {
+		return errors.Errorf("secret targets require a client name that is a valid label value, got %s", cr.Name)
+	}
+	for _, target := range cr.Spec.SecretTargets {
+		namespace := ClientSecretTargetNamespace(cr, target)
+		if namespace != cr.Namespace && !slices.Contains(allowedNamespaces, "*") && !slices.Contains(allowedNamespaces, namespace) {
+			return errors.Errorf("secret target %s/%s is not in an allowed namespace", namespace, target.Name)
+		}
+		if err := ValidateClientSecretTemplate(target.Template); err != nil {
+			return errors.Wrapf(err, "secret target %s/%s", namespace, target.Name)
+		}
+	}
+	return nil
+}
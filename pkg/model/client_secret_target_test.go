@@ -0,0 +1,64 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newSecretTargetTestClient(targets ...v1alpha1.KeycloakClientSecretTarget) *v1alpha1.KeycloakClient {
+	return &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client:         &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+			SecretTemplate: &v1alpha1.KeycloakClientSecretTemplate{Data: map[string]string{"ID": "{{ .ClientID }}"}},
+			SecretTargets:  targets,
+		},
+	}
+}
+
+func TestClientSecretTarget_Defaults(t *testing.T) {
+	// given
+	template := &v1alpha1.KeycloakClientSecretTemplate{Data: map[string]string{"SECRET": "{{ .ClientSecret }}"}}
+	cr := newSecretTargetTestClient(
+		v1alpha1.KeycloakClientSecretTarget{Name: "app"},
+		v1alpha1.KeycloakClientSecretTarget{Name: "app", Namespace: "gateway", Template: template},
+	)
+
+	// when
+	local := ClientSecretTarget(cr, cr.Spec.SecretTargets[0])
+	gateway := ClientSecretTarget(cr, cr.Spec.SecretTargets[1])
+
+	// then
+	assert.Equal(t, "test", local.Namespace)
+	assert.Equal(t, "gateway", gateway.Namespace)
+	assert.Equal(t, map[string]string{
+		"app":                            ApplicationName,
+		ClientSecretTargetNamespaceLabel: "test",
+		ClientSecretTargetNameLabel:      "app",
+	}, gateway.Labels)
+	assert.Equal(t, cr.Spec.SecretTemplate, ClientSecretTargetTemplate(cr, cr.Spec.SecretTargets[0]))
+	assert.Equal(t, template, ClientSecretTargetTemplate(cr, cr.Spec.SecretTargets[1]))
+}
+
+func TestClientSecretTarget_Validate(t *testing.T) {
+	// given
+	cr := newSecretTargetTestClient(
+		v1alpha1.KeycloakClientSecretTarget{Name: "app"},
+		v1alpha1.KeycloakClientSecretTarget{Name: "app", Namespace: "gateway"},
+	)
+
+	// then
+	assert.EqualError(t, ValidateClientSecretTargets(cr, nil), "secret target gateway/app is not in an allowed namespace")
+	assert.EqualError(t, ValidateClientSecretTargets(cr, []string{"other"}), "secret target gateway/app is not in an allowed namespace")
+	assert.NoError(t, ValidateClientSecretTargets(cr, []string{"other", "gateway"}))
+	assert.NoError(t, ValidateClientSecretTargets(cr, []string{"*"}))
+
+	// when the template of a target is invalid
+	cr.Spec.SecretTargets[0].Template = &v1alpha1.KeycloakClientSecretTemplate{Data: map[string]string{"ID": "{{ .ClientId }}"}}
+
+	// then
+	assert.ErrorContains(t, ValidateClientSecretTargets(cr, []string{"*"}), `secret target test/app: invalid secret template data "ID"`)
+}
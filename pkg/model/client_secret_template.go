@@ -0,0 +1,170 @@
+package model
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+// defaultClientSecretData is the template of the keys of a client secret without secret template
+var defaultClientSecretData = map[string]string{
+	ClientSecretClientIDProperty:     "{{ .ClientID }}",
+	ClientSecretClientSecretProperty: "{{ .ClientSecret }}",
+}
+
+// defaultClientKeyPairData is the template of the keys of the secret of a client with privateKeyJWT authentication
+// without secret template, besides the key pair
+var defaultClientKeyPairData = map[string]string{
+	ClientSecretClientIDProperty: "{{ .ClientID }}",
+}
+
+// ClientSecretTemplateData is the data the secret template of a client is rendered with
+type ClientSecretTemplateData struct {
+	ClientID             string
+	ClientSecret         string
+	PreviousClientSecret string
+	PrivateKey           string
+	KeyID                string
+	Realm                string
+	KeycloakURL          string
+	IssuerURL            string
+	TokenURL             string
+	AuthURL              string
+	UserinfoURL          string
+	JWKSURL              string
+}
+
+// clientSecretMetadata is the data the labels and annotations of the secret template are rendered with. Labels and
+// annotations are readable by far more than the keys of the secret, so they get no credentials.
+type clientSecretMetadata struct {
+	ClientID    string
+	KeyID       string
+	Realm       string
+	KeycloakURL string
+	IssuerURL   string
+	TokenURL    string
+	AuthURL     string
+	UserinfoURL string
+	JWKSURL     string
+}
+
+func (d ClientSecretTemplateData) metadata() clientSecretMetadata {
+	return clientSecretMetadata{
+		ClientID:    d.ClientID,
+		KeyID:       d.KeyID,
+		Realm:       d.Realm,
+		KeycloakURL: d.KeycloakURL,
+		IssuerURL:   d.IssuerURL,
+		TokenURL:    d.TokenURL,
+		AuthURL:     d.AuthURL,
+		UserinfoURL: d.UserinfoURL,
+		JWKSURL:     d.JWKSURL,
+	}
+}
+
+// NewClientSecretTemplateData returns the data to render the secret of the client with. The endpoints are taken
+// from the OpenID configuration of the realm, if known, so that they respect the frontend URL of Keycloak.
+func NewClientSecretTemplateData(cr *v1alpha1.KeycloakClient, realm string, config *v1alpha1.KeycloakOpenIDConfiguration) ClientSecretTemplateData {
+	data := ClientSecretTemplateData{
+		ClientID:     cr.Spec.Client.ClientID,
+		ClientSecret: cr.Spec.Client.Secret,
+		Realm:        realm,
+	}
+	if config != nil {
+		data.KeycloakURL = strings.TrimSuffix(config.Issuer, "/realms/"+realm)
+		data.IssuerURL = config.Issuer
+		data.TokenURL = config.TokenEndpoint
+		data.AuthURL = config.AuthorizationEndpoint
+		data.UserinfoURL = config.UserinfoEndpoint
+		data.JWKSURL = config.JwksURI
+	}
+	return data
+}
+
+// RenderClientSecret sets the keys of the secret and adds the labels and annotations of the secret template, which
+// cannot refer to the client secret or private key. Without template the secret contains CLIENT_ID and CLIENT_SECRET, or CLIENT_ID only for clients with a key pair. A previous
+// secret still valid after a rotation is published as CLIENT_SECRET_PREVIOUS and a key pair as PRIVATE_KEY and KEY_ID
+// in any case.
+func RenderClientSecret(secret *v1.Secret, secretTemplate *v1alpha1.KeycloakClientSecretTemplate, data ClientSecretTemplateData) error {
+	keys := defaultClientSecretData
+	if data.PrivateKey != "" {
+		keys = defaultClientKeyPairData
+	}
+	var labels, annotations map[string]string
+	if secretTemplate != nil {
+		if len(secretTemplate.Data) > 0 {
+			keys = secretTemplate.Data
+		}
+		labels = secretTemplate.Labels
+		annotations = secretTemplate.Annotations
+	}
+
+	rendered, err := renderTemplates("data", keys, data)
+	if err != nil {
+		return err
+	}
+	secret.Data = map[string][]byte{}
+	for key, value := range rendered {
+		secret.Data[key] = []byte(value)
+	}
+	if data.PreviousClientSecret != "" {
+		secret.Data[ClientSecretPreviousClientSecretProperty] = []byte(data.PreviousClientSecret)
+	}
+	if data.PrivateKey != "" {
+		// the key pair is read back from these keys, whatever the template
+		secret.Data[ClientSecretPrivateKeyProperty] = []byte(data.PrivateKey)
+		secret.Data[ClientSecretKeyIDProperty] = []byte(data.KeyID)
+	}
+
+	rendered, err = renderTemplates("labels", labels, data.metadata())
+	if err != nil {
+		return err
+	}
+	for key, value := range rendered {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[key] = value
+	}
+
+	rendered, err = renderTemplates("annotations", annotations, data.metadata())
+	if err != nil {
+		return err
+	}
+	for key, value := range rendered {
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[key] = value
+	}
+	return nil
+}
+
+// ValidateClientSecretTemplate returns an error if a template of the secret template does not parse or refers to
+// data that does not exist, e.g. a label referring to the client secret
+func ValidateClientSecretTemplate(secretTemplate *v1alpha1.KeycloakClientSecretTemplate) error {
+	if secretTemplate == nil {
+		return nil
+	}
+	return RenderClientSecret(&v1.Secret{}, secretTemplate, ClientSecretTemplateData{})
+}
+
+func renderTemplates(kind string, templates map[string]string, data any) (map[string]string, error) {
+	rendered := map[string]string{}
+	for key, text := range templates {
+		t, err := template.New(key).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid secret template %s %q", kind, key)
+		}
+		var value bytes.Buffer
+		if err := t.Execute(&value, data); err != nil {
+			return nil, errors.Wrapf(err, "invalid secret template %s %q", kind, key)
+		}
+		rendered[key] = value.String()
+	}
+	return rendered, nil
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTemplateTestClient(secretTemplate *v1alpha1.KeycloakClientSecretTemplate) *v1alpha1.KeycloakClient {
+	return &v1alpha1.KeycloakClient{
+		ObjectMeta: v13.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: v1alpha1.KeycloakClientSpec{
+			Client:         &v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "secret"},
+			SecretTemplate: secretTemplate,
+		},
+	}
+}
+
+func TestClientSecretTemplate_Default(t *testing.T) {
+	// given
+	cr := newTemplateTestClient(nil)
+	secret := ClientSecret(cr)
+
+	// when
+	err := RenderClientSecret(secret, cr.Spec.SecretTemplate, NewClientSecretTemplateData(cr, "test", nil))
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		ClientSecretClientIDProperty:     []byte("app"),
+		ClientSecretClientSecretProperty: []byte("secret"),
+	}, secret.Data)
+	assert.Equal(t, map[string]string{"app": ApplicationName}, secret.Labels)
+}
+
+func TestClientSecretTemplate_Custom(t *testing.T) {
+	// given
+	cr := newTemplateTestClient(&v1alpha1.KeycloakClientSecretTemplate{
+		Data: map[string]string{
+			"OIDC_CLIENT_ID":     "{{ .ClientID }}",
+			"OIDC_CLIENT_SECRET": "{{ .ClientSecret }}",
+			"OIDC_ISSUER":        "{{ .IssuerURL }}",
+			"OIDC_TOKEN_URL":     "{{ .TokenURL }}",
+			"OIDC_JWKS_URL":      "{{ .JWKSURL }}",
+			"KEYCLOAK_URL":       "{{ .KeycloakURL }}",
+			"REALM":              "{{ .Realm }}",
+		},
+		Labels:      map[string]string{"realm": "{{ .Realm }}"},
+		Annotations: map[string]string{"example.com/issuer": "{{ .IssuerURL }}"},
+	})
+	config := &v1alpha1.KeycloakOpenIDConfiguration{
+		Issuer:        "https://sso.example.com/auth/realms/test",
+		TokenEndpoint: "https://sso.example.com/auth/realms/test/protocol/openid-connect/token",
+		JwksURI:       "https://sso.example.com/auth/realms/test/protocol/openid-connect/certs",
+	}
+	secret := ClientSecret(cr)
+	data := NewClientSecretTemplateData(cr, "test", config)
+	data.PreviousClientSecret = "previous"
+
+	// when
+	err := RenderClientSecret(secret, cr.Spec.SecretTemplate, data)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"OIDC_CLIENT_ID":     []byte("app"),
+		"OIDC_CLIENT_SECRET": []byte("secret"),
+		"OIDC_ISSUER":        []byte("https://sso.example.com/auth/realms/test"),
+		"OIDC_TOKEN_URL":     []byte("https://sso.example.com/auth/realms/test/protocol/openid-connect/token"),
+		"OIDC_JWKS_URL":      []byte("https://sso.example.com/auth/realms/test/protocol/openid-connect/certs"),
+		"KEYCLOAK_URL":       []byte("https://sso.example.com/auth"),
+		"REALM":              []byte("test"),
+		// the previous secret of a rotation is always published
+		ClientSecretPreviousClientSecretProperty: []byte("previous"),
+	}, secret.Data)
+	assert.Equal(t, map[string]string{"app": ApplicationName, "realm": "test"}, secret.Labels)
+	assert.Equal(t, map[string]string{"example.com/issuer": "https://sso.example.com/auth/realms/test"}, secret.Annotations)
+}
+
+func TestClientSecretTemplate_Validate(t *testing.T) {
+	assert.NoError(t, ValidateClientSecretTemplate(nil))
+	assert.NoError(t, ValidateClientSecretTemplate(&v1alpha1.KeycloakClientSecretTemplate{
+		Data: map[string]string{"URL": "{{ .IssuerURL }}/account"},
+	}))
+	assert.ErrorContains(t, ValidateClientSecretTemplate(&v1alpha1.KeycloakClientSecretTemplate{
+		Data: map[string]string{"URL": "{{ .IssuerURL"},
+	}), `invalid secret template data "URL"`)
+	assert.ErrorContains(t, ValidateClientSecretTemplate(&v1alpha1.KeycloakClientSecretTemplate{
+		Labels: map[string]string{"issuer": "{{ .Issuer }}"},
+	}), `invalid secret template labels "issuer"`)
+	assert.NoError(t, ValidateClientSecretTemplate(&v1alpha1.KeycloakClientSecretTemplate{
+		Annotations: map[string]string{"issuer": "{{ .IssuerURL }}", "kid": "{{ .KeyID }}"},
+	}))
+	for _, field := range []string{"ClientSecret", "PreviousClientSecret", "PrivateKey"} {
+		assert.ErrorContains(t, ValidateClientSecretTemplate(&v1alpha1.KeycloakClientSecretTemplate{
+			Labels: map[string]string{"credential": "{{ ." + field + " }}"},
+		}), `invalid secret template labels "credential"`, field)
+		assert.ErrorContains(t, ValidateClientSecretTemplate(&v1alpha1.KeycloakClientSecretTemplate{
+			Annotations: map[string]string{"credential": "{{ ." + field + " }}"},
+		}), `invalid secret template annotations "credential"`, field)
+	}
+}
@@ -825,7 +825,7 @@ func keycloakClientSecretUpdatesToSecretSeedWhenClientIsRemoved() error {
 	}
 	fmt.Println("Made AuthenticatedClient")
 	fmt.Println("Delete Client in Keycloak")
-	err = authenticatedClient.DeleteClient(client.Spec.Client.ClientID, realmName)
+	err = authenticatedClient.DeleteClient(context.Background(), client.Spec.Client.ClientID, realmName)
 	fmt.Println("Deleted Client in Keycloak")
 	if err != nil {
 		fmt.Println("Deleted Client in Keycloak" + err.Error())
@@ -953,7 +953,7 @@ func keycloakClientDefaultRolesTest() error {
 }
 
 func getClientRoleID(authenticatedClient common.KeycloakInterface, clientName, roleName string) (string, error) {
-	retrievedRoles, err := authenticatedClient.ListClientRoles(clientName, realmName)
+	retrievedRoles, err := authenticatedClient.ListClientRoles(context.Background(), clientName, realmName)
 	if err != nil {
 		return "", err
 	}
@@ -971,7 +971,7 @@ func getRole(retrievedRoles []keycloakv1alpha1.RoleRepresentation, roleName stri
 
 func waitForClientRoles(keycloakCR keycloakv1alpha1.Keycloak, clientCR *keycloakv1alpha1.KeycloakClient, expected []keycloakv1alpha1.RoleRepresentation) error {
 	return WaitForConditionWithClient(keycloakCR, func(authenticatedClient common.KeycloakInterface) error {
-		roles, err := authenticatedClient.ListClientRoles(clientCR.Spec.Client.ID, realmName)
+		roles, err := authenticatedClient.ListClientRoles(context.Background(), clientCR.Spec.Client.ID, realmName)
 		if err != nil {
 			return err
 		}
@@ -1010,12 +1010,12 @@ func waitForDefaultClientRoles(keycloakCR keycloakv1alpha1.Keycloak, clientCR *k
 		fmt.Println("waitForDefaultClientRoles")
 		fail := false
 
-		realm, err := authenticatedClient.GetRealm(realmName)
+		realm, err := authenticatedClient.GetRealm(context.Background(), realmName)
 		if err != nil {
 			return err
 		}
 
-		defaultRoles, err := authenticatedClient.ListRealmRoleClientRoleComposites(realmName, realm.Spec.Realm.DefaultRole.ID, clientCR.Spec.Client.ID)
+		defaultRoles, err := authenticatedClient.ListRealmRoleClientRoleComposites(context.Background(), realmName, realm.Spec.Realm.DefaultRole.ID, clientCR.Spec.Client.ID)
 		if err != nil {
 			return err
 		}
@@ -1179,7 +1179,7 @@ func keycloakClientScopeMappingsTest() error {
 		return err
 	}
 
-	retrievedMappings, err := authenticatedClient.ListScopeMappings(testKeycloakClientCRName, realmName)
+	retrievedMappings, err := authenticatedClient.ListScopeMappings(context.Background(), testKeycloakClientCRName, realmName)
 	if err != nil {
 		return err
 	}
@@ -1248,13 +1248,13 @@ func keycloakClientServiceAccountRealmRolesTest() error {
 
 func assertServiceAccountRoles(keycloakCR keycloakv1alpha1.Keycloak, clientID string, expectedRealmRoles []string, expectedClientRoles map[string][]string) {
 	err := WaitForConditionWithClient(keycloakCR, func(authenticatedClient common.KeycloakInterface) error {
-		serviceAccountUser, err := authenticatedClient.GetServiceAccountUser(realmName, clientID)
+		serviceAccountUser, err := authenticatedClient.GetServiceAccountUser(context.Background(), realmName, clientID)
 		if err != nil {
 			return err
 		}
 
 		// get realm role names
-		actualRealmRoles, err := authenticatedClient.ListUserRealmRoles(realmName, serviceAccountUser.ID)
+		actualRealmRoles, err := authenticatedClient.ListUserRealmRoles(context.Background(), realmName, serviceAccountUser.ID)
 		if err != nil {
 			return err
 		}
@@ -1266,7 +1266,7 @@ func assertServiceAccountRoles(keycloakCR keycloakv1alpha1.Keycloak, clientID st
 		// get role names for all specified clients
 		var actualClientRolesNames = map[string][]string{}
 		for k := range expectedClientRoles {
-			roles, err := authenticatedClient.ListUserClientRoles(realmName, k, serviceAccountUser.ID)
+			roles, err := authenticatedClient.ListUserClientRoles(context.Background(), realmName, k, serviceAccountUser.ID)
 			if err != nil {
 				return err
 			}
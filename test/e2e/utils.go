@@ -104,7 +104,7 @@ func WaitForConditionWithClient(keycloakCR keycloakv1alpha1.Keycloak, cond Clien
 
 func MakeAuthenticatedClient(keycloakCR keycloakv1alpha1.Keycloak) (common.KeycloakInterface, error) {
 	keycloakFactory := common.LocalConfigKeycloakFactory{}
-	return keycloakFactory.AuthenticatedClient(keycloakCR, true)
+	return keycloakFactory.AuthenticatedClient(context.Background(), keycloakCR, true)
 }
 
 func WaitForKeycloakToBeReady(namespace string, name string) error {
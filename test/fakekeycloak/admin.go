@@ -0,0 +1,926 @@
+package fakekeycloak
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// serveAdmin serves the admin API below /admin/realms
+func (s *Server) serveAdmin(w http.ResponseWriter, req *http.Request, segments []string) {
+	if len(segments) > 0 && segments[len(segments)-1] == "" {
+		// e.g. /admin/realms/test/clients/?clientId=test
+		segments = segments[:len(segments)-1]
+	}
+
+	if len(segments) == 0 {
+		s.serveRealms(w, req)
+		return
+	}
+
+	r, ok := s.realms[segments[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Realm not found.")
+		return
+	}
+
+	if len(segments) == 1 {
+		s.serveRealm(w, req, r)
+		return
+	}
+
+	switch segments[1] {
+	case "clients":
+		r.serveClients(w, req, segments[2:])
+	case "client-scopes":
+		r.serveClientScopes(w, req, segments[2:])
+	case "roles":
+		r.serveRealmRoles(w, req, segments[2:])
+	case "roles-by-id":
+		r.serveRolesByID(w, req, segments[2:])
+	case "users":
+		r.serveUsers(w, req, segments[2:])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) serveRealms(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		realms := []*v1alpha1.KeycloakRealm{}
+		for _, r := range s.realms {
+			realms = append(realms, &v1alpha1.KeycloakRealm{Spec: v1alpha1.KeycloakRealmSpec{Realm: r.representationWithClientScopes()}})
+		}
+		writeJSON(w, http.StatusOK, realms)
+	case http.MethodPost:
+		representation := v1alpha1.KeycloakAPIRealm{}
+		if !decode(w, req, &representation) {
+			return
+		}
+		if _, exists := s.realms[representation.Realm]; exists {
+			writeError(w, http.StatusConflict, "Conflict detected. See logs for details")
+			return
+		}
+		r := s.addRealm(representation)
+		writeCreated(w, req, r.representation.Realm)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) serveRealm(w http.ResponseWriter, req *http.Request, r *realm) {
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, r.representationWithClientScopes())
+	case http.MethodPut:
+		representation := v1alpha1.KeycloakRealm{}
+		if !decode(w, req, &representation) {
+			return
+		}
+		if representation.Spec.Realm != nil {
+			r.representation.Enabled = representation.Spec.Realm.Enabled
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(s.realms, r.representation.Realm)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (r *realm) representationWithClientScopes() *v1alpha1.KeycloakAPIRealm {
+	representation := r.representation
+	representation.ClientScopes = append([]v1alpha1.KeycloakClientScope{}, r.clientScopes...)
+	return &representation
+}
+
+func (r *realm) serveClients(w http.ResponseWriter, req *http.Request, segments []string) {
+	if len(segments) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			clients := []v1alpha1.KeycloakAPIClient{}
+			clientID := req.URL.Query().Get("clientId")
+			for _, c := range r.clients {
+				if clientID == "" || c.representation.ClientID == clientID {
+					clients = append(clients, c.representation)
+				}
+			}
+			writeJSON(w, http.StatusOK, clients)
+		case http.MethodPost:
+			representation := v1alpha1.KeycloakAPIClient{}
+			if !decode(w, req, &representation) {
+				return
+			}
+			c, status, msg := r.addClient(representation)
+			if c == nil {
+				writeError(w, status, msg)
+				return
+			}
+			writeCreated(w, req, c.representation.ID)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	c, ok := r.clients[segments[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Could not find client")
+		return
+	}
+
+	if len(segments) == 1 {
+		r.serveClient(w, req, c)
+		return
+	}
+
+	switch segments[1] {
+	case "client-secret":
+		r.serveClientSecret(w, req, c, segments[2:])
+	case "installation":
+		r.serveInstallation(w, req, c, segments[2:])
+	case "roles":
+		r.serveClientRoles(w, req, c, segments[2:])
+	case "protocol-mappers":
+		r.serveProtocolMappers(w, req, c, segments[2:])
+	case "authz":
+		r.serveAuthorization(w, req, c, segments[2:])
+	case "scope-mappings":
+		r.serveScopeMappings(w, req, c, segments[2:])
+	case "default-client-scopes":
+		r.serveClientClientScopes(w, req, &c.defaultClientScopes, segments[2:])
+	case "optional-client-scopes":
+		r.serveClientClientScopes(w, req, &c.optionalClientScopes, segments[2:])
+	case "service-account-user":
+		if c.serviceAccountUserID == "" {
+			writeError(w, http.StatusBadRequest, "Service account not enabled for the client")
+			return
+		}
+		writeJSON(w, http.StatusOK, r.users[c.serviceAccountUserID].representation)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// serveInstallation serves the documents of the installation providers keycloak-oidc-keycloak-json,
+// saml-idp-descriptor and mod-auth-mellon, the latter as a zip like Keycloak
+func (r *realm) serveInstallation(w http.ResponseWriter, req *http.Request, c *client, segments []string) {
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if len(segments) != 2 || segments[0] != "providers" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch segments[1] {
+	case "keycloak-oidc-keycloak-json":
+		document := map[string]interface{}{
+			"realm":    r.representation.Realm,
+			"resource": c.representation.ClientID,
+		}
+		if c.representation.PublicClient {
+			document["public-client"] = true
+		} else {
+			document["credentials"] = map[string]string{"secret": c.representation.Secret}
+		}
+		writeJSON(w, http.StatusOK, document)
+	case "saml-idp-descriptor":
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `<EntityDescriptor entityID="%s"></EntityDescriptor>`, r.representation.Realm)
+	case "mod-auth-mellon":
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte{'P', 'K', 0x05, 0x06, 0xff})
+	default:
+		writeError(w, http.StatusNotFound, "Unknown Provider")
+	}
+}
+
+// serveClientSecret serves the secret of a client and, with the client secret rotation feature enabled, the previous
+// secret kept valid after the secret is regenerated
+func (r *realm) serveClientSecret(w http.ResponseWriter, req *http.Request, c *client, segments []string) {
+	if len(segments) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]string{"type": "secret", "value": c.representation.Secret})
+		case http.MethodPost:
+			if r.server.featureEnabled("CLIENT_SECRET_ROTATION") {
+				c.rotatedSecret = c.representation.Secret
+			}
+			c.representation.Secret = string(uuid.NewUUID())
+			writeJSON(w, http.StatusOK, map[string]string{"type": "secret", "value": c.representation.Secret})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	if len(segments) != 1 || segments[0] != "rotated" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		if c.rotatedSecret == "" {
+			writeError(w, http.StatusNotFound, "Client does not have a rotated secret")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"type": "secret", "value": c.rotatedSecret})
+	case http.MethodDelete:
+		c.rotatedSecret = ""
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (r *realm) serveClient(w http.ResponseWriter, req *http.Request, c *client) {
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, c.representation)
+	case http.MethodPut:
+		representation := v1alpha1.KeycloakAPIClient{}
+		if !decode(w, req, &representation) {
+			return
+		}
+		if other := r.clientByClientID(representation.ClientID); other != nil && other != c {
+			writeError(w, http.StatusConflict, "Client "+representation.ClientID+" already exists")
+			return
+		}
+		representation.ID = c.representation.ID
+		if representation.Secret == "" {
+			representation.Secret = c.representation.Secret
+		}
+		// like Keycloak, protocol mappers are only changed via their own endpoints
+		representation.ProtocolMappers = c.representation.ProtocolMappers
+		if !r.updateAuthorization(w, c, representation) {
+			return
+		}
+		representation.AuthorizationSettings = nil
+		c.representation = representation
+		r.ensureServiceAccount(c)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		r.deleteClient(c)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (r *realm) addClient(representation v1alpha1.KeycloakAPIClient) (*client, int, string) {
+	if representation.ClientID == "" {
+		return nil, http.StatusBadRequest, "clientId is required"
+	}
+	if r.clientByClientID(representation.ClientID) != nil {
+		return nil, http.StatusConflict, "Client " + representation.ClientID + " already exists"
+	}
+	if representation.ID == "" {
+		representation.ID = string(uuid.NewUUID())
+	}
+	if representation.Secret == "" && !representation.PublicClient {
+		representation.Secret = string(uuid.NewUUID())
+	}
+	representation.ProtocolMappers = append([]v1alpha1.KeycloakProtocolMapper{}, representation.ProtocolMappers...)
+	for i := range representation.ProtocolMappers {
+		representation.ProtocolMappers[i].ID = string(uuid.NewUUID())
+	}
+
+	c := &client{representation: representation}
+	if representation.AuthorizationServicesEnabled {
+		authorization, status, msg := newAuthorization(c, representation.AuthorizationSettings)
+		if authorization == nil {
+			return nil, status, msg
+		}
+		c.authorization = authorization
+		c.representation.AuthorizationSettings = nil
+	}
+	defaultClientScopes := representation.DefaultClientScopes
+	if defaultClientScopes == nil {
+		defaultClientScopes = r.defaultClientScopes
+	}
+	optionalClientScopes := representation.OptionalClientScopes
+	if optionalClientScopes == nil {
+		optionalClientScopes = realmOptionalClientScopes
+	}
+	c.defaultClientScopes = r.clientScopeIDs(defaultClientScopes)
+	c.optionalClientScopes = r.clientScopeIDs(optionalClientScopes)
+
+	r.clients[representation.ID] = c
+	r.ensureServiceAccount(c)
+	return c, http.StatusCreated, ""
+}
+
+// updateAuthorization creates the resource server of a client once authorization services are enabled and removes
+// it once they are disabled. The authorization settings of existing resource servers are not changed by client
+// updates.
+func (r *realm) updateAuthorization(w http.ResponseWriter, c *client, representation v1alpha1.KeycloakAPIClient) bool {
+	if !representation.AuthorizationServicesEnabled {
+		c.authorization = nil
+		return true
+	}
+	if c.authorization != nil {
+		return true
+	}
+	authorization, status, msg := newAuthorization(c, representation.AuthorizationSettings)
+	if authorization == nil {
+		writeError(w, status, msg)
+		return false
+	}
+	c.authorization = authorization
+	return true
+}
+
+// ensureServiceAccount creates the service account user of a client once service accounts are enabled
+func (r *realm) ensureServiceAccount(c *client) {
+	if !c.representation.ServiceAccountsEnabled || c.serviceAccountUserID != "" {
+		return
+	}
+	u := &user{representation: v1alpha1.KeycloakAPIUser{
+		ID:       string(uuid.NewUUID()),
+		UserName: "service-account-" + c.representation.ClientID,
+		Enabled:  true,
+	}}
+	if r.representation.DefaultRole != nil {
+		u.roles = []string{r.representation.DefaultRole.ID}
+	}
+	r.users[u.representation.ID] = u
+	c.serviceAccountUserID = u.representation.ID
+
+	for _, mapper := range [][2]string{{"Client ID", "client_id"}, {"Client Host", "clientHost"}, {"Client IP Address", "clientAddress"}} {
+		c.representation.ProtocolMappers = append(c.representation.ProtocolMappers, v1alpha1.KeycloakProtocolMapper{
+			ID:             string(uuid.NewUUID()),
+			Name:           mapper[0],
+			Protocol:       "openid-connect",
+			ProtocolMapper: "oidc-usersessionmodel-note-mapper",
+			Config:         map[string]string{"user.session.note": mapper[1], "claim.name": mapper[1]},
+		})
+	}
+}
+
+func (r *realm) deleteClient(c *client) {
+	delete(r.clients, c.representation.ID)
+	delete(r.users, c.serviceAccountUserID)
+	for _, role := range r.clientRoles(c.representation.ID) {
+		r.deleteRole(role.representation.ID)
+	}
+}
+
+func (r *realm) clientByClientID(clientID string) *client {
+	for _, c := range r.clients {
+		if c.representation.ClientID == clientID {
+			return c
+		}
+	}
+	return nil
+}
+
+func (r *realm) serveClientRoles(w http.ResponseWriter, req *http.Request, c *client, segments []string) {
+	if len(segments) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			roles := []v1alpha1.RoleRepresentation{}
+			for _, role := range r.clientRoles(c.representation.ID) {
+				roles = append(roles, role.representation)
+			}
+			writeJSON(w, http.StatusOK, roles)
+		case http.MethodPost:
+			representation := v1alpha1.RoleRepresentation{}
+			if !decode(w, req, &representation) {
+				return
+			}
+			if r.roleByName(representation.Name, c.representation.ID) != nil {
+				writeError(w, http.StatusConflict, "Role with name "+representation.Name+" already exists")
+				return
+			}
+			role := r.addRole(representation, c.representation.ID)
+			writeCreated(w, req, role.representation.Name)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	role := r.roleByName(segments[0], c.representation.ID)
+	if role == nil {
+		writeError(w, http.StatusNotFound, "Could not find role")
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, role.representation)
+	case http.MethodPut:
+		representation := v1alpha1.RoleRepresentation{}
+		if !decode(w, req, &representation) {
+			return
+		}
+		if other := r.roleByName(representation.Name, c.representation.ID); other != nil && other != role {
+			writeError(w, http.StatusConflict, "Role with name "+representation.Name+" already exists")
+			return
+		}
+		role.representation.Name = representation.Name
+		role.representation.Description = representation.Description
+		role.representation.Attributes = representation.Attributes
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		r.deleteRole(role.representation.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (r *realm) serveProtocolMappers(w http.ResponseWriter, req *http.Request, c *client, segments []string) {
+	if len(segments) == 0 || segments[0] != "models" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if len(segments) == 1 {
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, append([]v1alpha1.KeycloakProtocolMapper{}, c.representation.ProtocolMappers...))
+		case http.MethodPost:
+			representation := v1alpha1.KeycloakProtocolMapper{}
+			if !decode(w, req, &representation) {
+				return
+			}
+			if c.protocolMapperIndex(func(m v1alpha1.KeycloakProtocolMapper) bool { return m.Name == representation.Name }) >= 0 {
+				writeError(w, http.StatusConflict, "Protocol mapper exists with same name")
+				return
+			}
+			representation.ID = string(uuid.NewUUID())
+			c.representation.ProtocolMappers = append(c.representation.ProtocolMappers, representation)
+			writeCreated(w, req, representation.ID)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	index := c.protocolMapperIndex(func(m v1alpha1.KeycloakProtocolMapper) bool { return m.ID == segments[1] })
+	if index < 0 {
+		writeError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, c.representation.ProtocolMappers[index])
+	case http.MethodPut:
+		representation := v1alpha1.KeycloakProtocolMapper{}
+		if !decode(w, req, &representation) {
+			return
+		}
+		existing := &c.representation.ProtocolMappers[index]
+		if representation.ID != existing.ID {
+			writeError(w, http.StatusBadRequest, "Cannot update protocol mapper with a different ID")
+			return
+		}
+		existing.ConsentRequired = representation.ConsentRequired
+		existing.ConsentText = representation.ConsentText
+		existing.Config = representation.Config
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		c.representation.ProtocolMappers = append(c.representation.ProtocolMappers[:index], c.representation.ProtocolMappers[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (c *client) protocolMapperIndex(matches func(v1alpha1.KeycloakProtocolMapper) bool) int {
+	for i, mapper := range c.representation.ProtocolMappers {
+		if matches(mapper) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *realm) serveRealmRoles(w http.ResponseWriter, req *http.Request, segments []string) {
+	if len(segments) != 0 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		roles := []v1alpha1.RoleRepresentation{}
+		for _, role := range r.roles {
+			if role.clientID == "" {
+				roles = append(roles, role.representation)
+			}
+		}
+		writeJSON(w, http.StatusOK, roles)
+	case http.MethodPost:
+		representation := v1alpha1.RoleRepresentation{}
+		if !decode(w, req, &representation) {
+			return
+		}
+		if r.roleByName(representation.Name, "") != nil {
+			writeError(w, http.StatusConflict, "Role with name "+representation.Name+" already exists")
+			return
+		}
+		role := r.addRole(representation, "")
+		writeCreated(w, req, role.representation.Name)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (r *realm) addRole(representation v1alpha1.RoleRepresentation, clientID string) *role {
+	representation.ID = string(uuid.NewUUID())
+	clientRole := clientID != ""
+	representation.ClientRole = &clientRole
+	representation.ContainerID = r.representation.ID
+	if clientRole {
+		representation.ContainerID = clientID
+	}
+	representation.Composites = nil
+	composite := false
+	representation.Composite = &composite
+
+	role := &role{representation: representation, clientID: clientID}
+	r.roles[representation.ID] = role
+	return role
+}
+
+func (r *realm) deleteRole(roleID string) {
+	delete(r.roles, roleID)
+	delete(r.composites, roleID)
+	for id, composites := range r.composites {
+		r.composites[id] = without(composites, roleID)
+	}
+	for _, c := range r.clients {
+		c.scopeMappings = without(c.scopeMappings, roleID)
+	}
+	for _, u := range r.users {
+		u.roles = without(u.roles, roleID)
+	}
+}
+
+func (r *realm) clientRoles(clientID string) []*role {
+	var roles []*role
+	for _, role := range r.roles {
+		if role.clientID == clientID {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func (r *realm) roleByName(name, clientID string) *role {
+	for _, role := range r.roles {
+		if role.clientID == clientID && role.representation.Name == name {
+			return role
+		}
+	}
+	return nil
+}
+
+// qualifiedRoleName returns the name of a realm role or clientId/name of a client role
+func (r *realm) qualifiedRoleName(roleID string) string {
+	role, ok := r.roles[roleID]
+	if !ok {
+		return ""
+	}
+	if role.clientID == "" {
+		return role.representation.Name
+	}
+	return r.clients[role.clientID].representation.ClientID + "/" + role.representation.Name
+}
+
+// roleIDs resolves the roles of a request body by their IDs, like Keycloak does for role mappings
+func (r *realm) roleIDs(w http.ResponseWriter, roles []v1alpha1.RoleRepresentation, clientID string) ([]string, bool) {
+	var ids []string
+	for _, representation := range roles {
+		role, ok := r.roles[representation.ID]
+		if !ok || role.clientID != clientID {
+			writeError(w, http.StatusNotFound, "Could not find role")
+			return nil, false
+		}
+		ids = append(ids, role.representation.ID)
+	}
+	return ids, true
+}
+
+func (r *realm) roleRepresentations(roleIDs []string, clientID string) []v1alpha1.RoleRepresentation {
+	roles := []v1alpha1.RoleRepresentation{}
+	for _, id := range roleIDs {
+		if role, ok := r.roles[id]; ok && role.clientID == clientID {
+			roles = append(roles, role.representation)
+		}
+	}
+	return roles
+}
+
+func (r *realm) serveScopeMappings(w http.ResponseWriter, req *http.Request, c *client, segments []string) {
+	if len(segments) == 0 {
+		mappings := v1alpha1.MappingsRepresentation{
+			RealmMappings:  r.roleRepresentations(c.scopeMappings, ""),
+			ClientMappings: map[string]v1alpha1.ClientMappingsRepresentation{},
+		}
+		for _, other := range r.clients {
+			roles := r.roleRepresentations(c.scopeMappings, other.representation.ID)
+			if len(roles) > 0 {
+				mappings.ClientMappings[other.representation.ClientID] = v1alpha1.ClientMappingsRepresentation{
+					ID:       other.representation.ID,
+					Client:   other.representation.ClientID,
+					Mappings: roles,
+				}
+			}
+		}
+		writeJSON(w, http.StatusOK, mappings)
+		return
+	}
+
+	var clientID string
+	switch {
+	case len(segments) == 1 && segments[0] == "realm":
+	case len(segments) == 2 && segments[0] == "clients":
+		if _, ok := r.clients[segments[1]]; !ok {
+			writeError(w, http.StatusNotFound, "Could not find client")
+			return
+		}
+		clientID = segments[1]
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	r.serveRoleList(w, req, &c.scopeMappings, clientID)
+}
+
+// serveRoleList lists, adds or removes the roles of a client or the realm in a list of role IDs
+func (r *realm) serveRoleList(w http.ResponseWriter, req *http.Request, roleIDs *[]string, clientID string) {
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, r.roleRepresentations(*roleIDs, clientID))
+	case http.MethodPost, http.MethodDelete:
+		roles := []v1alpha1.RoleRepresentation{}
+		if !decode(w, req, &roles) {
+			return
+		}
+		ids, ok := r.roleIDs(w, roles, clientID)
+		if !ok {
+			return
+		}
+		for _, id := range ids {
+			*roleIDs = without(*roleIDs, id)
+			if req.Method == http.MethodPost {
+				*roleIDs = append(*roleIDs, id)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (r *realm) serveRolesByID(w http.ResponseWriter, req *http.Request, segments []string) {
+	if len(segments) < 2 || segments[1] != "composites" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	role, ok := r.roles[segments[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Could not find role")
+		return
+	}
+	composites := r.composites[role.representation.ID]
+
+	switch {
+	case len(segments) == 2 && (req.Method == http.MethodPost || req.Method == http.MethodDelete):
+		roles := []v1alpha1.RoleRepresentation{}
+		if !decode(w, req, &roles) {
+			return
+		}
+		for _, representation := range roles {
+			if _, ok := r.roles[representation.ID]; !ok {
+				writeError(w, http.StatusNotFound, "Could not find composite role")
+				return
+			}
+			composites = without(composites, representation.ID)
+			if req.Method == http.MethodPost {
+				composites = append(composites, representation.ID)
+			}
+		}
+		r.composites[role.representation.ID] = composites
+		composite := len(composites) > 0
+		role.representation.Composite = &composite
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 4 && segments[2] == "clients" && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, r.roleRepresentations(composites, segments[3]))
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (r *realm) serveClientScopes(w http.ResponseWriter, req *http.Request, segments []string) {
+	if len(segments) != 0 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, r.clientScopes)
+	case http.MethodPost:
+		representation := v1alpha1.KeycloakClientScope{}
+		if !decode(w, req, &representation) {
+			return
+		}
+		if len(r.clientScopeIDs([]string{representation.Name})) > 0 {
+			writeError(w, http.StatusConflict, "Client Scope "+representation.Name+" already exists")
+			return
+		}
+		representation.ID = string(uuid.NewUUID())
+		r.clientScopes = append(r.clientScopes, representation)
+		writeCreated(w, req, representation.ID)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (r *realm) serveClientClientScopes(w http.ResponseWriter, req *http.Request, clientScopeIDs *[]string, segments []string) {
+	if len(segments) == 0 && req.Method == http.MethodGet {
+		clientScopes := []v1alpha1.KeycloakClientScope{}
+		for _, id := range *clientScopeIDs {
+			if clientScope := r.clientScope(id); clientScope != nil {
+				clientScopes = append(clientScopes, v1alpha1.KeycloakClientScope{ID: clientScope.ID, Name: clientScope.Name})
+			}
+		}
+		writeJSON(w, http.StatusOK, clientScopes)
+		return
+	}
+	if len(segments) != 1 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.clientScope(segments[0]) == nil {
+		writeError(w, http.StatusNotFound, "Client scope not found")
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPut:
+		*clientScopeIDs = append(without(*clientScopeIDs, segments[0]), segments[0])
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		*clientScopeIDs = without(*clientScopeIDs, segments[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (r *realm) clientScope(id string) *v1alpha1.KeycloakClientScope {
+	for i := range r.clientScopes {
+		if r.clientScopes[i].ID == id {
+			return &r.clientScopes[i]
+		}
+	}
+	return nil
+}
+
+func (r *realm) clientScopeIDs(names []string) []string {
+	var ids []string
+	for _, name := range names {
+		for _, clientScope := range r.clientScopes {
+			if clientScope.Name == name {
+				ids = append(ids, clientScope.ID)
+			}
+		}
+	}
+	return ids
+}
+
+func (r *realm) serveUsers(w http.ResponseWriter, req *http.Request, segments []string) {
+	if len(segments) < 2 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	u, ok := r.users[segments[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	switch segments[1] {
+	case "role-mappings":
+		r.serveRoleMappings(w, req, u, segments[2:])
+	case "federated-identity":
+		r.serveFederatedIdentities(w, req, u, segments[2:])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (r *realm) serveRoleMappings(w http.ResponseWriter, req *http.Request, u *user, segments []string) {
+	var clientID string
+	switch {
+	case len(segments) >= 1 && segments[0] == "realm":
+		segments = segments[1:]
+	case len(segments) >= 2 && segments[0] == "clients":
+		if _, ok := r.clients[segments[1]]; !ok {
+			writeError(w, http.StatusNotFound, "Client not found")
+			return
+		}
+		clientID = segments[1]
+		segments = segments[2:]
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if len(segments) == 0 {
+		r.serveRoleList(w, req, &u.roles, clientID)
+		return
+	}
+	if len(segments) != 1 || segments[0] != "available" || req.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	available := []v1alpha1.RoleRepresentation{}
+	for _, role := range r.roles {
+		if role.clientID == clientID && !contains(u.roles, role.representation.ID) {
+			available = append(available, role.representation)
+		}
+	}
+	writeJSON(w, http.StatusOK, available)
+}
+
+func (r *realm) serveFederatedIdentities(w http.ResponseWriter, req *http.Request, u *user, segments []string) {
+	if len(segments) == 0 && req.Method == http.MethodGet {
+		identities := append([]v1alpha1.FederatedIdentity{}, u.federatedIdentities...)
+		writeJSON(w, http.StatusOK, identities)
+		return
+	}
+	if len(segments) != 1 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	var identities []v1alpha1.FederatedIdentity
+	for _, identity := range u.federatedIdentities {
+		if identity.IdentityProvider != segments[0] {
+			identities = append(identities, identity)
+		}
+	}
+	switch req.Method {
+	case http.MethodPost:
+		identity := v1alpha1.FederatedIdentity{}
+		if !decode(w, req, &identity) {
+			return
+		}
+		if len(identities) != len(u.federatedIdentities) {
+			writeError(w, http.StatusConflict, "User is already linked with provider")
+			return
+		}
+		identity.IdentityProvider = segments[0]
+		u.federatedIdentities = append(identities, identity)
+		writeCreated(w, req, segments[0])
+	case http.MethodDelete:
+		if len(identities) == len(u.federatedIdentities) {
+			writeError(w, http.StatusNotFound, "Link not found")
+			return
+		}
+		u.federatedIdentities = identities
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func decode(w http.ResponseWriter, req *http.Request, obj interface{}) bool {
+	if err := json.NewDecoder(req.Body).Decode(obj); err != nil {
+		writeError(w, http.StatusBadRequest, "unable to read request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func without(ids []string, id string) []string {
+	var result []string
+	for _, i := range ids {
+		if i != id {
+			result = append(result, i)
+		}
+	}
+	return result
+}
@@ -0,0 +1,595 @@
+package fakekeycloak
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// authorization is the resource server of a client with authorization services enabled
+type authorization struct {
+	settings  v1alpha1.KeycloakResourceServer
+	scopes    []*v1alpha1.KeycloakScope
+	resources []*authzResource
+	policies  []*authzPolicy
+}
+
+// authzResource is a resource of a resource server. Like in Keycloak, resource attributes are multi-valued.
+type authzResource struct {
+	ID                 string              `json:"_id,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	DisplayName        string              `json:"displayName,omitempty"`
+	Type               string              `json:"type,omitempty"`
+	IconURI            string              `json:"icon_uri,omitempty"`
+	OwnerManagedAccess bool                `json:"ownerManagedAccess,omitempty"`
+	Uris               []string            `json:"uris,omitempty"`
+	Attributes         map[string][]string `json:"attributes,omitempty"`
+	// scopes given by representation or by name in requests, by representation in responses
+	Scopes []json.RawMessage `json:"scopes,omitempty"`
+
+	scopeIDs []string
+}
+
+// authzPolicy is a policy or permission of a resource server
+type authzPolicy struct {
+	representation v1alpha1.KeycloakPolicy
+	resourceIDs    []string
+	scopeIDs       []string
+	policyIDs      []string
+}
+
+// named is the representation of the resources, scopes and policies associated with a policy
+type named struct {
+	ID   string `json:"id,omitempty"`
+	UID  string `json:"_id,omitempty"`
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// newAuthorization creates the resource server of a client. Without settings, Keycloak creates a default
+// resource, policy and permission.
+func newAuthorization(c *client, settings *v1alpha1.KeycloakResourceServer) (*authorization, int, string) {
+	a := &authorization{settings: v1alpha1.KeycloakResourceServer{
+		ID:                    c.representation.ID,
+		ClientID:              c.representation.ID,
+		Name:                  c.representation.ClientID,
+		PolicyEnforcementMode: "ENFORCING",
+		DecisionStrategy:      "UNANIMOUS",
+	}}
+
+	if settings == nil {
+		resource := &authzResource{ID: string(uuid.NewUUID()), Name: "Default Resource", Type: "urn:" + c.representation.ClientID + ":resources:default", Uris: []string{"/*"}}
+		policy := &authzPolicy{representation: v1alpha1.KeycloakPolicy{ID: string(uuid.NewUUID()), Name: "Default Policy", Type: "js", Logic: "POSITIVE", DecisionStrategy: "AFFIRMATIVE"}}
+		permission := &authzPolicy{
+			representation: v1alpha1.KeycloakPolicy{ID: string(uuid.NewUUID()), Name: "Default Permission", Type: "resource", Logic: "POSITIVE", DecisionStrategy: "UNANIMOUS", Config: map[string]string{"defaultResourceType": resource.Type}},
+			policyIDs:      []string{policy.representation.ID},
+		}
+		a.resources = []*authzResource{resource}
+		a.policies = []*authzPolicy{policy, permission}
+		return a, 0, ""
+	}
+
+	a.updateSettings(*settings)
+	for i := range settings.Scopes {
+		if status, msg := a.addScope(settings.Scopes[i]); status != 0 {
+			return nil, status, msg
+		}
+	}
+	for _, resource := range settings.Resources {
+		if status, msg := a.addResource(importResource(resource)); status != 0 {
+			return nil, status, msg
+		}
+	}
+	for _, policy := range settings.Policies {
+		if status, msg := a.addPolicy(policy); status != 0 {
+			return nil, status, msg
+		}
+	}
+	return a, 0, ""
+}
+
+// importResource converts a resource of a client representation into the representation of the resources endpoint
+func importResource(resource v1alpha1.KeycloakResource) *authzResource {
+	representation := &authzResource{
+		ID:                 resource.ID,
+		Name:               resource.Name,
+		DisplayName:        resource.DisplayName,
+		Type:               resource.Type,
+		IconURI:            resource.IconURI,
+		OwnerManagedAccess: resource.OwnerManagedAccess,
+		Uris:               resource.Uris,
+	}
+	for key, value := range resource.Attributes {
+		if representation.Attributes == nil {
+			representation.Attributes = map[string][]string{}
+		}
+		representation.Attributes[key] = []string{value}
+	}
+	for _, scope := range resource.Scopes {
+		representation.Scopes = append(representation.Scopes, json.RawMessage(scope.Raw))
+	}
+	return representation
+}
+
+func (a *authorization) updateSettings(settings v1alpha1.KeycloakResourceServer) {
+	a.settings.AllowRemoteResourceManagement = settings.AllowRemoteResourceManagement
+	if settings.PolicyEnforcementMode != "" {
+		a.settings.PolicyEnforcementMode = settings.PolicyEnforcementMode
+	}
+	if settings.DecisionStrategy != "" {
+		a.settings.DecisionStrategy = settings.DecisionStrategy
+	}
+}
+
+func (r *realm) serveAuthorization(w http.ResponseWriter, req *http.Request, c *client, segments []string) {
+	if len(segments) == 0 || segments[0] != "resource-server" || c.authorization == nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	a := c.authorization
+
+	if len(segments) == 1 {
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, a.settings)
+		case http.MethodPut:
+			representation := v1alpha1.KeycloakResourceServer{}
+			if !decode(w, req, &representation) {
+				return
+			}
+			a.updateSettings(representation)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	switch segments[1] {
+	case "scope":
+		a.serveScopes(w, req, segments[2:])
+	case "resource":
+		a.serveResources(w, req, segments[2:])
+	case "policy":
+		a.servePolicies(w, req, segments[2:])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (a *authorization) serveScopes(w http.ResponseWriter, req *http.Request, segments []string) {
+	if len(segments) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			scopes := []v1alpha1.KeycloakScope{}
+			for _, scope := range a.scopes {
+				scopes = append(scopes, *scope)
+			}
+			writeJSON(w, http.StatusOK, scopes)
+		case http.MethodPost:
+			representation := v1alpha1.KeycloakScope{}
+			if !decode(w, req, &representation) {
+				return
+			}
+			if status, msg := a.addScope(representation); status != 0 {
+				writeError(w, status, msg)
+				return
+			}
+			writeJSON(w, http.StatusCreated, a.scopes[len(a.scopes)-1])
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	scope := a.scope(segments[0])
+	if scope == nil {
+		writeError(w, http.StatusNotFound, "Could not find scope")
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, scope)
+	case http.MethodPut:
+		representation := v1alpha1.KeycloakScope{}
+		if !decode(w, req, &representation) {
+			return
+		}
+		if other := a.scope(representation.Name); other != nil && other != scope {
+			writeError(w, http.StatusConflict, "Scope with name ["+representation.Name+"] already exists")
+			return
+		}
+		scope.Name = representation.Name
+		scope.DisplayName = representation.DisplayName
+		scope.IconURI = representation.IconURI
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		a.deleteScope(scope.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *authorization) addScope(representation v1alpha1.KeycloakScope) (int, string) {
+	if representation.Name == "" {
+		return http.StatusBadRequest, "Scope name is required"
+	}
+	if a.scope(representation.Name) != nil {
+		return http.StatusConflict, "Scope with name [" + representation.Name + "] already exists"
+	}
+	a.scopes = append(a.scopes, &v1alpha1.KeycloakScope{
+		ID:          string(uuid.NewUUID()),
+		Name:        representation.Name,
+		DisplayName: representation.DisplayName,
+		IconURI:     representation.IconURI,
+	})
+	return 0, ""
+}
+
+// scope returns the scope with the given ID or name
+func (a *authorization) scope(idOrName string) *v1alpha1.KeycloakScope {
+	for _, scope := range a.scopes {
+		if scope.ID == idOrName || scope.Name == idOrName {
+			return scope
+		}
+	}
+	return nil
+}
+
+func (a *authorization) deleteScope(id string) {
+	var scopes []*v1alpha1.KeycloakScope
+	for _, scope := range a.scopes {
+		if scope.ID != id {
+			scopes = append(scopes, scope)
+		}
+	}
+	a.scopes = scopes
+	for _, resource := range a.resources {
+		resource.scopeIDs = without(resource.scopeIDs, id)
+	}
+	for _, policy := range a.policies {
+		policy.scopeIDs = without(policy.scopeIDs, id)
+	}
+}
+
+func (a *authorization) serveResources(w http.ResponseWriter, req *http.Request, segments []string) {
+	if len(segments) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			resources := []authzResource{}
+			for _, resource := range a.resources {
+				resources = append(resources, a.resourceRepresentation(resource))
+			}
+			writeJSON(w, http.StatusOK, resources)
+		case http.MethodPost:
+			representation := &authzResource{}
+			if !decode(w, req, representation) {
+				return
+			}
+			if status, msg := a.addResource(representation); status != 0 {
+				writeError(w, status, msg)
+				return
+			}
+			writeJSON(w, http.StatusCreated, a.resourceRepresentation(representation))
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	resource := a.resource(segments[0])
+	if resource == nil {
+		writeError(w, http.StatusNotFound, "Could not find resource")
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.resourceRepresentation(resource))
+	case http.MethodPut:
+		representation := &authzResource{}
+		if !decode(w, req, representation) {
+			return
+		}
+		if other := a.resource(representation.Name); other != nil && other != resource {
+			writeError(w, http.StatusConflict, "Resource with name ["+representation.Name+"] already exists")
+			return
+		}
+		representation.ID = resource.ID
+		if status, msg := a.resolveResourceScopes(representation); status != 0 {
+			writeError(w, status, msg)
+			return
+		}
+		*resource = *representation
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		a.deleteResource(resource.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *authorization) addResource(representation *authzResource) (int, string) {
+	if representation.Name == "" {
+		return http.StatusBadRequest, "Resource name is required"
+	}
+	if a.resource(representation.Name) != nil {
+		return http.StatusConflict, "Resource with name [" + representation.Name + "] already exists"
+	}
+	representation.ID = string(uuid.NewUUID())
+	if status, msg := a.resolveResourceScopes(representation); status != 0 {
+		return status, msg
+	}
+	a.resources = append(a.resources, representation)
+	return 0, ""
+}
+
+// resolveResourceScopes looks up the scopes of a resource by ID or name. Like Keycloak, unknown scopes are created.
+func (a *authorization) resolveResourceScopes(representation *authzResource) (int, string) {
+	representation.scopeIDs = nil
+	for _, raw := range representation.Scopes {
+		scope := v1alpha1.KeycloakScope{}
+		if err := json.Unmarshal(raw, &scope); err != nil {
+			if err := json.Unmarshal(raw, &scope.Name); err != nil {
+				return http.StatusBadRequest, "invalid scope " + string(raw)
+			}
+		}
+		idOrName := scope.ID
+		if idOrName == "" {
+			idOrName = scope.Name
+		}
+		if a.scope(idOrName) == nil {
+			if status, msg := a.addScope(scope); status != 0 {
+				return status, msg
+			}
+		}
+		representation.scopeIDs = append(representation.scopeIDs, a.scope(idOrName).ID)
+	}
+	representation.Scopes = nil
+	return 0, ""
+}
+
+// resourceRepresentation returns a resource with the representations of its scopes
+func (a *authorization) resourceRepresentation(resource *authzResource) authzResource {
+	representation := *resource
+	representation.Scopes = nil
+	for _, id := range resource.scopeIDs {
+		scope, _ := json.Marshal(named{ID: id, Name: a.scope(id).Name})
+		representation.Scopes = append(representation.Scopes, scope)
+	}
+	return representation
+}
+
+// resource returns the resource with the given ID or name
+func (a *authorization) resource(idOrName string) *authzResource {
+	for _, resource := range a.resources {
+		if resource.ID == idOrName || resource.Name == idOrName {
+			return resource
+		}
+	}
+	return nil
+}
+
+func (a *authorization) deleteResource(id string) {
+	var resources []*authzResource
+	for _, resource := range a.resources {
+		if resource.ID != id {
+			resources = append(resources, resource)
+		}
+	}
+	a.resources = resources
+	for _, policy := range a.policies {
+		policy.resourceIDs = without(policy.resourceIDs, id)
+	}
+}
+
+func (a *authorization) servePolicies(w http.ResponseWriter, req *http.Request, segments []string) {
+	if len(segments) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			// like Keycloak, the associated resources, scopes and policies are only available by policy
+			policies := []v1alpha1.KeycloakPolicy{}
+			for _, policy := range a.policies {
+				policies = append(policies, policy.representation)
+			}
+			writeJSON(w, http.StatusOK, policies)
+		case http.MethodPost:
+			representation := v1alpha1.KeycloakPolicy{}
+			if !decode(w, req, &representation) {
+				return
+			}
+			if status, msg := a.addPolicy(representation); status != 0 {
+				writeError(w, status, msg)
+				return
+			}
+			writeJSON(w, http.StatusCreated, a.policies[len(a.policies)-1].representation)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	policy := a.policy(segments[0])
+	if policy == nil {
+		writeError(w, http.StatusNotFound, "Could not find policy")
+		return
+	}
+
+	if len(segments) == 2 {
+		associations := []named{}
+		switch segments[1] {
+		case "resources":
+			for _, id := range policy.resourceIDs {
+				associations = append(associations, named{UID: id, Name: a.resource(id).Name})
+			}
+		case "scopes":
+			for _, id := range policy.scopeIDs {
+				associations = append(associations, named{ID: id, Name: a.scope(id).Name})
+			}
+		case "associatedPolicies":
+			for _, id := range policy.policyIDs {
+				associated := a.policy(id).representation
+				associations = append(associations, named{ID: id, Name: associated.Name, Type: associated.Type})
+			}
+		default:
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, associations)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, policy.representation)
+	case http.MethodPut:
+		representation := v1alpha1.KeycloakPolicy{}
+		if !decode(w, req, &representation) {
+			return
+		}
+		if other := a.policy(representation.Name); other != nil && other != policy {
+			writeError(w, http.StatusConflict, "Policy with name ["+representation.Name+"] already exists")
+			return
+		}
+		if representation.Type != "" && representation.Type != policy.representation.Type {
+			writeError(w, http.StatusBadRequest, "Policy type cannot be changed")
+			return
+		}
+		representation.ID = policy.representation.ID
+		representation.Type = policy.representation.Type
+		updated, status, msg := a.newPolicy(representation)
+		if updated == nil {
+			writeError(w, status, msg)
+			return
+		}
+		*policy = *updated
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		a.deletePolicy(policy.representation.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *authorization) addPolicy(representation v1alpha1.KeycloakPolicy) (int, string) {
+	if a.policy(representation.Name) != nil {
+		return http.StatusConflict, "Policy with name [" + representation.Name + "] already exists"
+	}
+	representation.ID = string(uuid.NewUUID())
+	policy, status, msg := a.newPolicy(representation)
+	if policy == nil {
+		return status, msg
+	}
+	a.policies = append(a.policies, policy)
+	return 0, ""
+}
+
+// newPolicy resolves the resources, scopes and policies of a policy, which are given by ID or name
+func (a *authorization) newPolicy(representation v1alpha1.KeycloakPolicy) (*authzPolicy, int, string) {
+	if representation.Name == "" || representation.Type == "" {
+		return nil, http.StatusBadRequest, "Policy name and type are required"
+	}
+	if representation.Logic == "" {
+		representation.Logic = "POSITIVE"
+	}
+	if representation.DecisionStrategy == "" {
+		representation.DecisionStrategy = "UNANIMOUS"
+	}
+
+	policy := &authzPolicy{}
+	for _, idOrName := range representation.Resources {
+		resource := a.resource(idOrName)
+		if resource == nil {
+			return nil, http.StatusBadRequest, "Resource with id [" + idOrName + "] does not exist"
+		}
+		policy.resourceIDs = append(policy.resourceIDs, resource.ID)
+	}
+	for _, idOrName := range representation.Scopes {
+		scope := a.scope(idOrName)
+		if scope == nil {
+			return nil, http.StatusBadRequest, "Scope with id [" + idOrName + "] does not exist"
+		}
+		policy.scopeIDs = append(policy.scopeIDs, scope.ID)
+	}
+	for _, idOrName := range representation.Policies {
+		associated := a.policy(idOrName)
+		if associated == nil {
+			return nil, http.StatusBadRequest, "Policy with id [" + idOrName + "] does not exist"
+		}
+		policy.policyIDs = append(policy.policyIDs, associated.representation.ID)
+	}
+
+	representation.Resources, representation.Scopes, representation.Policies = nil, nil, nil
+	policy.representation = representation
+	return policy, 0, ""
+}
+
+// policy returns the policy with the given ID or name
+func (a *authorization) policy(idOrName string) *authzPolicy {
+	for _, policy := range a.policies {
+		if policy.representation.ID == idOrName || policy.representation.Name == idOrName {
+			return policy
+		}
+	}
+	return nil
+}
+
+func (a *authorization) deletePolicy(id string) {
+	var policies []*authzPolicy
+	for _, policy := range a.policies {
+		if policy.representation.ID != id {
+			policy.policyIDs = without(policy.policyIDs, id)
+			policies = append(policies, policy)
+		}
+	}
+	a.policies = policies
+}
+
+// representation returns the settings of the resource server with its scopes, resources and policies. The
+// associations of the policies are given by name.
+func (a *authorization) representation() *v1alpha1.KeycloakResourceServer {
+	settings := a.settings
+	for _, scope := range a.scopes {
+		settings.Scopes = append(settings.Scopes, *scope)
+	}
+	for _, resource := range a.resources {
+		representation := v1alpha1.KeycloakResource{
+			ID:                 resource.ID,
+			Name:               resource.Name,
+			DisplayName:        resource.DisplayName,
+			Type:               resource.Type,
+			IconURI:            resource.IconURI,
+			OwnerManagedAccess: resource.OwnerManagedAccess,
+			Uris:               resource.Uris,
+		}
+		for key, values := range resource.Attributes {
+			if representation.Attributes == nil {
+				representation.Attributes = map[string]string{}
+			}
+			representation.Attributes[key] = values[0]
+		}
+		for _, scope := range a.resourceRepresentation(resource).Scopes {
+			representation.Scopes = append(representation.Scopes, apiextensionsv1.JSON{Raw: scope})
+		}
+		settings.Resources = append(settings.Resources, representation)
+	}
+	for _, policy := range a.policies {
+		representation := policy.representation
+		for _, id := range policy.resourceIDs {
+			representation.Resources = append(representation.Resources, a.resource(id).Name)
+		}
+		for _, id := range policy.scopeIDs {
+			representation.Scopes = append(representation.Scopes, a.scope(id).Name)
+		}
+		for _, id := range policy.policyIDs {
+			representation.Policies = append(representation.Policies, a.policy(id).representation.Name)
+		}
+		settings.Policies = append(settings.Policies, representation)
+	}
+	return &settings
+}
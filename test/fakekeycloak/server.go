@@ -0,0 +1,514 @@
+// Package fakekeycloak provides an in-process fake of the Keycloak admin REST API for tests.
+//
+// The fake implements the subset of the API used by common.KeycloakInterface, i.e. the token endpoint, realms,
+// clients, client secrets and their rotation, client roles, protocol mappers, authorization services, installation providers,
+// scope mappings, client scopes, service account users and their role mappings. All state
+// is kept in memory, so controller tests can run complete create, update and delete flows without a cluster or a
+// real Keycloak.
+package fakekeycloak
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	// AdminUser and AdminPassword are the credentials of the admin user of the master realm
+	AdminUser     = "admin"
+	AdminPassword = "admin"
+	// MasterRealm is the realm the admin user belongs to
+	MasterRealm = "master"
+
+	// DefaultTokenLifespan is the lifespan of the access tokens issued by the fake
+	DefaultTokenLifespan = 5 * time.Minute
+	// DefaultVersion is the Keycloak version reported by the fake
+	DefaultVersion = "26.0.5"
+)
+
+var (
+	// default and optional client scopes every realm is created with, like in a fresh Keycloak
+	realmDefaultClientScopes  = []string{"profile", "email", "roles", "web-origins"}
+	realmOptionalClientScopes = []string{"address", "phone", "offline_access"}
+	// realm roles every realm is created with besides its default role
+	realmRoles = []string{"offline_access", "uma_authorization"}
+	// protocol mapper types reported in the server info
+	protocolMapperTypes = map[string][]string{
+		"openid-connect": {"oidc-audience-mapper", "oidc-hardcoded-claim-mapper", "oidc-usermodel-attribute-mapper"},
+		"saml":           {"saml-hardcode-attribute-mapper", "saml-user-attribute-mapper"},
+	}
+)
+
+// Server is a fake Keycloak served by an httptest.Server
+type Server struct {
+	*httptest.Server
+
+	// ContextPath the fake is served from, "" like Keycloak 17+ or "/auth" like Keycloak 16 and older
+	ContextPath string
+	// TokenLifespan is the lifespan of the access tokens issued from now on
+	TokenLifespan time.Duration
+	// Version reported in the server info. Realms created with version 25 or newer get the basic client scope.
+	Version string
+	// Features reported in the server info
+	Features []v1alpha1.KeycloakFeature
+
+	mu       sync.Mutex
+	realms   map[string]*realm
+	tokens   map[string]token
+	failures map[string]int
+	delays   map[string]time.Duration
+	requests []string
+}
+
+type token struct {
+	realm  string
+	expiry time.Time
+}
+
+type realm struct {
+	// server the realm belongs to, for its features
+	server         *Server
+	representation v1alpha1.KeycloakAPIRealm
+	clients        map[string]*client
+	clientScopes   []v1alpha1.KeycloakClientScope
+	// names of the default client scopes of new clients
+	defaultClientScopes []string
+	roles               map[string]*role
+	// IDs of the composite roles of a role by the ID of the role
+	composites map[string][]string
+	users      map[string]*user
+}
+
+type client struct {
+	representation v1alpha1.KeycloakAPIClient
+	// IDs of the roles in the scope of the client
+	scopeMappings        []string
+	defaultClientScopes  []string
+	optionalClientScopes []string
+	serviceAccountUserID string
+	// previous secret kept valid after a rotation of the secret
+	rotatedSecret string
+	// resource server of a client with authorization services enabled
+	authorization *authorization
+}
+
+type role struct {
+	representation v1alpha1.RoleRepresentation
+	// ID of the client of a client role, empty for realm roles
+	clientID string
+}
+
+type user struct {
+	representation v1alpha1.KeycloakAPIUser
+	// IDs of the roles assigned to the user
+	roles               []string
+	federatedIdentities []v1alpha1.FederatedIdentity
+}
+
+// NewServer starts a fake Keycloak with a master realm served from the root context path.
+// The server must be closed after use.
+func NewServer() *Server {
+	return NewServerWithContextPath("")
+}
+
+// NewServerWithContextPath starts a fake Keycloak with a master realm served from the given context path
+func NewServerWithContextPath(contextPath string) *Server {
+	s := &Server{
+		ContextPath:   contextPath,
+		TokenLifespan: DefaultTokenLifespan,
+		Version:       DefaultVersion,
+		Features:      []v1alpha1.KeycloakFeature{{Name: "ADMIN_FINE_GRAINED_AUTHZ", Enabled: false}, {Name: "TOKEN_EXCHANGE", Enabled: true}},
+		realms:        map[string]*realm{},
+		tokens:        map[string]token{},
+		failures:      map[string]int{},
+		delays:        map[string]time.Duration{},
+	}
+	s.addRealm(v1alpha1.KeycloakAPIRealm{Realm: MasterRealm, Enabled: true})
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddRealm creates a realm with default roles and client scopes
+func (s *Server) AddRealm(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addRealm(v1alpha1.KeycloakAPIRealm{Realm: name, Enabled: true})
+}
+
+// AddClient creates a client in a realm and returns its ID
+func (s *Server) AddClient(realmName string, representation v1alpha1.KeycloakAPIClient) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.realms[realmName]
+	if !ok {
+		return "", fmt.Errorf("realm %s not found", realmName)
+	}
+	c, status, msg := r.addClient(representation)
+	if c == nil {
+		return "", fmt.Errorf("(%d) %s", status, msg)
+	}
+	return c.representation.ID, nil
+}
+
+// AddRealmRole creates a realm role and returns its ID
+func (s *Server) AddRealmRole(realmName, roleName string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.realms[realmName]
+	if !ok {
+		return "", fmt.Errorf("realm %s not found", realmName)
+	}
+	return r.addRole(v1alpha1.RoleRepresentation{Name: roleName}, "").representation.ID, nil
+}
+
+// Realm returns the representation of a realm, or nil if it does not exist
+func (s *Server) Realm(name string) *v1alpha1.KeycloakAPIRealm {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.realms[name]
+	if !ok {
+		return nil
+	}
+	representation := r.representation
+	return &representation
+}
+
+// Client returns the representation of the client with the given clientId, or nil if it does not exist
+func (s *Server) Client(realmName, clientID string) *v1alpha1.KeycloakAPIClient {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.realms[realmName]
+	if !ok {
+		return nil
+	}
+	c := r.clientByClientID(clientID)
+	if c == nil {
+		return nil
+	}
+	representation := c.representation
+	return &representation
+}
+
+// ClientRoles returns the names of the roles of the client with the given clientId
+func (s *Server) ClientRoles(realmName, clientID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	if r, ok := s.realms[realmName]; ok {
+		if c := r.clientByClientID(clientID); c != nil {
+			for _, role := range r.clientRoles(c.representation.ID) {
+				names = append(names, role.representation.Name)
+			}
+		}
+	}
+	return names
+}
+
+// AuthorizationSettings returns the resource server of a client with its scopes, resources and policies, or nil
+// if the client does not exist or has authorization services disabled
+func (s *Server) AuthorizationSettings(realmName, clientID string) *v1alpha1.KeycloakResourceServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.realms[realmName]
+	if !ok {
+		return nil
+	}
+	c := r.clientByClientID(clientID)
+	if c == nil || c.authorization == nil {
+		return nil
+	}
+	return c.authorization.representation()
+}
+
+// ClientRotatedSecret returns the previous secret of the client with the given clientId which is still valid after
+// a rotation, empty if there is none
+func (s *Server) ClientRotatedSecret(realmName, clientID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.realms[realmName]; ok {
+		if c := r.clientByClientID(clientID); c != nil {
+			return c.rotatedSecret
+		}
+	}
+	return ""
+}
+
+// ServiceAccountRoles returns the names of the roles assigned to the service account of the client with the given
+// clientId. Client roles are prefixed with the clientId of their client, e.g. "realm-management/view-users".
+func (s *Server) ServiceAccountRoles(realmName, clientID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	r, ok := s.realms[realmName]
+	if !ok {
+		return nil
+	}
+	c := r.clientByClientID(clientID)
+	if c == nil || c.serviceAccountUserID == "" {
+		return nil
+	}
+	for _, roleID := range r.users[c.serviceAccountUserID].roles {
+		names = append(names, r.qualifiedRoleName(roleID))
+	}
+	return names
+}
+
+// Fail lets all following requests with the given method and path, relative to the context path, fail with the
+// given status, e.g. Fail(http.MethodPost, "/admin/realms/test/clients", http.StatusInternalServerError).
+// A status of zero removes the failure.
+func (s *Server) Fail(method, path string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if status == 0 {
+		delete(s.failures, method+" "+path)
+		return
+	}
+	s.failures[method+" "+path] = status
+}
+
+// Delay lets all following requests with the given method and path, relative to the context path, be answered only
+// after the given delay or when the client gives up, like an overloaded Keycloak. A delay of zero removes the delay.
+func (s *Server) Delay(method, path string, delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if delay == 0 {
+		delete(s.delays, method+" "+path)
+		return
+	}
+	s.delays[method+" "+path] = delay
+}
+
+// RevokeTokens invalidates all issued tokens, like a restart of Keycloak or the revocation of all sessions
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = map[string]token{}
+}
+
+// Requests returns the requests received so far as "METHOD path", with paths relative to the context path
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	delay := s.delays[req.Method+" "+strings.TrimPrefix(req.URL.Path, s.ContextPath)]
+	s.mu.Unlock()
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(req.URL.Path, s.ContextPath) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	path := strings.TrimPrefix(req.URL.Path, s.ContextPath)
+	s.requests = append(s.requests, req.Method+" "+path)
+
+	if status, ok := s.failures[req.Method+" "+path]; ok {
+		writeError(w, status, "injected failure")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case path == "" || path == "/":
+		w.WriteHeader(http.StatusOK)
+	case len(segments) == 5 && segments[0] == "realms" && segments[2] == "protocol" && segments[4] == "token":
+		s.serveToken(w, req, segments[1])
+	case len(segments) == 4 && segments[0] == "realms" && segments[2] == ".well-known":
+		s.serveWellKnown(w, segments[1])
+	case len(segments) == 2 && segments[0] == "admin" && segments[1] == "serverinfo":
+		if !s.authorized(req) {
+			writeError(w, http.StatusUnauthorized, "HTTP 401 Unauthorized")
+			return
+		}
+		s.serveServerInfo(w)
+	case len(segments) >= 2 && segments[0] == "admin" && segments[1] == "realms":
+		if !s.authorized(req) {
+			writeError(w, http.StatusUnauthorized, "HTTP 401 Unauthorized")
+			return
+		}
+		s.serveAdmin(w, req, segments[2:])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) serveWellKnown(w http.ResponseWriter, realmName string) {
+	if _, ok := s.realms[realmName]; !ok {
+		writeError(w, http.StatusNotFound, "Realm does not exist")
+		return
+	}
+	issuer := fmt.Sprintf("%s%s/realms/%s", s.URL, s.ContextPath, realmName)
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 issuer,
+		"authorization_endpoint": issuer + "/protocol/openid-connect/auth",
+		"token_endpoint":         issuer + "/protocol/openid-connect/token",
+		"userinfo_endpoint":      issuer + "/protocol/openid-connect/userinfo",
+		"jwks_uri":               issuer + "/protocol/openid-connect/certs",
+	})
+}
+
+func (s *Server) serveServerInfo(w http.ResponseWriter) {
+	serverInfo := v1alpha1.KeycloakServerInfo{
+		SystemInfo:          v1alpha1.KeycloakSystemInfo{Version: s.Version},
+		Features:            s.Features,
+		ProtocolMapperTypes: map[string][]v1alpha1.KeycloakProtocolMapperType{},
+	}
+	for protocol, ids := range protocolMapperTypes {
+		for _, id := range ids {
+			serverInfo.ProtocolMapperTypes[protocol] = append(serverInfo.ProtocolMapperTypes[protocol], v1alpha1.KeycloakProtocolMapperType{ID: id})
+		}
+	}
+	writeJSON(w, http.StatusOK, serverInfo)
+}
+
+func (s *Server) serveToken(w http.ResponseWriter, req *http.Request, realmName string) {
+	r, ok := s.realms[realmName]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Realm does not exist")
+		return
+	}
+	if err := req.ParseForm(); err != nil {
+		writeTokenError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	switch req.PostForm.Get("grant_type") {
+	case "password":
+		if realmName != MasterRealm || req.PostForm.Get("username") != AdminUser || req.PostForm.Get("password") != AdminPassword {
+			writeTokenError(w, http.StatusUnauthorized, "invalid_grant", "Invalid user credentials")
+			return
+		}
+	case "client_credentials":
+		c := r.clientByClientID(req.PostForm.Get("client_id"))
+		if c == nil || !c.representation.ServiceAccountsEnabled || c.representation.Secret != req.PostForm.Get("client_secret") {
+			writeTokenError(w, http.StatusUnauthorized, "unauthorized_client", "Invalid client or Invalid client credentials")
+			return
+		}
+	case "refresh_token":
+		if !s.valid(req.PostForm.Get("refresh_token")) {
+			writeTokenError(w, http.StatusBadRequest, "invalid_grant", "Invalid refresh token")
+			return
+		}
+	default:
+		writeTokenError(w, http.StatusBadRequest, "unsupported_grant_type", "Unsupported grant_type")
+		return
+	}
+
+	expiresIn := int(s.TokenLifespan / time.Second)
+	writeJSON(w, http.StatusOK, v1alpha1.TokenResponse{
+		AccessToken:      s.issueToken(realmName),
+		ExpiresIn:        expiresIn,
+		RefreshToken:     s.issueToken(realmName),
+		RefreshExpiresIn: 2 * expiresIn,
+		TokenType:        "Bearer",
+	})
+}
+
+func (s *Server) issueToken(realmName string) string {
+	value := string(uuid.NewUUID())
+	s.tokens[value] = token{realm: realmName, expiry: time.Now().Add(s.TokenLifespan)}
+	return value
+}
+
+func (s *Server) valid(value string) bool {
+	t, ok := s.tokens[value]
+	return ok && time.Now().Before(t.expiry)
+}
+
+func (s *Server) authorized(req *http.Request) bool {
+	return s.valid(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+}
+
+func (s *Server) addRealm(representation v1alpha1.KeycloakAPIRealm) *realm {
+	if representation.ID == "" {
+		representation.ID = representation.Realm
+	}
+	r := &realm{
+		server:         s,
+		representation: representation,
+		clients:        map[string]*client{},
+		roles:          map[string]*role{},
+		composites:     map[string][]string{},
+		users:          map[string]*user{},
+	}
+
+	defaultRole := r.addRole(v1alpha1.RoleRepresentation{Name: "default-roles-" + representation.Realm}, "")
+	for _, name := range realmRoles {
+		roleID := r.addRole(v1alpha1.RoleRepresentation{Name: name}, "").representation.ID
+		r.composites[defaultRole.representation.ID] = append(r.composites[defaultRole.representation.ID], roleID)
+	}
+	r.representation.DefaultRole = &v1alpha1.RoleRepresentation{
+		ID:   defaultRole.representation.ID,
+		Name: defaultRole.representation.Name,
+	}
+
+	r.defaultClientScopes = append(s.basicClientScope(), realmDefaultClientScopes...)
+	for _, name := range append(append([]string{}, r.defaultClientScopes...), realmOptionalClientScopes...) {
+		r.clientScopes = append(r.clientScopes, v1alpha1.KeycloakClientScope{
+			ID:       string(uuid.NewUUID()),
+			Name:     name,
+			Protocol: "openid-connect",
+		})
+	}
+	r.representation.ClientScopes = nil
+
+	s.realms[representation.Realm] = r
+	return r
+}
+
+// featureEnabled returns true if the feature is reported as enabled in the server info
+func (s *Server) featureEnabled(name string) bool {
+	for _, feature := range s.Features {
+		if feature.Name == name {
+			return feature.Enabled
+		}
+	}
+	return false
+}
+
+// basicClientScope returns the basic client scope if the version of the fake provides it
+func (s *Server) basicClientScope() []string {
+	major, _, _ := strings.Cut(s.Version, ".")
+	if version, err := strconv.Atoi(major); err == nil && version >= 25 {
+		return []string{"basic"}
+	}
+	return []string{}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"errorMessage": msg})
+}
+
+func writeTokenError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{"error": code, "error_description": description})
+}
+
+func writeCreated(w http.ResponseWriter, req *http.Request, id string) {
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", strings.TrimSuffix(req.URL.String(), "/"), id))
+	w.WriteHeader(http.StatusCreated)
+}
@@ -0,0 +1,375 @@
+package fakekeycloak
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/movewp3/keycloakclient-controller/api/v1alpha1"
+	"github.com/movewp3/keycloakclient-controller/pkg/common"
+	"github.com/movewp3/keycloakclient-controller/pkg/model"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// authenticatedClient returns a client of the admin API of the server, created like the controllers do
+func authenticatedClient(t *testing.T, server *Server) common.KeycloakInterface {
+	keycloak := v1alpha1.Keycloak{
+		ObjectMeta: v13.ObjectMeta{Name: "keycloak", Namespace: "test"},
+		Spec:       v1alpha1.KeycloakSpec{External: v1alpha1.KeycloakExternal{Enabled: true}},
+		Status:     v1alpha1.KeycloakStatus{ExternalURL: server.URL},
+	}
+	credentials := &v1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "credential-keycloak", Namespace: "test"},
+		Data: map[string][]byte{
+			model.AdminUsernameProperty: []byte(AdminUser),
+			model.AdminPasswordProperty: []byte(AdminPassword),
+		},
+	}
+	factory := &common.LocalConfigKeycloakFactory{Client: fake.NewClientBuilder().WithObjects(credentials).Build()}
+
+	client, err := factory.AuthenticatedClient(context.TODO(), keycloak, false)
+	assert.NoError(t, err)
+	return client
+}
+
+func TestServer_realms(t *testing.T) {
+	// given
+	server := NewServer()
+	defer server.Close()
+	client := authenticatedClient(t, server)
+	realm := &v1alpha1.KeycloakRealm{Spec: v1alpha1.KeycloakRealmSpec{Realm: &v1alpha1.KeycloakAPIRealm{Realm: "test", Enabled: true}}}
+
+	// when
+	_, err := client.CreateRealm(context.TODO(), realm)
+
+	// then
+	assert.NoError(t, err)
+	created, err := client.GetRealm(context.TODO(), "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", created.Spec.Realm.Realm)
+	assert.Equal(t, "default-roles-test", created.Spec.Realm.DefaultRole.Name)
+	assert.Len(t, created.Spec.Realm.ClientScopes, 8)
+
+	// when
+	_, err = client.CreateRealm(context.TODO(), realm)
+
+	// then
+	assert.True(t, common.IsConflict(err))
+
+	// when
+	err = client.DeleteRealm(context.TODO(), "test")
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, server.Realm("test"))
+	missing, err := client.GetRealm(context.TODO(), "test")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestServer_clients(t *testing.T) {
+	// given
+	server := NewServer()
+	defer server.Close()
+	server.AddRealm("test")
+	client := authenticatedClient(t, server)
+	apiClient := &v1alpha1.KeycloakAPIClient{ClientID: "app", ServiceAccountsEnabled: true}
+
+	// when
+	id, err := client.CreateClient(context.TODO(), apiClient, "test")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, id, server.Client("test", "app").ID)
+	found, err := client.GetClientID(context.TODO(), "app", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, id, found)
+	secret, err := client.GetClientSecret(context.TODO(), id, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, server.Client("test", "app").Secret, secret)
+	defaultClientScopes, err := client.ListDefaultClientScopes(context.TODO(), id, "test")
+	assert.NoError(t, err)
+	assert.Len(t, defaultClientScopes, 5)
+
+	// when
+	_, err = client.CreateClient(context.TODO(), apiClient, "test")
+
+	// then
+	assert.True(t, common.IsConflict(err))
+
+	// when
+	apiClient.ID = id
+	apiClient.Description = "updated"
+	err = client.UpdateClient(context.TODO(), apiClient, "test")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", server.Client("test", "app").Description)
+
+	// when
+	err = client.DeleteClient(context.TODO(), id, "test")
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, server.Client("test", "app"))
+}
+
+func TestServer_clientSecretRotation(t *testing.T) {
+	// given
+	server := NewServer()
+	defer server.Close()
+	server.AddRealm("test")
+	client := authenticatedClient(t, server)
+	id, err := server.AddClient("test", v1alpha1.KeycloakAPIClient{ClientID: "app", Secret: "initial"})
+	assert.NoError(t, err)
+
+	// when the client secret rotation is disabled
+	secret, err := client.RegenerateClientSecret(context.TODO(), id, "test")
+
+	// then the previous secret is not kept
+	assert.NoError(t, err)
+	assert.NotEqual(t, "initial", secret)
+	assert.Equal(t, secret, server.Client("test", "app").Secret)
+	rotated, err := client.GetClientRotatedSecret(context.TODO(), id, "test")
+	assert.NoError(t, err)
+	assert.Empty(t, rotated)
+
+	// when
+	server.Features = append(server.Features, v1alpha1.KeycloakFeature{Name: "CLIENT_SECRET_ROTATION", Enabled: true})
+	regenerated, err := client.RegenerateClientSecret(context.TODO(), id, "test")
+
+	// then
+	assert.NoError(t, err)
+	rotated, err = client.GetClientRotatedSecret(context.TODO(), id, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, secret, rotated)
+	assert.Equal(t, regenerated, server.Client("test", "app").Secret)
+
+	// when
+	err = client.InvalidateClientRotatedSecret(context.TODO(), id, "test")
+
+	// then
+	assert.NoError(t, err)
+	assert.Empty(t, server.ClientRotatedSecret("test", "app"))
+}
+
+func TestServer_authorization(t *testing.T) {
+	// given
+	server := NewServer()
+	defer server.Close()
+	server.AddRealm("test")
+	client := authenticatedClient(t, server)
+	id, err := client.CreateClient(context.TODO(), &v1alpha1.KeycloakAPIClient{ClientID: "app", AuthorizationServicesEnabled: true}, "test")
+	assert.NoError(t, err)
+
+	// when
+	settings, err := client.GetAuthorizationSettings(context.TODO(), id, "test")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "ENFORCING", settings.PolicyEnforcementMode)
+	policies, err := client.ListAuthorizationPolicies(context.TODO(), id, "test")
+	assert.NoError(t, err)
+	assert.Len(t, policies, 2)
+	assert.Equal(t, "Default Permission", policies[1].Name)
+	assert.Equal(t, []string{"Default Policy"}, policies[1].Policies)
+
+	// when
+	settings.PolicyEnforcementMode = "PERMISSIVE"
+	assert.NoError(t, client.UpdateAuthorizationSettings(context.TODO(), id, settings, "test"))
+	assert.NoError(t, client.CreateAuthorizationScope(context.TODO(), id, &v1alpha1.KeycloakScope{Name: "view"}, "test"))
+	assert.NoError(t, client.CreateAuthorizationResource(context.TODO(), id, &v1alpha1.KeycloakResource{
+		Name:       "documents",
+		Attributes: map[string]string{"owner": "team"},
+		Scopes:     []apiextensionsv1.JSON{{Raw: []byte(`{"name":"view"}`)}},
+	}, "test"))
+	assert.NoError(t, client.CreateAuthorizationPolicy(context.TODO(), id, &v1alpha1.KeycloakPolicy{
+		Name:      "documents permission",
+		Type:      "scope",
+		Resources: []string{"documents"},
+		Scopes:    []string{"view"},
+		Policies:  []string{"Default Policy"},
+	}, "test"))
+
+	// then
+	assert.Equal(t, "PERMISSIVE", server.AuthorizationSettings("test", "app").PolicyEnforcementMode)
+	resources, err := client.ListAuthorizationResources(context.TODO(), id, "test")
+	assert.NoError(t, err)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, "team", resources[1].Attributes["owner"])
+	assert.JSONEq(t, `{"id":"`+server.AuthorizationSettings("test", "app").Scopes[0].ID+`","name":"view"}`, string(resources[1].Scopes[0].Raw))
+	policies, err = client.ListAuthorizationPolicies(context.TODO(), id, "test")
+	assert.NoError(t, err)
+	assert.Len(t, policies, 3)
+	assert.Equal(t, []string{"documents"}, policies[2].Resources)
+	assert.Equal(t, []string{"view"}, policies[2].Scopes)
+	assert.Equal(t, []string{"Default Policy"}, policies[2].Policies)
+
+	// when
+	scopes, err := client.ListAuthorizationScopes(context.TODO(), id, "test")
+	assert.NoError(t, err)
+	assert.NoError(t, client.DeleteAuthorizationScope(context.TODO(), id, scopes[0].ID, "test"))
+	assert.NoError(t, client.DeleteAuthorizationResource(context.TODO(), id, resources[0].ID, "test"))
+	assert.NoError(t, client.DeleteAuthorizationPolicy(context.TODO(), id, policies[0].ID, "test"))
+
+	// then
+	settings = server.AuthorizationSettings("test", "app")
+	assert.Empty(t, settings.Scopes)
+	assert.Len(t, settings.Resources, 1)
+	assert.Empty(t, settings.Resources[0].Scopes)
+	assert.Len(t, settings.Policies, 2)
+	assert.Equal(t, []string{"documents"}, settings.Policies[1].Resources)
+	assert.Empty(t, settings.Policies[1].Scopes)
+	assert.Empty(t, settings.Policies[1].Policies)
+
+	// when
+	err = client.CreateAuthorizationPolicy(context.TODO(), id, &v1alpha1.KeycloakPolicy{Name: "unknown", Type: "resource", Resources: []string{"unknown"}}, "test")
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestServer_roles(t *testing.T) {
+	// given
+	server := NewServer()
+	defer server.Close()
+	server.AddRealm("test")
+	client := authenticatedClient(t, server)
+	appID, err := server.AddClient("test", v1alpha1.KeycloakAPIClient{ClientID: "app", ServiceAccountsEnabled: true})
+	assert.NoError(t, err)
+	apiID, err := server.AddClient("test", v1alpha1.KeycloakAPIClient{ClientID: "api"})
+	assert.NoError(t, err)
+
+	// when
+	_, err = client.CreateClientRole(context.TODO(), apiID, &v1alpha1.RoleRepresentation{Name: "read"}, "test")
+
+	// then
+	assert.NoError(t, err)
+	roles, err := client.ListClientRoles(context.TODO(), apiID, "test")
+	assert.NoError(t, err)
+	assert.Len(t, roles, 1)
+	assert.Equal(t, []string{"read"}, server.ClientRoles("test", "api"))
+
+	// when
+	err = client.CreateClientClientScopeMappings(context.TODO(), &v1alpha1.KeycloakAPIClient{ID: appID}, &v1alpha1.ClientMappingsRepresentation{ID: apiID, Mappings: roles}, "test")
+
+	// then
+	assert.NoError(t, err)
+	mappings, err := client.ListScopeMappings(context.TODO(), appID, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "read", mappings.ClientMappings["api"].Mappings[0].Name)
+
+	// when
+	user, err := client.GetServiceAccountUser(context.TODO(), "test", appID)
+	assert.NoError(t, err)
+	_, err = client.CreateUserClientRole(context.TODO(), &v1alpha1.KeycloakUserRole{ID: roles[0].ID, Name: "read"}, "test", apiID, user.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Contains(t, server.ServiceAccountRoles("test", "app"), "api/read")
+
+	// when
+	err = client.DeleteClientRole(context.TODO(), apiID, "read", "test")
+
+	// then
+	assert.NoError(t, err)
+	assert.Empty(t, server.ClientRoles("test", "api"))
+	assert.NotContains(t, server.ServiceAccountRoles("test", "app"), "api/read")
+}
+
+func TestServer_unknownRolesAreRejected(t *testing.T) {
+	// given
+	server := NewServer()
+	defer server.Close()
+	server.AddRealm("test")
+	client := authenticatedClient(t, server)
+	appID, err := server.AddClient("test", v1alpha1.KeycloakAPIClient{ClientID: "app"})
+	assert.NoError(t, err)
+
+	// when
+	err = client.CreateClientRealmScopeMappings(context.TODO(), &v1alpha1.KeycloakAPIClient{ID: appID}, &[]v1alpha1.RoleRepresentation{{ID: "unknown"}}, "test")
+
+	// then
+	assert.True(t, common.IsNotFound(err))
+}
+
+func TestServer_failures(t *testing.T) {
+	// given
+	server := NewServer()
+	defer server.Close()
+	server.AddRealm("test")
+	client := authenticatedClient(t, server)
+	server.Fail(http.MethodGet, "/admin/realms/test", http.StatusForbidden)
+
+	// when
+	_, err := client.GetRealm(context.TODO(), "test")
+
+	// then
+	assert.True(t, common.IsForbidden(err))
+	assert.Contains(t, server.Requests(), "GET /admin/realms/test")
+
+	// when
+	server.Fail(http.MethodGet, "/admin/realms/test", 0)
+	_, err = client.GetRealm(context.TODO(), "test")
+
+	// then
+	assert.NoError(t, err)
+}
+
+func TestServer_expiredTokensAreRefreshed(t *testing.T) {
+	// given
+	server := NewServer()
+	defer server.Close()
+	server.AddRealm("test")
+	client := authenticatedClient(t, server)
+	server.RevokeTokens()
+
+	// when
+	realm, err := client.GetRealm(context.TODO(), "test")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "test", realm.Spec.Realm.Realm)
+}
+
+func TestServer_legacyContextPath(t *testing.T) {
+	// given
+	server := NewServerWithContextPath(common.LegacyContextPath)
+	defer server.Close()
+	server.AddRealm("test")
+	client := authenticatedClient(t, server)
+
+	// when
+	realm, err := client.GetRealm(context.TODO(), "test")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "test", realm.Spec.Realm.Realm)
+	assert.Contains(t, server.Requests(), "GET /admin/realms/test")
+}
+
+func TestServer_serverInfo(t *testing.T) {
+	// given
+	server := NewServer()
+	defer server.Close()
+	server.Version = "24.0.5"
+	server.AddRealm("test")
+	client := authenticatedClient(t, server)
+
+	// when
+	serverInfo, err := client.GetServerInfo(context.TODO())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "24.0.5", serverInfo.SystemInfo.Version)
+	assert.NotEmpty(t, serverInfo.ProtocolMapperTypes["openid-connect"])
+	realm, err := client.GetRealm(context.TODO(), "test")
+	assert.NoError(t, err)
+	for _, clientScope := range realm.Spec.Realm.ClientScopes {
+		assert.NotEqual(t, "basic", clientScope.Name)
+	}
+}